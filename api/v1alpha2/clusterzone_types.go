@@ -20,8 +20,10 @@ import (
 // +kubebuilder:resource:scope=Cluster
 
 // +kubebuilder:printcolumn:name="Serial",type="integer",JSONPath=".status.serial"
+// +kubebuilder:printcolumn:name="Serial Strategy",type="string",JSONPath=".status.soa_edit_api",priority=1
 // +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="Records",type="string",JSONPath=`.status.conditions[?(@.type=="RecordsReady")].message`
 // ClusterZone is the Schema for the clusterzones API
 type ClusterZone struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,89 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DomainVerificationSpec defines the desired state of DomainVerification
+type DomainVerificationSpec struct {
+	// ZoneRef reference the zone the verification record is created in.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Name of the verification record, relative to the zone or canonical. Defaults to the zone apex.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Name *string `json:"name,omitempty"`
+	// Verification string given by the provider (e.g. "google-site-verification=...", "MS=ms12345678"),
+	// published as a TXT record and quoted by the operator.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Value string `json:"value"`
+	// DNS TTL of the verification record, in seconds. Defaults to the --default-ttl of the operator.
+	// +optional
+	TTL *uint32 `json:"ttl,omitempty"`
+	// Duration, from the creation of the DomainVerification, after which the verification record is removed.
+	// The record is kept as long as the DomainVerification exists if not specified.
+	// +optional
+	ExpiresAfter *metav1.Duration `json:"expiresAfter,omitempty"`
+}
+
+// DomainVerificationStatus defines the observed state of DomainVerification
+type DomainVerificationStatus struct {
+	// Names of the RRsets generated, in the namespace of the DomainVerification.
+	// +optional
+	RRsets []string `json:"rrsets,omitempty"`
+	// Time the verification record is removed at.
+	// +optional
+	ExpiresAt          *metav1.Time       `json:"expiresAt,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Expires At",type="date",JSONPath=".status.expiresAt"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// DomainVerification is the Schema for the domainverifications API
+type DomainVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DomainVerificationSpec   `json:"spec,omitempty"`
+	Status DomainVerificationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DomainVerificationList contains a list of DomainVerification
+type DomainVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainVerification `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DomainVerification{}, &DomainVerificationList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (d *DomainVerification) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return d.Status.ObservedGeneration != nil &&
+		*d.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		d.Status.SyncStatus != nil &&
+		*d.Status.SyncStatus == expectedSyncStatus
+}
@@ -0,0 +1,152 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MailDomainSpec defines the desired state of MailDomain
+type MailDomainSpec struct {
+	// ZoneRef reference the zone the mail records are created in.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Name of the mail domain, relative to the zone or canonical. Defaults to the zone apex.
+	// +optional
+	Name *string `json:"name,omitempty"`
+	// DNS TTL of the mail records, in seconds. Defaults to the --default-ttl of the operator.
+	// +optional
+	TTL *uint32 `json:"ttl,omitempty"`
+	// Mail servers of the domain, published as MX records.
+	// +optional
+	MX []MailServer `json:"mx,omitempty"`
+	// SPF policy of the domain, published as a TXT record.
+	// +optional
+	SPF *SPFPolicy `json:"spf,omitempty"`
+	// DKIM selectors of the domain, published as TXT records under "_domainkey".
+	// +optional
+	// +listType=map
+	// +listMapKey=selector
+	DKIM []DKIMSelector `json:"dkim,omitempty"`
+	// DMARC policy of the domain, published as a TXT record under "_dmarc".
+	// +optional
+	DMARC *DMARCPolicy `json:"dmarc,omitempty"`
+}
+
+// MailServer defines a mail server of a domain
+type MailServer struct {
+	// Host name of the mail server, canonical or relative to the zone.
+	Host string `json:"host"`
+	// Preference of the mail server, lowest first.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Preference uint16 `json:"preference"`
+}
+
+// SPFPolicy defines the senders authorized by the SPF record (RFC 7208) of a domain
+type SPFPolicy struct {
+	// Authorize the mail servers of the domain ("mx" mechanism), defaults to true.
+	// +optional
+	MX *bool `json:"mx,omitempty"`
+	// IPv4 and IPv6 addresses or networks authorized to send mails ("ip4" and "ip6" mechanisms).
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+	// Domains whose SPF policy is included ("include" mechanism), e.g. "_spf.google.com".
+	// +optional
+	Includes []string `json:"includes,omitempty"`
+	// Result for the other senders: Fail ("-all"), SoftFail ("~all") or Neutral ("?all"), defaults to SoftFail.
+	// +optional
+	// +kubebuilder:validation:Enum:=Fail;SoftFail;Neutral
+	All *string `json:"all,omitempty"`
+}
+
+// DKIMSelector defines a DKIM public key (RFC 6376) of a domain
+type DKIMSelector struct {
+	// Selector of the key, the record is published as "<selector>._domainkey.<name>".
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`
+	Selector string `json:"selector"`
+	// Type of the key, defaults to rsa.
+	// +optional
+	// +kubebuilder:validation:Enum:=rsa;ed25519
+	KeyType *string `json:"keyType,omitempty"`
+	// Public key, base64 encoded.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9+/=]+$`
+	PublicKey string `json:"publicKey"`
+}
+
+// DMARCPolicy defines the DMARC policy (RFC 7489) of a domain
+type DMARCPolicy struct {
+	// Policy applied to the mails failing the DMARC checks.
+	// +kubebuilder:validation:Enum:=none;quarantine;reject
+	Policy string `json:"policy"`
+	// Policy applied to the subdomains, defaults to the policy.
+	// +optional
+	// +kubebuilder:validation:Enum:=none;quarantine;reject
+	SubdomainPolicy *string `json:"subdomainPolicy,omitempty"`
+	// Percentage of the mails the policy is applied to, defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+	// URIs the aggregate reports are sent to (e.g. "mailto:dmarc@example.org").
+	// +optional
+	AggregateReports []string `json:"aggregateReports,omitempty"`
+	// URIs the failure reports are sent to.
+	// +optional
+	FailureReports []string `json:"failureReports,omitempty"`
+}
+
+// MailDomainStatus defines the observed state of MailDomain
+type MailDomainStatus struct {
+	// Names of the RRsets generated, in the namespace of the MailDomain.
+	// +optional
+	RRsets             []string           `json:"rrsets,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// MailDomain is the Schema for the maildomains API
+type MailDomain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MailDomainSpec   `json:"spec,omitempty"`
+	Status MailDomainStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MailDomainList contains a list of MailDomain
+type MailDomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MailDomain `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MailDomain{}, &MailDomainList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (m *MailDomain) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return m.Status.ObservedGeneration != nil &&
+		*m.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		m.Status.SyncStatus != nil &&
+		*m.Status.SyncStatus == expectedSyncStatus
+}
@@ -16,23 +16,120 @@ import (
 )
 
 // RRsetSpec defines the desired state of RRset
+// +kubebuilder:validation:XValidation:rule="has(self.records) || has(self.naptr) || has(self.targetRef) || (has(self.ensure) && self.ensure == 'Absent')",message="records or targetRef are required unless ensure is Absent"
+// +kubebuilder:validation:XValidation:rule="!has(self.targetRef) || !has(self.records)",message="records and targetRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.targetRef) || self.type in ['A', 'AAAA']",message="targetRef requires the A or AAAA type"
+// +kubebuilder:validation:XValidation:rule="!has(self.targetRef) || !has(self.strategy) || self.strategy != 'Merge'",message="targetRef is not supported with the Merge strategy"
+// +kubebuilder:validation:XValidation:rule="!has(self.activeFrom) || !has(self.activeUntil) || self.activeFrom < self.activeUntil",message="activeUntil must be after activeFrom"
+// +kubebuilder:validation:XValidation:rule="(!has(self.activeFrom) && !has(self.activeUntil)) || ((!has(self.strategy) || self.strategy == 'Replace') && (!has(self.ensure) || self.ensure == 'Present'))",message="activeFrom and activeUntil require the Replace strategy and the Present ensure"
+// +kubebuilder:validation:XValidation:rule="!has(self.naptr) || self.type == 'NAPTR'",message="naptr requires the NAPTR type"
+// +kubebuilder:validation:XValidation:rule="!has(self.records) || !(self.type in ['CNAME', 'DNAME', 'SOA']) || size(self.records) == 1",message="CNAME, DNAME and SOA RRsets must contain exactly one record"
+// +kubebuilder:validation:XValidation:rule="!has(self.removedRecords) || (has(self.strategy) && self.strategy == 'Patch')",message="removedRecords requires the Patch strategy"
+// +kubebuilder:validation:XValidation:rule="!has(self.comment) || !has(self.strategy) || self.strategy != 'Merge'",message="comment is not supported with the Merge strategy"
+// +kubebuilder:validation:XValidation:rule="!has(self.removedRecords) || !has(self.records) || self.records.all(r, !(r in self.removedRecords))",message="records and removedRecords must not overlap"
+// +kubebuilder:validation:XValidation:rule="!has(self.ttl) || type(self.ttl) == int",message="TTL durations must be converted to seconds by the defaulting webhook, enable it or use seconds"
+// +kubebuilder:validation:XValidation:rule="!has(self.ttl) || type(self.ttl) != int || (self.ttl >= 0 && self.ttl <= 2147483647)",message="TTL must be between 0 and 2147483647 seconds"
 type RRsetSpec struct {
 	// Type of the record (e.g. "A", "PTR", "MX").
 	Type string `json:"type"`
 	// Name of the record
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Name string `json:"name"`
-	// DNS TTL of the records, in seconds.
-	TTL uint32 `json:"ttl"`
+	// DNS TTL of the records, in seconds. Defaults to the --default-ttl of the operator.
+	// A duration in the BIND format (e.g. "5m", "1h30m", "1d") is converted to seconds by the defaulting webhook.
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Type=""
+	// +kubebuilder:validation:Format=""
+	// +kubebuilder:validation:Pattern=`^([0-9]+[smhdw])+$`
+	TTL *uint32 `json:"ttl,omitempty"`
 	// All records in this Resource Record Set.
-	Records []string `json:"records"`
+	// The order of the records is not significant, duplicated records are rejected.
+	// Required unless ensure is Absent, or the records are resolved from the targetRef.
+	// +optional
+	// +listType=set
+	Records []string `json:"records,omitempty"`
+	// TargetRef reference the object the records are resolved from, instead of the records:
+	// the external IP addresses of the object of the family of the type, updated when they change.
+	// +optional
+	TargetRef *TargetReference `json:"targetRef,omitempty"`
+	// Structured NAPTR records, added to the records of a NAPTR RRset.
+	// +optional
+	NAPTR []NAPTRRecord `json:"naptr,omitempty"`
+	// Strategy applying the records to PowerDNS:
+	// Replace (default) sets the records of the RRset to the records, removing any other record,
+	// Patch adds the records to the RRset, keeping the records not managed by the operator,
+	// Merge sets the records of the RRset to the union of the records of the RRsets using the Merge strategy
+	// for the same name and type, each of them owning its own records.
+	// +optional
+	// +kubebuilder:validation:Enum:=Replace;Patch;Merge
+	Strategy *string `json:"strategy,omitempty"`
+	// Records to remove from the RRset, with the Patch strategy.
+	// +optional
+	// +listType=set
+	RemovedRecords []string `json:"removedRecords,omitempty"`
+	// Ensure the RRset is Present (default) in the zone, or Absent: the RRset with the same name and type
+	// is deleted from PowerDNS if found, and kept deleted.
+	// +optional
+	// +kubebuilder:validation:Enum:=Present;Absent
+	Ensure *string `json:"ensure,omitempty"`
 	// Comment on RRSet.
 	// +optional
 	Comment *string `json:"comment,omitempty"`
+	// Time the RRset is published from in PowerDNS, it is not published before.
+	// +optional
+	ActiveFrom *metav1.Time `json:"activeFrom,omitempty"`
+	// Time the RRset is published until in PowerDNS, its records are removed afterwards.
+	// +optional
+	ActiveUntil *metav1.Time `json:"activeUntil,omitempty"`
+	// Priority of the RRset when several RRsets or ClusterRRsets claim the same name and type,
+	// with the priority conflict policy of the operator: the highest priority wins, then the oldest RRset.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
 	// ZoneRef reference the zone the RRSet depends on.
 	ZoneRef ZoneRef `json:"zoneRef"`
 }
 
+// NAPTRRecord defines a NAPTR record (RFC 3403), the character strings are quoted by the operator
+// +kubebuilder:validation:XValidation:rule="!has(self.regexp) || size(self.regexp) == 0 || !has(self.replacement) || self.replacement == '.'",message="regexp and replacement are mutually exclusive"
+type NAPTRRecord struct {
+	// Order in which the records must be processed, lowest first.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Order uint16 `json:"order"`
+	// Preference of the records with the same order, lowest first.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Preference uint16 `json:"preference"`
+	// Flags controlling the rewriting and the interpretation of the fields (e.g. "U", "S", "A", "P").
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]*$`
+	Flags string `json:"flags,omitempty"`
+	// Service parameters (e.g. "E2U+sip", "SIP+D2U").
+	// +optional
+	Service string `json:"service,omitempty"`
+	// Substitution expression applied to the original string (e.g. "!^.*$!sip:info@example.com!").
+	// +optional
+	Regexp string `json:"regexp,omitempty"`
+	// Next domain name to query, "." when the regexp is used.
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// TargetReference reference the addressable object the records of a RRset are resolved from
+type TargetReference struct {
+	// Kind of the object: Service, its LoadBalancer ingress IPs, or else its external IPs, are used.
+	// +kubebuilder:validation:Enum:=Service
+	// +kubebuilder:default:="Service"
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name of the object.
+	Name string `json:"name"`
+	// Namespace of the object, required for a ClusterRRset: the objects of a RRset are in its namespace.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
 type ZoneRef struct {
 	// Name of the zone.
 	Name string `json:"name"`
@@ -48,6 +145,29 @@ type RRsetStatus struct {
 	SyncStatus         *string            `json:"syncStatus,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+	// PendingChanges lists, in dry-run mode, the records the operator would remove ("-") or add ("+") in PowerDNS
+	PendingChanges []string `json:"pendingChanges,omitempty"`
+	// AppliedHash is the hash of the desired state last successfully applied in PowerDNS
+	AppliedHash *string `json:"appliedHash,omitempty"`
+	// AppliedZoneSerial is the serial of the zone read from PowerDNS once the desired state was last successfully applied
+	AppliedZoneSerial *uint32 `json:"appliedZoneSerial,omitempty"`
+	// Revisions are the last records successfully applied in PowerDNS, the oldest first,
+	// restored with the dns.cav.enablers.ob/rollback annotation
+	Revisions []RRsetRevision `json:"revisions,omitempty"`
+	// LastHandledReconcileAt is the last value of the dns.cav.enablers.ob/reconcile annotation handled
+	LastHandledReconcileAt *string `json:"lastHandledReconcileAt,omitempty"`
+}
+
+// RRsetRevision defines the records of a generation of the RRset applied in PowerDNS
+type RRsetRevision struct {
+	// Revision is the generation of the RRset applied
+	Revision int64 `json:"revision"`
+	// AppliedTime is the time the generation was applied
+	AppliedTime metav1.Time `json:"appliedTime"`
+	// TTL of the records, in seconds
+	TTL *uint32 `json:"ttl,omitempty"`
+	// Records applied
+	Records []string `json:"records,omitempty"`
 }
 
 //+kubebuilder:object:root=true
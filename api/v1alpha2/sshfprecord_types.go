@@ -0,0 +1,93 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SSHFPRecordSpec defines the desired state of SSHFPRecord
+type SSHFPRecordSpec struct {
+	// ZoneRef reference the zone the SSHFP records are created in.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Source the SSH host public keys are read from.
+	Source SSHFPSource `json:"source"`
+	// DNS TTL of the SSHFP records, in seconds. Defaults to the --default-ttl of the operator.
+	// +optional
+	TTL *uint32 `json:"ttl,omitempty"`
+	// Fingerprint types of the SSHFP records (SHA1, SHA256), defaults to SHA256.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=SHA1;SHA256
+	FingerprintTypes []string `json:"fingerprintTypes,omitempty"`
+}
+
+// SSHFPSource defines where the SSH host public keys are read from, exactly one source must be set.
+// Each key of the object is a host name, relative to the zone or canonical, and its value the public keys
+// of the host in the OpenSSH format (e.g. the content of the /etc/ssh/ssh_host_*_key.pub files), one per line.
+// +kubebuilder:validation:XValidation:rule="[has(self.configMap), has(self.secret)].filter(x, x).size() == 1",message="Exactly one source must be set"
+type SSHFPSource struct {
+	// ConfigMap, in the namespace of the SSHFPRecord, the public keys are read from.
+	// +optional
+	ConfigMap *corev1.LocalObjectReference `json:"configMap,omitempty"`
+	// Secret, in the namespace of the SSHFPRecord, the public keys are read from.
+	// +optional
+	Secret *corev1.LocalObjectReference `json:"secret,omitempty"`
+}
+
+// SSHFPRecordStatus defines the observed state of SSHFPRecord
+type SSHFPRecordStatus struct {
+	// Names of the RRsets generated, one per host, in the namespace of the SSHFPRecord.
+	// +optional
+	RRsets             []string           `json:"rrsets,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="RRsets",type="string",JSONPath=".status.rrsets"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// SSHFPRecord is the Schema for the sshfprecords API
+type SSHFPRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSHFPRecordSpec   `json:"spec,omitempty"`
+	Status SSHFPRecordStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SSHFPRecordList contains a list of SSHFPRecord
+type SSHFPRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHFPRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SSHFPRecord{}, &SSHFPRecordList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (s *SSHFPRecord) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return s.Status.ObservedGeneration != nil &&
+		*s.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		s.Status.SyncStatus != nil &&
+		*s.Status.SyncStatus == expectedSyncStatus
+}
@@ -0,0 +1,107 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TLSARecordSpec defines the desired state of TLSARecord
+type TLSARecordSpec struct {
+	// ZoneRef reference the zone the TLSA records are created in.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Name of the host serving the certificate, relative to the zone or canonical.
+	Name string `json:"name"`
+	// Port of the service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Protocol of the service, defaults to tcp.
+	// +optional
+	// +kubebuilder:validation:Enum:=tcp;udp;sctp
+	Protocol *string `json:"protocol,omitempty"`
+	// SecretRef reference the Secret, in the namespace of the TLSARecord, containing the certificate
+	// in the "tls.crt" key, e.g. the Secret of a cert-manager Certificate.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+	// Certificate usage (RFC 6698): 0 (PKIX-TA), 1 (PKIX-EE), 2 (DANE-TA) or 3 (DANE-EE), defaults to 3.
+	// The trust anchor usages use the "ca.crt" certificate of the Secret, or the last certificate of the chain.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3
+	Usage *int32 `json:"usage,omitempty"`
+	// Selector (RFC 6698): 0 (full certificate) or 1 (SubjectPublicKeyInfo), defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	Selector *int32 `json:"selector,omitempty"`
+	// Matching type (RFC 6698): 0 (exact match), 1 (SHA-256) or 2 (SHA-512), defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2
+	MatchingType *int32 `json:"matchingType,omitempty"`
+	// DNS TTL of the TLSA records, in seconds. Defaults to the --default-ttl of the operator.
+	// +optional
+	TTL *uint32 `json:"ttl,omitempty"`
+}
+
+// TLSARecordStatus defines the observed state of TLSARecord
+type TLSARecordStatus struct {
+	// Names of the RRsets generated, in the namespace of the TLSARecord.
+	// +optional
+	RRsets []string `json:"rrsets,omitempty"`
+	// Expiration time of the certificate the TLSA records are generated from.
+	// +optional
+	NotAfter           *metav1.Time       `json:"notAfter,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Port",type="integer",JSONPath=".spec.port"
+// +kubebuilder:printcolumn:name="Not After",type="date",JSONPath=".status.notAfter"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// TLSARecord is the Schema for the tlsarecords API
+type TLSARecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TLSARecordSpec   `json:"spec,omitempty"`
+	Status TLSARecordStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TLSARecordList contains a list of TLSARecord
+type TLSARecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TLSARecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TLSARecord{}, &TLSARecordList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (t *TLSARecord) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return t.Status.ObservedGeneration != nil &&
+		*t.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		t.Status.SyncStatus != nil &&
+		*t.Status.SyncStatus == expectedSyncStatus
+}
@@ -16,6 +16,10 @@ import (
 )
 
 // ZoneSpec defines the desired state of Zone
+// +kubebuilder:validation:XValidation:rule="!has(self.masters) || self.kind in ['Slave', 'Consumer']",message="masters require the Slave or Consumer kind"
+// +kubebuilder:validation:XValidation:rule="!(has(self.zoneFile) || has(self.zoneFileFrom)) || !(self.kind in ['Slave', 'Consumer'])",message="zoneFile and zoneFileFrom require a primary kind"
+// +kubebuilder:validation:XValidation:rule="!(has(self.zoneFile) && has(self.zoneFileFrom))",message="zoneFile and zoneFileFrom are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.cloneFrom) || (has(oldSelf.cloneFrom) && self.cloneFrom == oldSelf.cloneFrom)",message="cloneFrom cannot be added nor changed once the zone is created"
 type ZoneSpec struct {
 	// Kind of the zone, one of "Native", "Master", "Slave", "Producer", "Consumer".
 	// +kubebuilder:validation:Enum:=Native;Master;Slave;Producer;Consumer
@@ -24,14 +28,151 @@ type ZoneSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:items:Pattern=`^([a-zA-Z0-9-]+\.)*[a-zA-Z0-9-]+$`
 	Nameservers []string `json:"nameservers"`
+	// List of the IP addresses, with an optional port, of the primaries of the zone ("Slave" and "Consumer" kinds only).
+	// +optional
+	// +listType=set
+	Masters []string `json:"masters,omitempty"`
+	// List of the Services of the primaries of the zone ("Slave" and "Consumer" kinds only), resolved to
+	// their IP addresses (LoadBalancer ingress IPs, or ClusterIPs) and added to the masters.
+	// +optional
+	MasterServices []ServiceReference `json:"masterServices,omitempty"`
 	// The catalog this zone is a member of
 	// +optional
 	Catalog *string `json:"catalog,omitempty"`
-	// The SOA-EDIT-API metadata item, one of "DEFAULT", "INCREASE", "EPOCH", defaults to "DEFAULT"
+	// The SOA-EDIT-API metadata item producing the serials on changes, one of "DEFAULT" (date-based, YYYYMMDDnn),
+	// "INCREASE" (incremented by one), "EPOCH" (UNIX timestamp), defaults to "DEFAULT"
 	// +kubebuilder:validation:Enum:=DEFAULT;INCREASE;EPOCH
 	// +kubebuilder:default:="DEFAULT"
 	// +optional
 	SOAEditAPI *string `json:"soa_edit_api,omitempty"`
+	// Whether or not the zone is presigned: signatures are retrieved through zone transfers
+	// and no key management is done locally (sets the PRESIGNED metadata)
+	// +optional
+	Presigned *bool `json:"presigned,omitempty"`
+	// Human readable description of the zone (owner, contact...), stored in the zone metadata
+	// so it travels with the zone outside of Kubernetes
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=1024
+	// +optional
+	Description *string `json:"description,omitempty"`
+	// Whether or not the NS delegation (and glue records) of the zone is created
+	// in its parent zone, when the parent zone is managed by the operator
+	// +optional
+	Delegate *bool `json:"delegate,omitempty"`
+	// Deletion policy of the RRsets owned by the zone, on deletion of the zone:
+	// Background (default) deletes the zone from PowerDNS at once, the RRsets are garbage collected afterwards,
+	// Foreground deletes the RRsets, and their records, before deleting the zone from PowerDNS.
+	// +kubebuilder:validation:Enum:=Background;Foreground
+	// +optional
+	CascadeDeletion *string `json:"cascadeDeletion,omitempty"`
+	// The managed zone whose records are copied in the zone, once, when it is created in PowerDNS.
+	// It cannot be added nor changed afterwards.
+	// +optional
+	CloneFrom *ZoneCloneSource `json:"cloneFrom,omitempty"`
+	// Records of the zone, in the BIND zone file format (RFC 1035), synchronized in PowerDNS without RRset resources.
+	// The SOA and apex NS records are ignored, they are managed through the zone, as the records managed by RRsets.
+	// +kubebuilder:validation:MinLength:=1
+	// +optional
+	ZoneFile *string `json:"zoneFile,omitempty"`
+	// The ConfigMap the zone file of the zone is read from, instead of zoneFile, synchronized again on its changes
+	// +optional
+	ZoneFileFrom *ZoneFileSource `json:"zoneFileFrom,omitempty"`
+	// The namespaces allowed to create RRsets referencing the zone, all of them when not set.
+	// The RRsets of a Zone are in its namespace: only relevant for a ClusterZone.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+	// Whether or not the new, changed or deleted RRsets referencing the zone are held until approved,
+	// through the dns.cav.enablers.ob/approved annotation set to the generation of the RRset by an approver
+	// +optional
+	RequireApproval *bool `json:"requireApproval,omitempty"`
+	// The maintenance windows the changes and the deletions of the RRsets referencing the zone are applied in,
+	// queued outside of them. The changes of the zone itself are not held.
+	// They replace the maintenance windows of the operator, the changes are applied at once without any window.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Whether or not the zone is frozen: the records are neither modified nor deleted in PowerDNS until unfrozen,
+	// the zone is also frozen through the dns.cav.enablers.ob/frozen annotation set to "true"
+	// +optional
+	Frozen *bool `json:"frozen,omitempty"`
+}
+
+// ZoneTransferStatus defines the state of the zone transfers of a secondary zone
+type ZoneTransferStatus struct {
+	// Result of the transfers: "Succeeded" when the zone has the serial of the primaries, "Pending" when the zone
+	// was never transferred, "Failed" when a primary serves a newer serial, "Unknown" when no primary answered.
+	Result string `json:"result"`
+	// Time the operator first observed the serial transferred from the primaries.
+	// +optional
+	LastTransferTime *metav1.Time `json:"lastTransferTime,omitempty"`
+	// The primary serving the serial transferred.
+	// +optional
+	Primary *string `json:"primary,omitempty"`
+	// The newest serial served by the primaries.
+	// +optional
+	PrimarySerial *uint32 `json:"primarySerial,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring period the changes are applied in
+type MaintenanceWindow struct {
+	// Schedule of the start of the window, in cron format (e.g. "0 2 * * 6"), in UTC unless prefixed with
+	// a time zone (e.g. "CRON_TZ=Europe/Paris 0 2 * * 6").
+	// +kubebuilder:validation:MinLength:=1
+	Schedule string `json:"schedule"`
+	// Duration of the window (e.g. "2h").
+	Duration metav1.Duration `json:"duration"`
+}
+
+// AllowedNamespaces select the namespaces allowed to create RRsets referencing a zone,
+// a namespace is allowed when listed or matched by the selector
+type AllowedNamespaces struct {
+	// Names of the namespaces.
+	// +optional
+	// +listType=set
+	Names []string `json:"names,omitempty"`
+	// Selector of the labels of the namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ZoneFileSource defines the object the zone file of a zone is read from
+type ZoneFileSource struct {
+	// ConfigMap holding the zone file.
+	ConfigMap ZoneFileConfigMapSource `json:"configMap"`
+}
+
+// ZoneFileConfigMapSource reference the ConfigMap holding the zone file of a zone
+type ZoneFileConfigMapSource struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap, required for a ClusterZone: the ConfigMap of a Zone is in its namespace.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Key of the zone file in the ConfigMap, defaults to "<zone>.zone".
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// ServiceReference reference the Service of a primary of a zone
+type ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+	// Namespace of the Service, required for a ClusterZone: the Services of a Zone are in its namespace.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Port of the primary, defaults to the DNS port.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// ZoneCloneSource defines the managed zone a zone is initialized from
+type ZoneCloneSource struct {
+	// ZoneRef reference the zone to copy the records from, a Zone in the same namespace or a ClusterZone.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Whether or not RRset (ClusterRRset for a ClusterZone) resources are created for the copied records.
+	// +optional
+	RegenerateRRsets *bool `json:"regenerateRRsets,omitempty"`
 }
 
 // ZoneStatus defines the observed state of Zone
@@ -54,18 +195,52 @@ type ZoneStatus struct {
 	// The SOA serial as seen in query responses.
 	// +optional
 	EditedSerial *uint32 `json:"edited_serial,omitempty"`
+	// The effective SOA-EDIT-API metadata item of the zone in PowerDNS, producing the serials on changes:
+	// "DEFAULT" (date-based), "INCREASE" or "EPOCH".
+	// +optional
+	SOAEditAPI *string `json:"soa_edit_api,omitempty"`
 	// List of IP addresses configured as a master for this zone ("Slave" type zones only).
 	// +optional
 	Masters []string `json:"masters,omitempty"`
 	// Whether or not this zone is DNSSEC signed.
 	// +optional
 	DNSsec *bool `json:"dnssec,omitempty"`
+	// Whether or not this zone is presigned.
+	// +optional
+	Presigned *bool `json:"presigned,omitempty"`
+	// DNSSEC keys of the zone, as observed by the operator.
+	// +optional
+	DNSSECKeys []DNSSECKey `json:"dnssecKeys,omitempty"`
+	// The managed parent zone of this zone, if any.
+	// +optional
+	ParentZone *string `json:"parentZone,omitempty"`
+	// The zone transfers from the primaries, as observed by the operator ("Slave" and "Consumer" zones only).
+	// +optional
+	Transfer *ZoneTransferStatus `json:"transfer,omitempty"`
 	// The catalog this zone is a member of.
 	// +optional
 	Catalog            *string            `json:"catalog,omitempty"`
 	SyncStatus         *string            `json:"syncStatus,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
 	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+	// The last value of the dns.cav.enablers.ob/reconcile annotation handled.
+	// +optional
+	LastHandledReconcileAt *string `json:"lastHandledReconcileAt,omitempty"`
+}
+
+// DNSSECKey defines a DNSSEC key of a zone, as observed by the operator
+type DNSSECKey struct {
+	// ID of the key in PowerDNS.
+	ID uint64 `json:"id"`
+	// Type of the key, one of "ksk", "zsk", "csk".
+	KeyType string `json:"keyType"`
+	// Whether or not the key is active.
+	Active bool `json:"active"`
+	// Time the key has been observed for the first time by the operator.
+	FirstSeen metav1.Time `json:"firstSeen"`
+	// DS records matching the key, to be published in the parent zone.
+	// +optional
+	DS []string `json:"ds,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -74,8 +249,10 @@ type ZoneStatus struct {
 //+kubebuilder:resource:scope=Namespaced
 
 // +kubebuilder:printcolumn:name="Serial",type="integer",JSONPath=".status.serial"
+// +kubebuilder:printcolumn:name="Serial Strategy",type="string",JSONPath=".status.soa_edit_api",priority=1
 // +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="Records",type="string",JSONPath=`.status.conditions[?(@.type=="RecordsReady")].message`
 // Zone is the Schema for the zones API
 type Zone struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,133 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneBackupSpec defines the desired state of ZoneBackup
+// +kubebuilder:validation:XValidation:rule="!(has(self.interval) && has(self.schedule))",message="Only one of interval or schedule can be set"
+type ZoneBackupSpec struct {
+	// ZoneRef reference the zone to back up.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Target the zone file is stored in.
+	Target BackupTarget `json:"target"`
+	// Interval between two backups (e.g. "24h").
+	// When not set, a backup is only done on creation and on each change of the specification.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="Interval must be at least 1m"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Schedule of the backups, in cron format (e.g. "0 3 * * *").
+	// When not set, a backup is only done on creation and on each change of the specification.
+	// +optional
+	Schedule *string `json:"schedule,omitempty"`
+}
+
+// BackupTarget defines where a zone file is stored, exactly one target must be set
+// +kubebuilder:validation:XValidation:rule="[has(self.configMap), has(self.secret), has(self.s3)].filter(x, x).size() == 1",message="Exactly one target must be set"
+type BackupTarget struct {
+	// ConfigMap, in the namespace of the ZoneBackup, the zone file is stored in.
+	// +optional
+	ConfigMap *BackupObjectTarget `json:"configMap,omitempty"`
+	// Secret, in the namespace of the ZoneBackup, the zone file is stored in.
+	// +optional
+	Secret *BackupObjectTarget `json:"secret,omitempty"`
+	// S3-compatible object storage the zone files are uploaded to.
+	// +optional
+	S3 *S3BackupTarget `json:"s3,omitempty"`
+}
+
+type BackupObjectTarget struct {
+	// Name of the object, created if it does not exist.
+	Name string `json:"name"`
+}
+
+// S3BackupTarget defines an S3-compatible object storage bucket
+type S3BackupTarget struct {
+	// Endpoint of the object storage (e.g. "s3.amazonaws.com", "minio.minio.svc:9000").
+	Endpoint string `json:"endpoint"`
+	// Bucket the zone files are uploaded to.
+	Bucket string `json:"bucket"`
+	// Prefix of the object keys (e.g. "backups/").
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// Region of the bucket.
+	// +optional
+	Region *string `json:"region,omitempty"`
+	// Whether or not the endpoint is reached without TLS.
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+	// CredentialsSecretRef reference a Secret, in the namespace of the ZoneBackup,
+	// containing the "accessKeyID" and "secretAccessKey" keys.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+	// Number of backups of the zone to keep in the bucket, older ones are deleted.
+	// All backups are kept when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+}
+
+// ZoneBackupStatus defines the observed state of ZoneBackup
+type ZoneBackupStatus struct {
+	// Time of the last successful backup.
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+	// The SOA serial of the zone at the last successful backup.
+	// +optional
+	Serial *uint32 `json:"serial,omitempty"`
+	// Location of the last successful backup (e.g. "configmap/<name>", "s3://<bucket>/<key>").
+	// +optional
+	Location           *string            `json:"location,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Serial",type="integer",JSONPath=".status.serial"
+// +kubebuilder:printcolumn:name="Last Backup",type="date",JSONPath=".status.lastBackupTime"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// ZoneBackup is the Schema for the zonebackups API
+type ZoneBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ZoneBackupSpec   `json:"spec,omitempty"`
+	Status ZoneBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneBackupList contains a list of ZoneBackup
+type ZoneBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneBackup{}, &ZoneBackupList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (b *ZoneBackup) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return b.Status.ObservedGeneration != nil &&
+		*b.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		b.Status.SyncStatus != nil &&
+		*b.Status.SyncStatus == expectedSyncStatus
+}
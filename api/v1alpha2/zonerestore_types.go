@@ -0,0 +1,108 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ZoneRestoreSpec defines the desired state of ZoneRestore
+type ZoneRestoreSpec struct {
+	// ZoneRef reference the zone to restore.
+	ZoneRef ZoneRef `json:"zoneRef"`
+	// Source of the zone file to restore.
+	Source RestoreSource `json:"source"`
+	// Whether or not the changes are only computed and reported in the status, without being applied.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
+	// Whether or not RRset resources are created, in the namespace of the ZoneRestore,
+	// for the restored records which are not already managed by a RRset or a ClusterRRset.
+	// +optional
+	RegenerateRRsets *bool `json:"regenerateRRsets,omitempty"`
+}
+
+// RestoreSource defines where a zone file is read from, exactly one source must be set
+// +kubebuilder:validation:XValidation:rule="[has(self.zoneBackupRef), has(self.configMap), has(self.secret)].filter(x, x).size() == 1",message="Exactly one source must be set"
+type RestoreSource struct {
+	// ZoneBackupRef reference a ZoneBackup, in the namespace of the ZoneRestore, whose last successful backup is restored.
+	// +optional
+	ZoneBackupRef *corev1.LocalObjectReference `json:"zoneBackupRef,omitempty"`
+	// ConfigMap, in the namespace of the ZoneRestore, the zone file is read from.
+	// +optional
+	ConfigMap *RestoreObjectSource `json:"configMap,omitempty"`
+	// Secret, in the namespace of the ZoneRestore, the zone file is read from.
+	// +optional
+	Secret *RestoreObjectSource `json:"secret,omitempty"`
+}
+
+type RestoreObjectSource struct {
+	// Name of the object.
+	Name string `json:"name"`
+	// Key of the zone file in the object, defaults to "<zone>.zone".
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// ZoneRestoreStatus defines the observed state of ZoneRestore
+type ZoneRestoreStatus struct {
+	// Time the zone file has been restored.
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+	// Number of records to add or remove to restore the zone file.
+	// +optional
+	Changes *int32 `json:"changes,omitempty"`
+	// Records to add ("+") or remove ("-") to restore the zone file, truncated to the first 100 lines.
+	// +optional
+	Diff               []string           `json:"diff,omitempty"`
+	SyncStatus         *string            `json:"syncStatus,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	ObservedGeneration *int64             `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneRef.name"
+// +kubebuilder:printcolumn:name="Dry Run",type="boolean",JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="Changes",type="integer",JSONPath=".status.changes"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.syncStatus"
+// ZoneRestore is the Schema for the zonerestores API
+type ZoneRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ZoneRestoreSpec   `json:"spec,omitempty"`
+	Status ZoneRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ZoneRestoreList contains a list of ZoneRestore
+type ZoneRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZoneRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZoneRestore{}, &ZoneRestoreList{})
+}
+
+// IsInExpectedStatus returns true if Status.SyncStatus and Status.ObservedGeneration are, at least, at expected value
+func (z *ZoneRestore) IsInExpectedStatus(expectedMinimumObservedGeneration int64, expectedSyncStatus string) bool {
+	return z.Status.ObservedGeneration != nil &&
+		*z.Status.ObservedGeneration >= expectedMinimumObservedGeneration &&
+		z.Status.SyncStatus != nil &&
+		*z.Status.SyncStatus == expectedSyncStatus
+}
@@ -16,10 +16,81 @@
 package v1alpha2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupObjectTarget) DeepCopyInto(out *BackupObjectTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupObjectTarget.
+func (in *BackupObjectTarget) DeepCopy() *BackupObjectTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupObjectTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(BackupObjectTarget)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(BackupObjectTarget)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupTarget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTarget.
+func (in *BackupTarget) DeepCopy() *BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRRset) DeepCopyInto(out *ClusterRRset) {
 	*out = *in
@@ -139,7 +210,83 @@ func (in *ClusterZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRset) DeepCopyInto(out *RRset) {
+func (in *DKIMSelector) DeepCopyInto(out *DKIMSelector) {
+	*out = *in
+	if in.KeyType != nil {
+		in, out := &in.KeyType, &out.KeyType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DKIMSelector.
+func (in *DKIMSelector) DeepCopy() *DKIMSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DKIMSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DMARCPolicy) DeepCopyInto(out *DMARCPolicy) {
+	*out = *in
+	if in.SubdomainPolicy != nil {
+		in, out := &in.SubdomainPolicy, &out.SubdomainPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AggregateReports != nil {
+		in, out := &in.AggregateReports, &out.AggregateReports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureReports != nil {
+		in, out := &in.FailureReports, &out.FailureReports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DMARCPolicy.
+func (in *DMARCPolicy) DeepCopy() *DMARCPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DMARCPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECKey) DeepCopyInto(out *DNSSECKey) {
+	*out = *in
+	in.FirstSeen.DeepCopyInto(&out.FirstSeen)
+	if in.DS != nil {
+		in, out := &in.DS, &out.DS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECKey.
+func (in *DNSSECKey) DeepCopy() *DNSSECKey {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainVerification) DeepCopyInto(out *DomainVerification) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -147,18 +294,18 @@ func (in *RRset) DeepCopyInto(out *RRset) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRset.
-func (in *RRset) DeepCopy() *RRset {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerification.
+func (in *DomainVerification) DeepCopy() *DomainVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(RRset)
+	out := new(DomainVerification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RRset) DeepCopyObject() runtime.Object {
+func (in *DomainVerification) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -166,31 +313,31 @@ func (in *RRset) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetList) DeepCopyInto(out *RRsetList) {
+func (in *DomainVerificationList) DeepCopyInto(out *DomainVerificationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RRset, len(*in))
+		*out = make([]DomainVerification, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetList.
-func (in *RRsetList) DeepCopy() *RRsetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationList.
+func (in *DomainVerificationList) DeepCopy() *DomainVerificationList {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetList)
+	out := new(DomainVerificationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RRsetList) DeepCopyObject() runtime.Object {
+func (in *DomainVerificationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -198,42 +345,47 @@ func (in *RRsetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetSpec) DeepCopyInto(out *RRsetSpec) {
+func (in *DomainVerificationSpec) DeepCopyInto(out *DomainVerificationSpec) {
 	*out = *in
-	if in.Records != nil {
-		in, out := &in.Records, &out.Records
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Comment != nil {
-		in, out := &in.Comment, &out.Comment
+	out.ZoneRef = in.ZoneRef
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
-	out.ZoneRef = in.ZoneRef
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ExpiresAfter != nil {
+		in, out := &in.ExpiresAfter, &out.ExpiresAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetSpec.
-func (in *RRsetSpec) DeepCopy() *RRsetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationSpec.
+func (in *DomainVerificationSpec) DeepCopy() *DomainVerificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetSpec)
+	out := new(DomainVerificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
+func (in *DomainVerificationStatus) DeepCopyInto(out *DomainVerificationStatus) {
 	*out = *in
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
+	if in.RRsets != nil {
+		in, out := &in.RRsets, &out.RRsets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.DnsEntryName != nil {
-		in, out := &in.DnsEntryName, &out.DnsEntryName
-		*out = new(string)
-		**out = **in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
 	}
 	if in.SyncStatus != nil {
 		in, out := &in.SyncStatus, &out.SyncStatus
@@ -254,18 +406,18 @@ func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetStatus.
-func (in *RRsetStatus) DeepCopy() *RRsetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainVerificationStatus.
+func (in *DomainVerificationStatus) DeepCopy() *DomainVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RRsetStatus)
+	out := new(DomainVerificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Zone) DeepCopyInto(out *Zone) {
+func (in *MailDomain) DeepCopyInto(out *MailDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -273,18 +425,18 @@ func (in *Zone) DeepCopyInto(out *Zone) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
-func (in *Zone) DeepCopy() *Zone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailDomain.
+func (in *MailDomain) DeepCopy() *MailDomain {
 	if in == nil {
 		return nil
 	}
-	out := new(Zone)
+	out := new(MailDomain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Zone) DeepCopyObject() runtime.Object {
+func (in *MailDomain) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -292,31 +444,31 @@ func (in *Zone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneList) DeepCopyInto(out *ZoneList) {
+func (in *MailDomainList) DeepCopyInto(out *MailDomainList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Zone, len(*in))
+		*out = make([]MailDomain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneList.
-func (in *ZoneList) DeepCopy() *ZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailDomainList.
+func (in *MailDomainList) DeepCopy() *MailDomainList {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneList)
+	out := new(MailDomainList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ZoneList) DeepCopyObject() runtime.Object {
+func (in *MailDomainList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -324,98 +476,61 @@ func (in *ZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneRef) DeepCopyInto(out *ZoneRef) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRef.
-func (in *ZoneRef) DeepCopy() *ZoneRef {
-	if in == nil {
-		return nil
-	}
-	out := new(ZoneRef)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+func (in *MailDomainSpec) DeepCopyInto(out *MailDomainSpec) {
 	*out = *in
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
+	out.ZoneRef = in.ZoneRef
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
-	if in.SOAEditAPI != nil {
-		in, out := &in.SOAEditAPI, &out.SOAEditAPI
-		*out = new(string)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
 		**out = **in
 	}
+	if in.MX != nil {
+		in, out := &in.MX, &out.MX
+		*out = make([]MailServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.SPF != nil {
+		in, out := &in.SPF, &out.SPF
+		*out = new(SPFPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DKIM != nil {
+		in, out := &in.DKIM, &out.DKIM
+		*out = make([]DKIMSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DMARC != nil {
+		in, out := &in.DMARC, &out.DMARC
+		*out = new(DMARCPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
-func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailDomainSpec.
+func (in *MailDomainSpec) DeepCopy() *MailDomainSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneSpec)
+	out := new(MailDomainSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZoneStatus) DeepCopyInto(out *ZoneStatus) {
+func (in *MailDomainStatus) DeepCopyInto(out *MailDomainStatus) {
 	*out = *in
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(string)
-		**out = **in
-	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-	if in.Kind != nil {
-		in, out := &in.Kind, &out.Kind
-		*out = new(string)
-		**out = **in
-	}
-	if in.Serial != nil {
-		in, out := &in.Serial, &out.Serial
-		*out = new(uint32)
-		**out = **in
-	}
-	if in.NotifiedSerial != nil {
-		in, out := &in.NotifiedSerial, &out.NotifiedSerial
-		*out = new(uint32)
-		**out = **in
-	}
-	if in.EditedSerial != nil {
-		in, out := &in.EditedSerial, &out.EditedSerial
-		*out = new(uint32)
-		**out = **in
-	}
-	if in.Masters != nil {
-		in, out := &in.Masters, &out.Masters
+	if in.RRsets != nil {
+		in, out := &in.RRsets, &out.RRsets
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.DNSsec != nil {
-		in, out := &in.DNSsec, &out.DNSsec
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
-		*out = new(string)
-		**out = **in
-	}
 	if in.SyncStatus != nil {
 		in, out := &in.SyncStatus, &out.SyncStatus
 		*out = new(string)
@@ -435,12 +550,1371 @@ func (in *ZoneStatus) DeepCopyInto(out *ZoneStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneStatus.
-func (in *ZoneStatus) DeepCopy() *ZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailDomainStatus.
+func (in *MailDomainStatus) DeepCopy() *MailDomainStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ZoneStatus)
+	out := new(MailDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MailServer) DeepCopyInto(out *MailServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MailServer.
+func (in *MailServer) DeepCopy() *MailServer {
+	if in == nil {
+		return nil
+	}
+	out := new(MailServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NAPTRRecord) DeepCopyInto(out *NAPTRRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NAPTRRecord.
+func (in *NAPTRRecord) DeepCopy() *NAPTRRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(NAPTRRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRset) DeepCopyInto(out *RRset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRset.
+func (in *RRset) DeepCopy() *RRset {
+	if in == nil {
+		return nil
+	}
+	out := new(RRset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RRset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetList) DeepCopyInto(out *RRsetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RRset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetList.
+func (in *RRsetList) DeepCopy() *RRsetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RRsetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetRevision) DeepCopyInto(out *RRsetRevision) {
+	*out = *in
+	in.AppliedTime.DeepCopyInto(&out.AppliedTime)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetRevision.
+func (in *RRsetRevision) DeepCopy() *RRsetRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetSpec) DeepCopyInto(out *RRsetSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(TargetReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NAPTR != nil {
+		in, out := &in.NAPTR, &out.NAPTR
+		*out = make([]NAPTRRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.RemovedRecords != nil {
+		in, out := &in.RemovedRecords, &out.RemovedRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ensure != nil {
+		in, out := &in.Ensure, &out.Ensure
+		*out = new(string)
+		**out = **in
+	}
+	if in.Comment != nil {
+		in, out := &in.Comment, &out.Comment
+		*out = new(string)
+		**out = **in
+	}
+	if in.ActiveFrom != nil {
+		in, out := &in.ActiveFrom, &out.ActiveFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveUntil != nil {
+		in, out := &in.ActiveUntil, &out.ActiveUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	out.ZoneRef = in.ZoneRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetSpec.
+func (in *RRsetSpec) DeepCopy() *RRsetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RRsetStatus) DeepCopyInto(out *RRsetStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DnsEntryName != nil {
+		in, out := &in.DnsEntryName, &out.DnsEntryName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedHash != nil {
+		in, out := &in.AppliedHash, &out.AppliedHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.AppliedZoneSerial != nil {
+		in, out := &in.AppliedZoneSerial, &out.AppliedZoneSerial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]RRsetRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastHandledReconcileAt != nil {
+		in, out := &in.LastHandledReconcileAt, &out.LastHandledReconcileAt
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RRsetStatus.
+func (in *RRsetStatus) DeepCopy() *RRsetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RRsetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreObjectSource) DeepCopyInto(out *RestoreObjectSource) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreObjectSource.
+func (in *RestoreObjectSource) DeepCopy() *RestoreObjectSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreObjectSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
+	*out = *in
+	if in.ZoneBackupRef != nil {
+		in, out := &in.ZoneBackupRef, &out.ZoneBackupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(RestoreObjectSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(RestoreObjectSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
+func (in *RestoreSource) DeepCopy() *RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupTarget) DeepCopyInto(out *S3BackupTarget) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupTarget.
+func (in *S3BackupTarget) DeepCopy() *S3BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPFPolicy) DeepCopyInto(out *SPFPolicy) {
+	*out = *in
+	if in.MX != nil {
+		in, out := &in.MX, &out.MX
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPFPolicy.
+func (in *SPFPolicy) DeepCopy() *SPFPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SPFPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPRecord) DeepCopyInto(out *SSHFPRecord) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPRecord.
+func (in *SSHFPRecord) DeepCopy() *SSHFPRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHFPRecord) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPRecordList) DeepCopyInto(out *SSHFPRecordList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSHFPRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPRecordList.
+func (in *SSHFPRecordList) DeepCopy() *SSHFPRecordList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPRecordList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHFPRecordList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPRecordSpec) DeepCopyInto(out *SSHFPRecordSpec) {
+	*out = *in
+	out.ZoneRef = in.ZoneRef
+	in.Source.DeepCopyInto(&out.Source)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.FingerprintTypes != nil {
+		in, out := &in.FingerprintTypes, &out.FingerprintTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPRecordSpec.
+func (in *SSHFPRecordSpec) DeepCopy() *SSHFPRecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPRecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPRecordStatus) DeepCopyInto(out *SSHFPRecordStatus) {
+	*out = *in
+	if in.RRsets != nil {
+		in, out := &in.RRsets, &out.RRsets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPRecordStatus.
+func (in *SSHFPRecordStatus) DeepCopy() *SSHFPRecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPRecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPSource) DeepCopyInto(out *SSHFPSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPSource.
+func (in *SSHFPSource) DeepCopy() *SSHFPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSARecord) DeepCopyInto(out *TLSARecord) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSARecord.
+func (in *TLSARecord) DeepCopy() *TLSARecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSARecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TLSARecord) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSARecordList) DeepCopyInto(out *TLSARecordList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TLSARecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSARecordList.
+func (in *TLSARecordList) DeepCopy() *TLSARecordList {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSARecordList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TLSARecordList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSARecordSpec) DeepCopyInto(out *TLSARecordSpec) {
+	*out = *in
+	out.ZoneRef = in.ZoneRef
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	out.SecretRef = in.SecretRef
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MatchingType != nil {
+		in, out := &in.MatchingType, &out.MatchingType
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSARecordSpec.
+func (in *TLSARecordSpec) DeepCopy() *TLSARecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSARecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSARecordStatus) DeepCopyInto(out *TLSARecordStatus) {
+	*out = *in
+	if in.RRsets != nil {
+		in, out := &in.RRsets, &out.RRsets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSARecordStatus.
+func (in *TLSARecordStatus) DeepCopy() *TLSARecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSARecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetReference) DeepCopyInto(out *TargetReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetReference.
+func (in *TargetReference) DeepCopy() *TargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Zone) DeepCopyInto(out *Zone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
+func (in *Zone) DeepCopy() *Zone {
+	if in == nil {
+		return nil
+	}
+	out := new(Zone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Zone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackup) DeepCopyInto(out *ZoneBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackup.
+func (in *ZoneBackup) DeepCopy() *ZoneBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupList) DeepCopyInto(out *ZoneBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupList.
+func (in *ZoneBackupList) DeepCopy() *ZoneBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupSpec) DeepCopyInto(out *ZoneBackupSpec) {
+	*out = *in
+	out.ZoneRef = in.ZoneRef
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupSpec.
+func (in *ZoneBackupSpec) DeepCopy() *ZoneBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneBackupStatus) DeepCopyInto(out *ZoneBackupStatus) {
+	*out = *in
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Serial != nil {
+		in, out := &in.Serial, &out.Serial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneBackupStatus.
+func (in *ZoneBackupStatus) DeepCopy() *ZoneBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneCloneSource) DeepCopyInto(out *ZoneCloneSource) {
+	*out = *in
+	out.ZoneRef = in.ZoneRef
+	if in.RegenerateRRsets != nil {
+		in, out := &in.RegenerateRRsets, &out.RegenerateRRsets
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneCloneSource.
+func (in *ZoneCloneSource) DeepCopy() *ZoneCloneSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneCloneSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneFileConfigMapSource) DeepCopyInto(out *ZoneFileConfigMapSource) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneFileConfigMapSource.
+func (in *ZoneFileConfigMapSource) DeepCopy() *ZoneFileConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneFileConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneFileSource) DeepCopyInto(out *ZoneFileSource) {
+	*out = *in
+	in.ConfigMap.DeepCopyInto(&out.ConfigMap)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneFileSource.
+func (in *ZoneFileSource) DeepCopy() *ZoneFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneList) DeepCopyInto(out *ZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Zone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneList.
+func (in *ZoneList) DeepCopy() *ZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRef) DeepCopyInto(out *ZoneRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRef.
+func (in *ZoneRef) DeepCopy() *ZoneRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRestore) DeepCopyInto(out *ZoneRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRestore.
+func (in *ZoneRestore) DeepCopy() *ZoneRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRestoreList) DeepCopyInto(out *ZoneRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ZoneRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRestoreList.
+func (in *ZoneRestoreList) DeepCopy() *ZoneRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ZoneRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRestoreSpec) DeepCopyInto(out *ZoneRestoreSpec) {
+	*out = *in
+	out.ZoneRef = in.ZoneRef
+	in.Source.DeepCopyInto(&out.Source)
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RegenerateRRsets != nil {
+		in, out := &in.RegenerateRRsets, &out.RegenerateRRsets
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRestoreSpec.
+func (in *ZoneRestoreSpec) DeepCopy() *ZoneRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRestoreStatus) DeepCopyInto(out *ZoneRestoreStatus) {
+	*out = *in
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRestoreStatus.
+func (in *ZoneRestoreStatus) DeepCopy() *ZoneRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneSpec) DeepCopyInto(out *ZoneSpec) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MasterServices != nil {
+		in, out := &in.MasterServices, &out.MasterServices
+		*out = make([]ServiceReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(string)
+		**out = **in
+	}
+	if in.SOAEditAPI != nil {
+		in, out := &in.SOAEditAPI, &out.SOAEditAPI
+		*out = new(string)
+		**out = **in
+	}
+	if in.Presigned != nil {
+		in, out := &in.Presigned, &out.Presigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Delegate != nil {
+		in, out := &in.Delegate, &out.Delegate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CascadeDeletion != nil {
+		in, out := &in.CascadeDeletion, &out.CascadeDeletion
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloneFrom != nil {
+		in, out := &in.CloneFrom, &out.CloneFrom
+		*out = new(ZoneCloneSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneFile != nil {
+		in, out := &in.ZoneFile, &out.ZoneFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.ZoneFileFrom != nil {
+		in, out := &in.ZoneFileFrom, &out.ZoneFileFrom
+		*out = new(ZoneFileSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequireApproval != nil {
+		in, out := &in.RequireApproval, &out.RequireApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneSpec.
+func (in *ZoneSpec) DeepCopy() *ZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneStatus) DeepCopyInto(out *ZoneStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Serial != nil {
+		in, out := &in.Serial, &out.Serial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.NotifiedSerial != nil {
+		in, out := &in.NotifiedSerial, &out.NotifiedSerial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.EditedSerial != nil {
+		in, out := &in.EditedSerial, &out.EditedSerial
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.SOAEditAPI != nil {
+		in, out := &in.SOAEditAPI, &out.SOAEditAPI
+		*out = new(string)
+		**out = **in
+	}
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSsec != nil {
+		in, out := &in.DNSsec, &out.DNSsec
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Presigned != nil {
+		in, out := &in.Presigned, &out.Presigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSSECKeys != nil {
+		in, out := &in.DNSSECKeys, &out.DNSSECKeys
+		*out = make([]DNSSECKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ParentZone != nil {
+		in, out := &in.ParentZone, &out.ParentZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.Transfer != nil {
+		in, out := &in.Transfer, &out.Transfer
+		*out = new(ZoneTransferStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastHandledReconcileAt != nil {
+		in, out := &in.LastHandledReconcileAt, &out.LastHandledReconcileAt
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneStatus.
+func (in *ZoneStatus) DeepCopy() *ZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneTransferStatus) DeepCopyInto(out *ZoneTransferStatus) {
+	*out = *in
+	if in.LastTransferTime != nil {
+		in, out := &in.LastTransferTime, &out.LastTransferTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrimarySerial != nil {
+		in, out := &in.PrimarySerial, &out.PrimarySerial
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneTransferStatus.
+func (in *ZoneTransferStatus) DeepCopy() *ZoneTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneTransferStatus)
 	in.DeepCopyInto(out)
 	return out
 }
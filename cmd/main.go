@@ -16,25 +16,40 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"flag"
+	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/robfig/cron/v3"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/powerdns-operator/powerdns-operator/internal/controller"
+	webhookv1alpha2 "github.com/powerdns-operator/powerdns-operator/internal/webhook/v1alpha2"
 
 	powerdns "github.com/joeig/go-powerdns/v3"
 
@@ -42,11 +57,21 @@ import (
 	//+kubebuilder:scaffold:imports
 )
 
+const (
+	SHUTDOWN_GRACE_PERIOD_MARGIN = 5 * time.Second
+)
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// version and gitCommit are set at build time, with -ldflags "-X main.version=... -X main.gitCommit=..."
+var (
+	version   string
+	gitCommit string
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
@@ -57,9 +82,34 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var leaderElectionID string
+	var leaderElectionNamespace string
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
 	var probeAddr string
+	var pprofAddr string
 	var secureMetrics bool
+	var metricsCertPath, metricsCertName, metricsCertKey string
 	var enableHTTP2 bool
+	var dnssecKeyRolloverDays uint
+	var enableZoneExport bool
+	var enableExternalNameSource, enableHeadlessSource, enableIstioSource bool
+	var acmeDNSAddr, acmeDNSZone, acmeDNSNamespace, acmeDNSCertPath string
+	var acmeDNSMaxAccounts int
+	var rfc2136Addr, rfc2136Namespace, rfc2136TSIGSecret string
+	var externalDNSAddr, externalDNSNamespace string
+	var enableWebhooks bool
+	var dryRun bool
+	var validateInventory bool
+	var defaultTTL uint
+	var clusterID string
+	var conflictPolicy string
+	var checkUnmanagedRecords bool
+	var shutdownGracePeriod time.Duration
+	var pdnsHealthCheckInterval time.Duration
+	var maintenanceWindowSchedule string
+	var maintenanceWindowDuration time.Duration
 
 	// Get environment variables for PowerDNS API configuration
 	apiURL := os.Getenv("PDNS_API_URL")
@@ -68,32 +118,37 @@ func main() {
 	if apiVhost == "" {
 		apiVhost = "localhost"
 	}
-	apiInsecureStr := os.Getenv("PDNS_API_INSECURE")
-	var apiInsecure bool
-	if apiInsecureStr != "" {
-		if insecure, err := strconv.ParseBool(apiInsecureStr); err == nil {
-			apiInsecure = insecure
-		}
-	}
+	apiInsecure := getEnvBool("PDNS_API_INSECURE")
 	apiCAPath := os.Getenv("PDNS_API_CA_PATH")
+	apiPeerURLs := os.Getenv("PDNS_PEER_API_URLS")
 
 	// Parse PowerDNS API timeout from environment variable (in seconds)
-	apiTimeoutStr := os.Getenv("PDNS_API_TIMEOUT")
-	apiTimeoutSeconds := 10 // default timeout in seconds
-	if apiTimeoutStr != "" {
-		if timeout, err := strconv.Atoi(apiTimeoutStr); err == nil && timeout > 0 {
-			apiTimeoutSeconds = timeout
-		}
-	}
+	apiTimeoutSeconds := getEnvTimeout("PDNS_API_TIMEOUT", 10) // default timeout in seconds
 
 	// Parse command line flags
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "0",
+		"The address the pprof endpoint binds to (e.g. localhost:8082). Use 0 to disable it.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionID, "leader-elect-id", "6bc048b3.cav.enablers.ob",
+		"The name of the resource used as leader election lock")
+	flag.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"The namespace of the leader election lock, defaults to the namespace the manager runs in")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration that non-leader candidates will wait to force acquire leadership")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"The duration that the acting leader will retry refreshing leadership before giving up")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"The duration the leader election clients should wait between tries of actions")
 	flag.BoolVar(&secureMetrics, "metrics-secure", false,
-		"If set the metrics endpoint is served securely")
+		"If set the metrics endpoint is served securely via HTTPS, with authentication and authorization")
+	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
+		"The directory that contains the metrics server certificate, a self-signed certificate is generated if not set")
+	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file")
+	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.StringVar(&apiURL, "pdns-api-url", apiURL, "The URL of the PowerDNS API")
@@ -104,6 +159,74 @@ func main() {
 	flag.BoolVar(&apiInsecure, "pdns-api-insecure", apiInsecure,
 		"Enable insecure connections to PowerDNS API")
 	flag.StringVar(&apiCAPath, "pdns-api-ca-path", apiCAPath, "The path to certificate authority")
+	flag.StringVar(&apiPeerURLs, "pdns-peer-api-urls", apiPeerURLs,
+		"Comma-separated URLs of the PowerDNS API of the peers serving the same data, the serials of the zones are compared "+
+			"against them (same API key and vhost)")
+	flag.UintVar(&dnssecKeyRolloverDays, "dnssec-key-rollover-days", uint(controller.DEFAULT_DNSSEC_KEY_ROLLOVER_DAYS),
+		"The maximum age (in days) of an active DNSSEC key before it should be rolled over")
+	flag.DurationVar(&shutdownGracePeriod, "shutdown-grace-period", controller.DEFAULT_SHUTDOWN_GRACE_PERIOD,
+		"The time left to in-flight reconciliations to complete on shutdown, before their PowerDNS calls are cancelled")
+	flag.DurationVar(&pdnsHealthCheckInterval, "pdns-health-check-interval", controller.DEFAULT_PDNS_HEALTH_CHECK_INTERVAL,
+		"The interval between the probes of the PowerDNS API, the resources failed during an outage are resynchronized "+
+			"as soon as it is available again (0 disables the probes)")
+	flag.UintVar(&defaultTTL, "default-ttl", uint(controller.DEFAULT_TTL),
+		"The TTL (in seconds) of the records of the RRsets and ClusterRRsets not specifying one")
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"The identifier of the cluster, recorded on the records written to PowerDNS, when several clusters write into "+
+			"the same PowerDNS: the records of the other clusters are not modified, the conflicts are reported")
+	flag.StringVar(&conflictPolicy, "conflict-policy", controller.CONFLICT_POLICY_FAIL,
+		"The resolution of the conflicts between RRsets and ClusterRRsets claiming the same name and type: "+
+			"fail keeps the first one, oldest-wins and priority elect a winner (highest spec.priority, then oldest)")
+	flag.BoolVar(&checkUnmanagedRecords, "check-unmanaged-records", false,
+		"If set, the records found in PowerDNS before an RRset or a ClusterRRset is applied for the first time, "+
+			"not written by the operator, are not overwritten: they win the conflict, unless the conflict policy "+
+			"is priority and the resource has a positive spec.priority")
+	flag.StringVar(&maintenanceWindowSchedule, "maintenance-window-schedule", "",
+		"The schedule, in cron format (e.g. \"0 2 * * 6\"), of the start of the maintenance window RRset and ClusterRRset "+
+			"changes are applied in, queued outside of it, for the zones without maintenance windows (empty disables it)")
+	flag.DurationVar(&maintenanceWindowDuration, "maintenance-window-duration", time.Hour,
+		"The duration of the maintenance window of --maintenance-window-schedule")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, the changes of the zones and RRsets are not applied to PowerDNS, they are reported in the resources status")
+	flag.BoolVar(&validateInventory, "validate-inventory", true,
+		"If set, the managed zones and RRsets are compared with PowerDNS on startup, without modifying them, "+
+			"and a summary is logged and exported")
+	flag.BoolVar(&enableZoneExport, "enable-zone-export", false,
+		"If set, the managed zones are exported as zone files or octoDNS YAML on the metrics endpoint, "+
+			"under "+controller.ZONE_EXPORT_PATH+", requires --metrics-secure")
+	flag.BoolVar(&enableExternalNameSource, "enable-externalname-source", false,
+		"If set, CNAME records are published for the Services of type ExternalName annotated with "+
+			controller.SOURCE_HOSTNAME_ANNOTATION)
+	flag.BoolVar(&enableHeadlessSource, "enable-headless-source", false,
+		"If set, A/AAAA records of the ready endpoints are published for the headless Services annotated with "+
+			controller.SOURCE_HOSTNAME_ANNOTATION)
+	flag.BoolVar(&enableIstioSource, "enable-istio-source", false,
+		"If set, records of their hosts are published for the Istio Gateways and VirtualServices annotated with "+
+			controller.SOURCE_ZONE_ANNOTATION)
+	flag.StringVar(&acmeDNSAddr, "acme-dns-bind-address", "0",
+		"The address the acme-dns compatible API binds to (e.g. :8443). Use 0 to disable it.")
+	flag.StringVar(&acmeDNSZone, "acme-dns-zone", "",
+		"The name of the Zone the acme-dns compatible API writes the challenge records in")
+	flag.StringVar(&acmeDNSNamespace, "acme-dns-namespace", "",
+		"The namespace of the acme-dns Zone, where the acme-dns accounts and challenge RRsets are stored")
+	flag.StringVar(&acmeDNSCertPath, "acme-dns-cert-path", "",
+		"The directory of the tls.crt and tls.key files the acme-dns compatible API is served with in HTTPS. "+
+			"Without it, the API is served in plain HTTP and must be exposed behind a proxy terminating TLS.")
+	flag.IntVar(&acmeDNSMaxAccounts, "acme-dns-max-accounts", 100,
+		"The maximum number of acme-dns accounts, the registrations are refused beyond. Use 0 for no limit.")
+	flag.StringVar(&rfc2136Addr, "rfc2136-bind-address", "0",
+		"The address the RFC 2136 dynamic update gateway binds to, on UDP and TCP (e.g. :5353). Use 0 to disable it.")
+	flag.StringVar(&rfc2136Namespace, "rfc2136-namespace", "",
+		"The namespace of the Zones open to the dynamic updates, where the updated RRsets are stored")
+	flag.StringVar(&rfc2136TSIGSecret, "rfc2136-tsig-secret", "",
+		"The name of the Secret, in the dynamic updates namespace, containing the TSIG keys by key name")
+	flag.StringVar(&externalDNSAddr, "external-dns-webhook-bind-address", "0",
+		"The address the external-dns webhook provider API binds to (e.g. localhost:8888). Use 0 to disable it.")
+	flag.StringVar(&externalDNSNamespace, "external-dns-namespace", "",
+		"The namespace of the Zones managed by external-dns, where the endpoints RRsets are stored")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the admission webhooks are served, e.g. to convert RRset TTLs expressed as durations into seconds"+
+			" and to check the approvers of the RRsets")
 
 	opts := zap.Options{
 		Development: false,
@@ -126,6 +249,77 @@ func main() {
 	}
 	setupLog.Info("PowerDNS API vhost", "vhost", apiVhost)
 
+	if enableLeaderElection && !isValidLeaderElection(leaseDuration, renewDeadline, retryPeriod) {
+		setupLog.Error(nil, "leader election durations must verify 0 < retry period < renew deadline < lease duration",
+			"leaseDuration", leaseDuration, "renewDeadline", renewDeadline, "retryPeriod", retryPeriod)
+		os.Exit(1)
+	}
+
+	if isMissingFlag(acmeDNSAddr, acmeDNSZone, acmeDNSNamespace) {
+		setupLog.Error(nil, "--acme-dns-zone and --acme-dns-namespace are required with --acme-dns-bind-address")
+		os.Exit(1)
+	}
+
+	if isMissingFlag(rfc2136Addr, rfc2136Namespace, rfc2136TSIGSecret) {
+		setupLog.Error(nil, "--rfc2136-namespace and --rfc2136-tsig-secret are required with --rfc2136-bind-address")
+		os.Exit(1)
+	}
+
+	if enableZoneExport && !secureMetrics {
+		setupLog.Error(nil, "--metrics-secure is required with --enable-zone-export")
+		os.Exit(1)
+	}
+
+	if isMissingFlag(externalDNSAddr, externalDNSNamespace) {
+		setupLog.Error(nil, "--external-dns-namespace is required with --external-dns-webhook-bind-address")
+		os.Exit(1)
+	}
+
+	if strings.ContainsAny(clusterID, "/ \t") {
+		setupLog.Error(nil, "cluster id must not contain slashes or spaces", "clusterID", clusterID)
+		os.Exit(1)
+	}
+
+	if !slices.Contains(controller.CONFLICT_POLICIES, conflictPolicy) {
+		setupLog.Error(nil, "unknown conflict policy", "conflictPolicy", conflictPolicy,
+			"supported", controller.CONFLICT_POLICIES)
+		os.Exit(1)
+	}
+
+	if defaultTTL > math.MaxInt32 {
+		setupLog.Error(nil, "default TTL must not exceed 2147483647 seconds", "defaultTTL", defaultTTL)
+		os.Exit(1)
+	}
+
+	maintenanceWindows, err := getMaintenanceWindows(maintenanceWindowSchedule, maintenanceWindowDuration)
+	if err != nil {
+		setupLog.Error(err, "invalid maintenance window, a cron schedule and a positive duration are required",
+			"schedule", maintenanceWindowSchedule, "duration", maintenanceWindowDuration)
+		os.Exit(1)
+	}
+
+	// The version and the enabled features are exposed for the audit of the operators of a fleet
+	buildVersion, buildCommit := getBuildInfo()
+	setupLog.Info("Starting operator", "version", buildVersion, "gitCommit", buildCommit)
+	controller.SetBuildInfoMetric(buildVersion, buildCommit, apiURL, apiVhost, map[string]bool{
+		"dry-run":                 dryRun,
+		"webhooks":                enableWebhooks,
+		"zone-export":             enableZoneExport,
+		"externalname-source":     enableExternalNameSource,
+		"headless-source":         enableHeadlessSource,
+		"istio-source":            enableIstioSource,
+		"acme-dns":                isEnabledEndpoint(acmeDNSAddr),
+		"rfc2136":                 isEnabledEndpoint(rfc2136Addr),
+		"external-dns-webhook":    isEnabledEndpoint(externalDNSAddr),
+		"check-unmanaged-records": checkUnmanagedRecords,
+		"conflict-resolution":     conflictPolicy != controller.CONFLICT_POLICY_FAIL,
+		"maintenance-window":      maintenanceWindowSchedule != "",
+		"peers":                   apiPeerURLs != "",
+		"leader-election":         enableLeaderElection,
+		"metrics-secure":          secureMetrics,
+		"validate-inventory":      validateInventory,
+	})
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -146,17 +340,43 @@ func main() {
 		TLSOpts: tlsOpts,
 	})
 
+	// Metrics endpoint is protected by authentication and authorization when served securely:
+	// requests must carry a token allowed to "get" the "/metrics" non-resource URL
+	metricsServerOptions := metricsserver.Options{
+		BindAddress:   metricsAddr,
+		SecureServing: secureMetrics,
+		TLSOpts:       tlsOpts,
+	}
+	if secureMetrics {
+		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+	}
+
+	// Certificates are reloaded on change, so that they can be rotated (e.g. by cert-manager)
+	metricsCertWatcher, err := newMetricsCertWatcher(metricsCertPath, metricsCertName, metricsCertKey)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize metrics certificate watcher")
+		os.Exit(1)
+	}
+	if metricsCertWatcher != nil {
+		metricsServerOptions.TLSOpts = append(metricsServerOptions.TLSOpts, func(c *tls.Config) {
+			c.GetCertificate = metricsCertWatcher.GetCertificate
+		})
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress:   metricsAddr,
-			SecureServing: secureMetrics,
-			TLSOpts:       tlsOpts,
-		},
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "6bc048b3.cav.enablers.ob",
+		Scheme:                  scheme,
+		Metrics:                 metricsServerOptions,
+		WebhookServer:           webhookServer,
+		HealthProbeBindAddress:  probeAddr,
+		PprofBindAddress:        pprofAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        leaderElectionID,
+		LeaderElectionNamespace: leaderElectionNamespace,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		// The manager waits a bit longer than the reconciliations, so that their status patches complete
+		GracefulShutdownTimeout: ptr.To(shutdownGracePeriod + SHUTDOWN_GRACE_PERIOD_MARGIN),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -175,97 +395,509 @@ func main() {
 	}
 
 	// Initialize a http.Client to communicate with PowerDNS API
-	var httpClient *http.Client
+	httpClient, err := newPDNSHTTPClient(apiInsecure, apiCAPath)
+	if err != nil {
+		setupLog.Error(err, "unable to load CA certificate")
+		os.Exit(1)
+	}
+
+	pdnsClient, err := PDNSClientInitializer(apiURL, apiKey, apiVhost, apiTimeoutSeconds,
+		httpClient)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize connection with PowerDNS server")
+		os.Exit(1)
+	}
+	pdnsPeers := getPDNSPeers(apiPeerURLs, apiVhost, apiKey, httpClient)
+	connectivityMonitor := setupConnectivityMonitor(mgr, pdnsClient, apiVhost, pdnsHealthCheckInterval)
+
+	rrsetOptions := controller.RRsetOptions{
+		DryRun:                dryRun,
+		DefaultTTL:            uint32(defaultTTL),
+		ClusterID:             clusterID,
+		ConflictPolicy:        conflictPolicy,
+		CheckUnmanagedRecords: checkUnmanagedRecords,
+		MaintenanceWindows:    maintenanceWindows,
+		// The validating webhook checks the approvers of the RRsets
+		VerifiedApprovals: enableWebhooks,
+	}
+	zoneOptions := controller.ZoneOptions{
+		DryRun:                dryRun,
+		DNSSECKeyRolloverDays: uint32(dnssecKeyRolloverDays),
+		ClusterID:             clusterID,
+		Reader:                mgr.GetAPIReader(),
+	}
+	setupZonesAndRRsets(mgr, pdnsClient, pdnsPeers, zoneOptions, rrsetOptions, shutdownGracePeriod,
+		connectivityMonitor)
+	setupBackups(mgr, pdnsClient, shutdownGracePeriod)
+	setupGenerators(mgr, shutdownGracePeriod)
+	setupSources(mgr, enableExternalNameSource, enableHeadlessSource, enableIstioSource, shutdownGracePeriod)
+	if enableWebhooks {
+		setupWebhooks(mgr)
+	}
+	//+kubebuilder:scaffold:builder
+
+	setupZoneExport(mgr, enableZoneExport, pdnsClient)
+
+	setupAcmeDNS(mgr, acmeDNSAddr, acmeDNSZone, acmeDNSNamespace, acmeDNSCertPath, acmeDNSMaxAccounts,
+		shutdownGracePeriod)
+
+	if validateInventory {
+		setupInventoryValidator(mgr, pdnsClient, uint32(defaultTTL), clusterID)
+	}
+
+	setupRFC2136(mgr, rfc2136Addr, rfc2136Namespace, rfc2136TSIGSecret)
+	setupExternalDNS(mgr, externalDNSAddr, externalDNSNamespace, uint32(defaultTTL), shutdownGracePeriod)
+
+	if metricsCertWatcher != nil {
+		if err := mgr.Add(metricsCertWatcher); err != nil {
+			setupLog.Error(err, "unable to add metrics certificate watcher to manager")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// getEnvBool return the boolean value of the environment variable, false if not set or invalid
+func getEnvBool(name string) bool {
+	value, err := strconv.ParseBool(os.Getenv(name))
+	return err == nil && value
+}
+
+// getEnvTimeout return the positive number of seconds of the environment variable, the default if not set or invalid
+func getEnvTimeout(name string, defaultSeconds int) int {
+	if timeout, err := strconv.Atoi(os.Getenv(name)); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultSeconds
+}
+
+// isValidLeaderElection return True if the leader election durations verify
+// 0 < retry period < renew deadline < lease duration
+func isValidLeaderElection(leaseDuration, renewDeadline, retryPeriod time.Duration) bool {
+	return retryPeriod > 0 && renewDeadline > retryPeriod && leaseDuration > renewDeadline
+}
+
+// isEnabledEndpoint return True if the endpoint is bound to an address, 0 disables it
+func isEnabledEndpoint(addr string) bool {
+	return addr != "0"
+}
+
+// isMissingFlag return True if the endpoint is enabled without one of the flags it requires
+func isMissingFlag(addr string, required ...string) bool {
+	return isEnabledEndpoint(addr) && slices.Contains(required, "")
+}
+
+// newMetricsCertWatcher return the watcher of the metrics server certificate, nil if none is provided
+func newMetricsCertWatcher(certPath string, certName string, certKey string) (*certwatcher.CertWatcher, error) {
+	if certPath == "" {
+		return nil, nil
+	}
+	setupLog.Info("Initializing metrics certificate watcher using provided certificates",
+		"metrics-cert-path", certPath, "metrics-cert-name", certName, "metrics-cert-key", certKey)
+	return certwatcher.New(
+		filepath.Join(certPath, certName),
+		filepath.Join(certPath, certKey),
+	)
+}
+
+// getMaintenanceWindows return the default maintenance window of the zones, none if no schedule is set
+func getMaintenanceWindows(schedule string, duration time.Duration) ([]dnsv1alpha2.MaintenanceWindow, error) {
+	if schedule == "" {
+		return nil, nil
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("non-positive duration %s", duration)
+	}
+	return []dnsv1alpha2.MaintenanceWindow{{
+		Schedule: schedule,
+		Duration: metav1.Duration{Duration: duration},
+	}}, nil
+}
+
+// newPDNSHTTPClient return the http.Client communicating with the PowerDNS API, trusting the CA certificate if any
+func newPDNSHTTPClient(insecure bool, caPath string) (*http.Client, error) {
 	tlsConfig := &tls.Config{
-		InsecureSkipVerify: apiInsecure,
+		InsecureSkipVerify: insecure,
 	}
-	if apiInsecure {
+	if insecure {
 		setupLog.Info("the communication with PowerDNS API is set as insecure")
 	}
 
-	if apiCAPath != "" {
-		caCert, err := os.ReadFile(apiCAPath)
+	if caPath != "" {
+		caCert, err := os.ReadFile(caPath)
 		if err != nil {
-			setupLog.Error(err, "unable to load CA certificate")
-			os.Exit(1)
+			return nil, err
 		}
 		caCertPool := x509.NewCertPool()
-		ok := caCertPool.AppendCertsFromPEM(caCert)
-		if !ok {
-			setupLog.Error(err, "unable to parse CA certificate")
-			os.Exit(1)
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("unable to parse CA certificate %s", caPath)
 		}
-		setupLog.Info("CA certificate parsed successfully", "apiCAPath", apiCAPath)
+		setupLog.Info("CA certificate parsed successfully", "apiCAPath", caPath)
 		tlsConfig.RootCAs = caCertPool
 	}
 
 	tr := &http.Transport{TLSClientConfig: tlsConfig}
-	httpClient = &http.Client{Transport: tr}
+	return &http.Client{Transport: tr}, nil
+}
 
-	pdnsClient, err := PDNSClientInitializer(apiURL, apiKey, apiVhost, apiTimeoutSeconds,
-		httpClient)
-	if err != nil {
-		setupLog.Error(err, "unable to initialize connection with PowerDNS server")
+// getPDNSPeers return the clients of the PowerDNS peers serving the same data
+// The peers are not required to be reachable at startup, a divergence is reported instead
+func getPDNSPeers(peerURLs string, vhost string, key string, httpClient *http.Client) []controller.PdnsPeer {
+	var pdnsPeers []controller.PdnsPeer
+	for _, peerURL := range strings.Split(peerURLs, ",") {
+		if peerURL = strings.TrimSpace(peerURL); peerURL == "" {
+			continue
+		}
+		peerClient := powerdns.New(peerURL, vhost, powerdns.WithAPIKey(key), powerdns.WithHTTPClient(httpClient))
+		pdnsPeers = append(pdnsPeers, controller.PdnsPeer{URL: peerURL, Zones: peerClient.Zones})
+		setupLog.Info("PowerDNS peer API URL", "url", peerURL)
+	}
+	return pdnsPeers
+}
+
+// setupConnectivityMonitor probe the PowerDNS API, and return the monitor, nil if the probes are disabled
+// The resources failed during a PowerDNS outage are resynchronized on recovery, instead of on their backoff
+func setupConnectivityMonitor(mgr ctrl.Manager, pdnsClient *powerdns.Client, vhost string,
+	interval time.Duration) *controller.ConnectivityMonitor {
+	if interval <= 0 {
+		return nil
+	}
+	connectivityMonitor := &controller.ConnectivityMonitor{
+		Client:   mgr.GetClient(),
+		Servers:  pdnsClient.Servers,
+		Vhost:    vhost,
+		Interval: interval,
+	}
+	if err := mgr.Add(connectivityMonitor); err != nil {
+		setupLog.Error(err, "unable to set up PowerDNS connectivity monitor")
 		os.Exit(1)
 	}
-	if err = (&controller.ZoneReconciler{
+	return connectivityMonitor
+}
+
+// setupZonesAndRRsets set up the controllers of the Zones, ClusterZones, RRsets and ClusterRRsets
+func setupZonesAndRRsets(mgr ctrl.Manager, pdnsClient *powerdns.Client, pdnsPeers []controller.PdnsPeer,
+	zoneOptions controller.ZoneOptions, rrsetOptions controller.RRsetOptions, shutdownGracePeriod time.Duration,
+	connectivityMonitor *controller.ConnectivityMonitor) {
+	if err := (&controller.ZoneReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
+			Peers:      pdnsPeers,
 		},
+		ZoneOptions:         zoneOptions,
+		ShutdownGracePeriod: shutdownGracePeriod,
+		Resync:              connectivityMonitor.Source(&dnsv1alpha2.ZoneList{}),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Zone")
 		os.Exit(1)
 	}
-	if err = (&controller.RRsetReconciler{
+	if err := (&controller.RRsetReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
 		},
+		RRsetOptions:        rrsetOptions,
+		ShutdownGracePeriod: shutdownGracePeriod,
+		Resync:              connectivityMonitor.Source(&dnsv1alpha2.RRsetList{}),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RRset")
 		os.Exit(1)
 	}
-	if err = (&controller.ClusterZoneReconciler{
+	if err := (&controller.ClusterZoneReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
+			Peers:      pdnsPeers,
 		},
+		ZoneOptions:         zoneOptions,
+		ShutdownGracePeriod: shutdownGracePeriod,
+		Resync:              connectivityMonitor.Source(&dnsv1alpha2.ClusterZoneList{}),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterZone")
 		os.Exit(1)
 	}
-	if err = (&controller.ClusterRRsetReconciler{
+	if err := (&controller.ClusterRRsetReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		PDNSClient: controller.PdnsClienter{
-			Records: pdnsClient.Records,
-			Zones:   pdnsClient.Zones,
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
 		},
+		RRsetOptions:        rrsetOptions,
+		ShutdownGracePeriod: shutdownGracePeriod,
+		Resync:              connectivityMonitor.Source(&dnsv1alpha2.ClusterRRsetList{}),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterRRset")
 		os.Exit(1)
 	}
-	//+kubebuilder:scaffold:builder
+}
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
+// setupWebhooks register the admission webhooks
+func setupWebhooks(mgr ctrl.Manager) {
+	if err := webhookv1alpha2.SetupRRsetWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "RRset")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
+	if err := webhookv1alpha2.SetupZoneBackupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ZoneBackup")
 		os.Exit(1)
 	}
+}
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+// setupBackups set up the controllers backing up and restoring the zones
+func setupBackups(mgr ctrl.Manager, pdnsClient *powerdns.Client, shutdownGracePeriod time.Duration) {
+	if err := (&controller.ZoneBackupReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		PDNSClient: controller.PdnsClienter{
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
+		},
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ZoneBackup")
+		os.Exit(1)
+	}
+	if err := (&controller.ZoneRestoreReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		PDNSClient: controller.PdnsClienter{
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
+		},
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ZoneRestore")
+		os.Exit(1)
+	}
+}
+
+// setupGenerators set up the controllers generating RRsets from the SSHFPRecords, TLSARecords, MailDomains
+// and DomainVerifications
+func setupGenerators(mgr ctrl.Manager, shutdownGracePeriod time.Duration) {
+	if err := (&controller.SSHFPRecordReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SSHFPRecord")
+		os.Exit(1)
+	}
+	if err := (&controller.TLSARecordReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TLSARecord")
+		os.Exit(1)
+	}
+	if err := (&controller.MailDomainReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MailDomain")
+		os.Exit(1)
+	}
+	if err := (&controller.DomainVerificationReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		ShutdownGracePeriod: shutdownGracePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DomainVerification")
+		os.Exit(1)
+	}
+}
+
+// setupZoneExport serve the export of the managed zones on the metrics endpoint, if enabled
+func setupZoneExport(mgr ctrl.Manager, enabled bool, pdnsClient *powerdns.Client) {
+	if !enabled {
+		return
+	}
+	if err := mgr.AddMetricsServerExtraHandler(controller.ZONE_EXPORT_PATH, &controller.ZoneExportHandler{
+		Client: mgr.GetClient(),
+		PDNSClient: controller.PdnsClienter{
+			Records:    pdnsClient.Records,
+			Zones:      pdnsClient.Zones,
+			Cryptokeys: pdnsClient.Cryptokeys,
+			Metadata:   pdnsClient.Metadata,
+		},
+	}); err != nil {
+		setupLog.Error(err, "unable to set up zone export endpoint")
+		os.Exit(1)
+	}
+}
+
+// setupInventoryValidator compare the managed zones and RRsets with PowerDNS on startup
+func setupInventoryValidator(mgr ctrl.Manager, pdnsClient *powerdns.Client, defaultTTL uint32, clusterID string) {
+	if err := mgr.Add(&controller.InventoryValidator{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+		PDNSClient: controller.PdnsClienter{
+			Records: pdnsClient.Records,
+			Zones:   pdnsClient.Zones,
+		},
+		Recorder:   mgr.GetEventRecorder("powerdns-operator"),
+		DefaultTTL: defaultTTL,
+		ClusterID:  clusterID,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up startup inventory validation")
+		os.Exit(1)
+	}
+}
+
+// setupSources set up the controllers publishing the records of the annotated Services, Istio Gateways
+// and VirtualServices
+func setupSources(mgr ctrl.Manager, externalName bool, headless bool, istio bool, shutdownGracePeriod time.Duration) {
+	if externalName || headless {
+		if err := (&controller.ServiceSourceReconciler{
+			Client:              mgr.GetClient(),
+			Scheme:              mgr.GetScheme(),
+			ExternalName:        externalName,
+			Headless:            headless,
+			ShutdownGracePeriod: shutdownGracePeriod,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ServiceSource")
+			os.Exit(1)
+		}
+	}
+	if !istio {
+		return
+	}
+	for _, kind := range []string{controller.ISTIO_GATEWAY_KIND, controller.ISTIO_VIRTUAL_SERVICE_KIND} {
+		if err := (&controller.IstioSourceReconciler{
+			Client:              mgr.GetClient(),
+			Scheme:              mgr.GetScheme(),
+			Kind:                kind,
+			ShutdownGracePeriod: shutdownGracePeriod,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Istio"+kind+"Source")
+			os.Exit(1)
+		}
+	}
+}
+
+// setupAcmeDNS serve the acme-dns compatible API, if bound to an address
+// The API is served by all the replicas, the records are written through the Kubernetes API
+func setupAcmeDNS(mgr ctrl.Manager, addr string, zone string, namespace string, certPath string, maxAccounts int,
+	shutdownGracePeriod time.Duration) {
+	if !isEnabledEndpoint(addr) {
+		return
+	}
+	// Served in HTTPS with the certificate of the directory, reloaded on renewal, if any
+	var listener net.Listener
+	if certPath != "" {
+		certWatcher, err := certwatcher.New(filepath.Join(certPath, "tls.crt"), filepath.Join(certPath, "tls.key"))
+		if err == nil {
+			err = mgr.Add(certWatcher)
+		}
+		if err == nil {
+			listener, err = tls.Listen("tcp", addr, &tls.Config{
+				GetCertificate: certWatcher.GetCertificate,
+				MinVersion:     tls.VersionTLS12,
+			})
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up acme-dns certificate")
+			os.Exit(1)
+		}
+	}
+	if err := mgr.Add(&manager.Server{
+		Name: "acme-dns",
+		Server: &http.Server{
+			Addr: addr,
+			Handler: &controller.AcmeDNSHandler{
+				Client:    mgr.GetClient(),
+				Reader:    mgr.GetAPIReader(),
+				Zone:      zone,
+				Namespace: namespace,
+				RegisterLimiter: rate.NewLimiter(rate.Every(controller.ACME_DNS_REGISTER_INTERVAL),
+					controller.ACME_DNS_REGISTER_BURST),
+				MaxAccounts: maxAccounts,
+			},
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		Listener:        listener,
+		ShutdownTimeout: ptr.To(shutdownGracePeriod),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up acme-dns endpoint")
+		os.Exit(1)
+	}
+}
+
+// setupRFC2136 serve the RFC 2136 dynamic update gateway, if bound to an address
+func setupRFC2136(mgr ctrl.Manager, addr string, namespace string, tsigSecret string) {
+	if !isEnabledEndpoint(addr) {
+		return
+	}
+	if err := mgr.Add(&controller.RFC2136Gateway{
+		Client:     mgr.GetClient(),
+		Reader:     mgr.GetAPIReader(),
+		Addr:       addr,
+		Namespace:  namespace,
+		TSIGSecret: tsigSecret,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up RFC 2136 dynamic update gateway")
+		os.Exit(1)
+	}
+}
+
+// setupExternalDNS serve the external-dns webhook provider API, if bound to an address
+func setupExternalDNS(mgr ctrl.Manager, addr string, namespace string, defaultTTL uint32,
+	shutdownGracePeriod time.Duration) {
+	if !isEnabledEndpoint(addr) {
+		return
+	}
+	if err := mgr.Add(&manager.Server{
+		Name: "external-dns-webhook",
+		Server: &http.Server{
+			Addr: addr,
+			Handler: &controller.ExternalDNSWebhook{
+				Client:     mgr.GetClient(),
+				Namespace:  namespace,
+				DefaultTTL: defaultTTL,
+			},
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		ShutdownTimeout: ptr.To(shutdownGracePeriod),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up external-dns webhook provider endpoint")
 		os.Exit(1)
 	}
 }
@@ -303,3 +935,20 @@ func PDNSClientInitializer(baseURL string, key string, vhost string, timeoutSeco
 
 	return client, nil
 }
+
+// getBuildInfo return the version and the git commit of the operator, read from the build information
+// of the binary when not set at build time
+func getBuildInfo() (string, string) {
+	buildVersion, buildCommit := version, gitCommit
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if buildVersion == "" {
+			buildVersion = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && buildCommit == "" {
+				buildCommit = setting.Value
+			}
+		}
+	}
+	return buildVersion, buildCommit
+}
@@ -0,0 +1,223 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// pdnsctl imports existing PowerDNS zones into resources, exports resources to zone files and
+// shows the differences between resources and PowerDNS content.
+// Installed in the PATH as kubectl-pdnsctl, it is usable as a kubectl plugin (kubectl pdnsctl).
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	powerdns "github.com/joeig/go-powerdns/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/controller"
+)
+
+const usage = `Usage: pdnsctl <command> --zone <name> [flags]
+
+Commands:
+  import  Print the resources describing a zone existing in PowerDNS
+  export  Print the zone file rendered from the resources of a zone
+  diff    Print the differences between PowerDNS content ("-") and the resources of a zone ("+")
+
+Flags:
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(dnsv1alpha2.AddToScheme(scheme))
+}
+
+// options holds the flags of the commands
+type options struct {
+	zoneRef     dnsv1alpha2.ZoneRef
+	namespace   string
+	apiURL      string
+	apiKey      string
+	apiVhost    string
+	apiInsecure bool
+	timeout     time.Duration
+	defaultTTL  uint32
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	var zoneName, zoneKind, namespace string
+	apiInsecure, _ := strconv.ParseBool(os.Getenv("PDNS_API_INSECURE"))
+	apiVhost := os.Getenv("PDNS_API_VHOST")
+	if apiVhost == "" {
+		apiVhost = "localhost"
+	}
+	apiURL := os.Getenv("PDNS_API_URL")
+	apiKey := os.Getenv("PDNS_API_KEY")
+	timeout := 30 * time.Second
+	var defaultTTL uint
+
+	flag.StringVar(&zoneName, "zone", "", "The name of the zone")
+	flag.StringVar(&zoneKind, "kind", "ClusterZone", "The kind of the zone resource (Zone or ClusterZone)")
+	flag.StringVar(&namespace, "namespace", "default", "The namespace of the Zone and RRset resources")
+	flag.StringVar(&apiURL, "pdns-api-url", apiURL, "The URL of the PowerDNS API")
+	flag.StringVar(&apiKey, "pdns-api-key", apiKey, "The API key to authenticate with the PowerDNS API")
+	flag.StringVar(&apiVhost, "pdns-api-vhost", apiVhost, "The vhost of the PowerDNS API")
+	flag.BoolVar(&apiInsecure, "pdns-api-insecure", apiInsecure, "Enable insecure connections to PowerDNS API")
+	flag.DurationVar(&timeout, "timeout", timeout, "The timeout of the command")
+	flag.UintVar(&defaultTTL, "default-ttl", uint(controller.DEFAULT_TTL),
+		"The TTL of the records of the RRsets not specifying one, as set on the operator")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	if err := flag.CommandLine.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+
+	differences, err := run(command, options{
+		zoneRef:     dnsv1alpha2.ZoneRef{Name: zoneName, Kind: zoneKind},
+		namespace:   namespace,
+		apiURL:      apiURL,
+		apiKey:      apiKey,
+		apiVhost:    apiVhost,
+		apiInsecure: apiInsecure,
+		timeout:     timeout,
+		defaultTTL:  uint32(defaultTTL),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	// Like diff(1), exit with 1 when differences are found, 2 on errors
+	if differences {
+		os.Exit(1)
+	}
+}
+
+// run execute the command, and return whether differences were found by the diff command
+func run(command string, opts options) (bool, error) {
+	if opts.zoneRef.Name == "" {
+		return false, fmt.Errorf("--zone flag is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	switch command {
+	case "import":
+		pdnsClient, err := newPDNSClient(opts)
+		if err != nil {
+			return false, err
+		}
+		objects, err := controller.ImportZone(ctx, opts.zoneRef, opts.namespace, pdnsClient)
+		if err != nil {
+			return false, err
+		}
+		for _, o := range objects {
+			if err := printObject(o); err != nil {
+				return false, err
+			}
+		}
+	case "export":
+		cl, err := newKubernetesClient()
+		if err != nil {
+			return false, err
+		}
+		zoneFile, err := controller.ExportZone(ctx, opts.zoneRef, opts.namespace, opts.defaultTTL, cl)
+		if err != nil {
+			return false, err
+		}
+		fmt.Print(zoneFile)
+	case "diff":
+		cl, err := newKubernetesClient()
+		if err != nil {
+			return false, err
+		}
+		pdnsClient, err := newPDNSClient(opts)
+		if err != nil {
+			return false, err
+		}
+		diff, err := controller.DiffZone(ctx, opts.zoneRef, opts.namespace, opts.defaultTTL, cl, pdnsClient)
+		if err != nil {
+			return false, err
+		}
+		for _, line := range diff {
+			fmt.Println(line)
+		}
+		return len(diff) > 0, nil
+	default:
+		return false, fmt.Errorf("unknown command %q", command)
+	}
+	return false, nil
+}
+
+func newPDNSClient(opts options) (controller.PdnsClienter, error) {
+	if opts.apiURL == "" || opts.apiKey == "" {
+		return controller.PdnsClienter{}, fmt.Errorf("PDNS_API_URL and PDNS_API_KEY environment variables " +
+			"or --pdns-api-url and --pdns-api-key flags are required")
+	}
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.apiInsecure},
+	}}
+	pdnsClient := powerdns.New(opts.apiURL, opts.apiVhost, powerdns.WithAPIKey(opts.apiKey),
+		powerdns.WithHTTPClient(httpClient))
+	return controller.PdnsClienter{
+		Records:    pdnsClient.Records,
+		Zones:      pdnsClient.Zones,
+		Cryptokeys: pdnsClient.Cryptokeys,
+		Metadata:   pdnsClient.Metadata,
+	}, nil
+}
+
+func newKubernetesClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// printObject print a resource as a YAML document, without its status
+func printObject(o client.Object) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return err
+	}
+	delete(content, "status")
+	delete(content["metadata"].(map[string]any), "creationTimestamp")
+	out, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("---\n%s", out)
+	return nil
+}
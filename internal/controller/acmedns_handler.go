@@ -0,0 +1,345 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ACME_DNS_REGISTER_PATH  = "/register"
+	ACME_DNS_UPDATE_PATH    = "/update"
+	ACME_DNS_HEALTH_PATH    = "/health"
+	ACME_DNS_USER_HEADER    = "X-Api-User"
+	ACME_DNS_KEY_HEADER     = "X-Api-Key"
+	ACME_DNS_ACCOUNT_LABEL  = "dns.cav.enablers.ob/acme-dns-account"
+	ACME_DNS_RESOURCE       = "acme-dns-"
+	ACME_DNS_PASSWORD_CHARS = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+	ACME_DNS_PASSWORD_SIZE  = 40
+	// ACME_DNS_TXT_VALUES is the number of TXT values kept by subdomain, to validate a certificate
+	// for a domain and its wildcard at once
+	ACME_DNS_TXT_VALUES = 2
+	ACME_DNS_TTL        = uint32(1)
+	// ACME_DNS_MAX_BODY_SIZE is the maximum size in bytes of the request bodies
+	ACME_DNS_MAX_BODY_SIZE = 4096
+	// ACME_DNS_REGISTER_INTERVAL and ACME_DNS_REGISTER_BURST limit the rate of the registrations, by replica
+	ACME_DNS_REGISTER_INTERVAL = 10 * time.Second
+	ACME_DNS_REGISTER_BURST    = 5
+)
+
+// acmeChallengeRegexp matches the ACME DNS-01 challenge values: base64url encoded SHA-256 digests
+var acmeChallengeRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// AcmeDNSHandler serves an acme-dns compatible API (https://github.com/joohoi/acme-dns): ACME clients
+// register an account (POST /register), get a subdomain of the zone and update its TXT records (POST /update).
+// The users CNAME their _acme-challenge records to the subdomain of their account.
+// The accounts are stored in Secrets and the TXT records in RRsets, in the namespace of the Zone.
+// The registrations are not authenticated, as with acme-dns: they are rate limited and the number of accounts is capped.
+type AcmeDNSHandler struct {
+	Client client.Client
+	// Reader reads the accounts without cache, an account can be updated right after its registration
+	Reader    client.Reader
+	Zone      string
+	Namespace string
+	// RegisterLimiter limits the rate of the registrations, if not nil
+	RegisterLimiter *rate.Limiter
+	// MaxAccounts is the maximum number of accounts, unlimited if 0
+	MaxAccounts int
+
+	// registerMu serializes the registrations, the number of accounts is checked before each creation
+	registerMu sync.Mutex
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+
+type acmeDNSRegistration struct {
+	AllowFrom []string `json:"allowfrom,omitempty"`
+}
+
+type acmeDNSAccount struct {
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	FullDomain string   `json:"fulldomain"`
+	Subdomain  string   `json:"subdomain"`
+	AllowFrom  []string `json:"allowfrom"`
+}
+
+type acmeDNSUpdate struct {
+	Subdomain string `json:"subdomain"`
+	TXT       string `json:"txt"`
+}
+
+func (h *AcmeDNSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, ACME_DNS_MAX_BODY_SIZE)
+	switch {
+	case r.URL.Path == ACME_DNS_HEALTH_PATH && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == ACME_DNS_REGISTER_PATH && r.Method == http.MethodPost:
+		h.register(w, r)
+	case r.URL.Path == ACME_DNS_UPDATE_PATH && r.Method == http.MethodPost:
+		h.update(w, r)
+	case r.URL.Path == ACME_DNS_HEALTH_PATH || r.URL.Path == ACME_DNS_REGISTER_PATH || r.URL.Path == ACME_DNS_UPDATE_PATH:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *AcmeDNSHandler) register(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("acme-dns")
+	if h.RegisterLimiter != nil && !h.RegisterLimiter.Allow() {
+		writeAcmeDNSError(w, http.StatusTooManyRequests, "too_many_registrations")
+		return
+	}
+	registration := acmeDNSRegistration{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&registration); err != nil {
+			writeAcmeDNSError(w, http.StatusBadRequest, "malformed_json_payload")
+			return
+		}
+	}
+	for _, cidr := range registration.AllowFrom {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			writeAcmeDNSError(w, http.StatusBadRequest, "invalid_allowfrom_cidr")
+			return
+		}
+	}
+
+	h.registerMu.Lock()
+	defer h.registerMu.Unlock()
+	if full, err := h.isFull(r.Context()); err != nil || full {
+		if err != nil {
+			log.Error(err, "Failed to count accounts")
+		} else {
+			log.Info("Registration refused, maximum number of accounts reached", "max", h.MaxAccounts)
+		}
+		writeAcmeDNSError(w, http.StatusInternalServerError, "failed_to_create_user")
+		return
+	}
+
+	password, err := generateAcmeDNSPassword()
+	if err != nil {
+		log.Error(err, "Failed to generate password")
+		writeAcmeDNSError(w, http.StatusInternalServerError, "failed_to_create_user")
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error(err, "Failed to hash password")
+		writeAcmeDNSError(w, http.StatusInternalServerError, "failed_to_create_user")
+		return
+	}
+	account := acmeDNSAccount{
+		Username:  uuid.NewString(),
+		Password:  password,
+		Subdomain: uuid.NewString(),
+		AllowFrom: registration.AllowFrom,
+	}
+	account.FullDomain = account.Subdomain + "." + strings.TrimSuffix(h.Zone, ".")
+	if account.AllowFrom == nil {
+		account.AllowFrom = []string{}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ACME_DNS_RESOURCE + account.Username,
+			Namespace: h.Namespace,
+			Labels:    map[string]string{ACME_DNS_ACCOUNT_LABEL: "true"},
+		},
+		Data: map[string][]byte{
+			"password":  hash,
+			"subdomain": []byte(account.Subdomain),
+			"allowfrom": []byte(strings.Join(account.AllowFrom, ",")),
+		},
+	}
+	if err := h.Client.Create(r.Context(), secret); err != nil {
+		log.Error(err, "Failed to store account")
+		writeAcmeDNSError(w, http.StatusInternalServerError, "failed_to_create_user")
+		return
+	}
+	log.Info("Registered acme-dns account", "subdomain", account.Subdomain)
+	writeAcmeDNSResponse(w, http.StatusCreated, account)
+}
+
+func (h *AcmeDNSHandler) update(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("acme-dns")
+	username, password := r.Header.Get(ACME_DNS_USER_HEADER), r.Header.Get(ACME_DNS_KEY_HEADER)
+	if uuid.Validate(username) != nil || password == "" {
+		writeAcmeDNSError(w, http.StatusUnauthorized, "forbidden")
+		return
+	}
+	secret := &corev1.Secret{}
+	if err := h.Reader.Get(r.Context(), client.ObjectKey{Namespace: h.Namespace, Name: ACME_DNS_RESOURCE + username}, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to read account")
+		}
+		writeAcmeDNSError(w, http.StatusUnauthorized, "forbidden")
+		return
+	}
+	if bcrypt.CompareHashAndPassword(secret.Data["password"], []byte(password)) != nil {
+		writeAcmeDNSError(w, http.StatusUnauthorized, "forbidden")
+		return
+	}
+	if !isAcmeDNSAllowedFrom(r.RemoteAddr, splitAcmeDNSAllowFrom(string(secret.Data["allowfrom"]))) {
+		writeAcmeDNSError(w, http.StatusUnauthorized, "forbidden")
+		return
+	}
+
+	update := acmeDNSUpdate{}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeAcmeDNSError(w, http.StatusBadRequest, "malformed_json_payload")
+		return
+	}
+	if update.Subdomain != string(secret.Data["subdomain"]) {
+		writeAcmeDNSError(w, http.StatusUnauthorized, "forbidden")
+		return
+	}
+	if !acmeChallengeRegexp.MatchString(update.TXT) {
+		writeAcmeDNSError(w, http.StatusBadRequest, "bad_txt")
+		return
+	}
+
+	values := rotateAcmeDNSValues(strings.Fields(string(secret.Data["txt"])), update.TXT)
+	if err := h.updateRRset(r.Context(), update.Subdomain, values); err != nil {
+		log.Error(err, "Failed to update RRset", "subdomain", update.Subdomain)
+		writeAcmeDNSError(w, http.StatusInternalServerError, "db_error")
+		return
+	}
+	secret.Data["txt"] = []byte(strings.Join(values, " "))
+	if err := h.Client.Update(r.Context(), secret); err != nil {
+		log.Error(err, "Failed to update account", "subdomain", update.Subdomain)
+		writeAcmeDNSError(w, http.StatusInternalServerError, "db_error")
+		return
+	}
+	writeAcmeDNSResponse(w, http.StatusOK, map[string]string{"txt": update.TXT})
+}
+
+// isFull return True if the maximum number of accounts is reached
+func (h *AcmeDNSHandler) isFull(ctx context.Context) (bool, error) {
+	if h.MaxAccounts <= 0 {
+		return false, nil
+	}
+	accounts := &metav1.PartialObjectMetadataList{}
+	accounts.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := h.Reader.List(ctx, accounts, client.InNamespace(h.Namespace), client.MatchingLabels{ACME_DNS_ACCOUNT_LABEL: "true"}); err != nil {
+		return false, err
+	}
+	return len(accounts.Items) >= h.MaxAccounts, nil
+}
+
+// updateRRset creates or updates the TXT RRset of the subdomain with the values
+func (h *AcmeDNSHandler) updateRRset(ctx context.Context, subdomain string, values []string) error {
+	records := make([]string, 0, len(values))
+	for _, v := range values {
+		records = append(records, quoteCharacterString(v))
+	}
+	rrset := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ACME_DNS_RESOURCE + subdomain,
+			Namespace: h.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, h.Client, rrset, func() error {
+		rrset.Spec = dnsv1alpha2.RRsetSpec{
+			Type:    "TXT",
+			Name:    subdomain,
+			TTL:     ptr.To(ACME_DNS_TTL),
+			Records: records,
+			ZoneRef: dnsv1alpha2.ZoneRef{Name: strings.TrimSuffix(h.Zone, "."), Kind: "Zone"},
+		}
+		return nil
+	})
+	return err
+}
+
+// rotateAcmeDNSValues return the TXT values of a subdomain after an update: the new value replaces the oldest one
+func rotateAcmeDNSValues(values []string, value string) []string {
+	values = append(values, value)
+	if len(values) > ACME_DNS_TXT_VALUES {
+		values = values[len(values)-ACME_DNS_TXT_VALUES:]
+	}
+	return values
+}
+
+// splitAcmeDNSAllowFrom return the networks an account can be updated from, stored comma separated
+func splitAcmeDNSAllowFrom(in string) []string {
+	if in == "" {
+		return nil
+	}
+	return strings.Split(in, ",")
+}
+
+// isAcmeDNSAllowedFrom return True if the remote address is in one of the networks, or if no network is given
+func isAcmeDNSAllowedFrom(remoteAddr string, allowFrom []string) bool {
+	if len(allowFrom) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	for _, cidr := range allowFrom {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAcmeDNSPassword return a random password, in the acme-dns format
+func generateAcmeDNSPassword() (string, error) {
+	random := make([]byte, ACME_DNS_PASSWORD_SIZE)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	password := make([]byte, ACME_DNS_PASSWORD_SIZE)
+	for i, b := range random {
+		password[i] = ACME_DNS_PASSWORD_CHARS[int(b)%len(ACME_DNS_PASSWORD_CHARS)]
+	}
+	return string(password), nil
+}
+
+func writeAcmeDNSResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeAcmeDNSError(w http.ResponseWriter, status int, message string) {
+	writeAcmeDNSResponse(w, status, map[string]string{"error": message})
+}
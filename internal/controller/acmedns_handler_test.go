@@ -0,0 +1,126 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+)
+
+func TestIsAcmeDNSAllowedFrom(t *testing.T) {
+	var testCases = []struct {
+		description string
+		remoteAddr  string
+		allowFrom   []string
+		want        bool
+	}{
+		{"No restriction", "192.0.2.1:1234", nil, true},
+		{"Allowed IPv4", "192.0.2.1:1234", []string{"198.51.100.0/24", "192.0.2.0/24"}, true},
+		{"Denied IPv4", "203.0.113.1:1234", []string{"192.0.2.0/24"}, false},
+		{"Allowed IPv6", "[2001:db8::1]:1234", []string{"2001:db8::/32"}, true},
+		{"IPv4-mapped IPv6", "[::ffff:192.0.2.1]:1234", []string{"192.0.2.0/24"}, true},
+		{"Invalid remote address", "unknown", []string{"192.0.2.0/24"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isAcmeDNSAllowedFrom(tc.remoteAddr, tc.allowFrom); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRotateAcmeDNSValues(t *testing.T) {
+	var testCases = []struct {
+		description string
+		values      []string
+		want        []string
+	}{
+		{"First value", nil, []string{"new"}},
+		{"Second value", []string{"first"}, []string{"first", "new"}},
+		{"Oldest value replaced", []string{"first", "second"}, []string{"second", "new"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := rotateAcmeDNSValues(tc.values, "new"); !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGenerateAcmeDNSPassword(t *testing.T) {
+	password, err := generateAcmeDNSPassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != ACME_DNS_PASSWORD_SIZE || strings.Trim(password, ACME_DNS_PASSWORD_CHARS) != "" {
+		t.Errorf("invalid password %s", password)
+	}
+}
+
+func TestAcmeDNSHandlerRouting(t *testing.T) {
+	handler := &AcmeDNSHandler{Zone: "acme.example.org", Namespace: "acme"}
+	var testCases = []struct {
+		description string
+		method      string
+		path        string
+		headers     map[string]string
+		want        int
+	}{
+		{"Health", http.MethodGet, ACME_DNS_HEALTH_PATH, nil, http.StatusOK},
+		{"Unknown path", http.MethodGet, "/unknown", nil, http.StatusNotFound},
+		{"Wrong method", http.MethodGet, ACME_DNS_UPDATE_PATH, nil, http.StatusMethodNotAllowed},
+		{"Update without credentials", http.MethodPost, ACME_DNS_UPDATE_PATH, nil, http.StatusUnauthorized},
+		{"Update with a malformed user", http.MethodPost, ACME_DNS_UPDATE_PATH, map[string]string{ACME_DNS_USER_HEADER: "../admin", ACME_DNS_KEY_HEADER: "key"}, http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Errorf("got status %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestAcmeDNSHandlerRegisterBodyLimit(t *testing.T) {
+	handler := &AcmeDNSHandler{Zone: "acme.example.org", Namespace: "acme"}
+	body := `{"allowfrom": ["` + strings.Repeat("192.0.2.0/24", ACME_DNS_MAX_BODY_SIZE) + `"]}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ACME_DNS_REGISTER_PATH, strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestAcmeDNSHandlerRegisterRateLimit(t *testing.T) {
+	handler := &AcmeDNSHandler{Zone: "acme.example.org", Namespace: "acme", RegisterLimiter: rate.NewLimiter(0, 0)}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ACME_DNS_REGISTER_PATH, nil))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("got status %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
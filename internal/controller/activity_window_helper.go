@@ -0,0 +1,63 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	RrsetReasonInactive  = "Inactive"
+	RrsetMessageInactive = "RRset outside of its activity window, not published in PowerDNS"
+)
+
+// isActiveRRset return True if the RRset is published at the time: within its activity window, if any
+func isActiveRRset(rrset dnsv1alpha2.GenericRRset, now time.Time) bool {
+	spec := rrset.GetSpec()
+	return (spec.ActiveFrom == nil || !now.Before(spec.ActiveFrom.Time)) &&
+		(spec.ActiveUntil == nil || now.Before(spec.ActiveUntil.Time))
+}
+
+// getNextActivityChange return the next time the RRset is published or removed after the time, zero if none
+func getNextActivityChange(rrset dnsv1alpha2.GenericRRset, now time.Time) time.Time {
+	// activeFrom is before activeUntil
+	for _, change := range []*metav1.Time{rrset.GetSpec().ActiveFrom, rrset.GetSpec().ActiveUntil} {
+		if change != nil && change.After(now) {
+			return change.Time
+		}
+	}
+	return time.Time{}
+}
+
+// getInactiveRRset return a copy of the RRset removing its records from PowerDNS, as an absent RRset
+func getInactiveRRset(rrset dnsv1alpha2.GenericRRset) dnsv1alpha2.GenericRRset {
+	inactive := rrset.Copy()
+	inactive.GetSpec().Ensure = ptr.To(RRSET_ABSENT_ENSURE)
+	inactive.GetSpec().Records = nil
+	inactive.GetSpec().NAPTR = nil
+	inactive.GetSpec().TargetRef = nil
+	return inactive
+}
+
+// isRemovedRRset return True if the records of the RRset are known to be absent from PowerDNS: it was never applied,
+// or its removal outside of its activity window is the last change applied
+func isRemovedRRset(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, defaultTTL uint32, clusterID string) bool {
+	if !hasBeenApplied(rrset) {
+		return true
+	}
+	return ptr.Deref(rrset.GetStatus().AppliedHash, "") == getAppliedHash(getInactiveRRset(rrset), zone, defaultTTL, clusterID)
+}
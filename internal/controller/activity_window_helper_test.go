@@ -0,0 +1,67 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestActivityWindow(t *testing.T) {
+	from := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	var testCases = []struct {
+		description string
+		activeFrom  *metav1.Time
+		activeUntil *metav1.Time
+		now         time.Time
+		wantActive  bool
+		wantNext    time.Time
+	}{
+		{"No window", nil, nil, from, true, time.Time{}},
+		{"Before activeFrom", &metav1.Time{Time: from}, nil, from.Add(-time.Minute), false, from},
+		{"At activeFrom", &metav1.Time{Time: from}, nil, from, true, time.Time{}},
+		{"Before activeUntil", nil, &metav1.Time{Time: until}, from, true, until},
+		{"At activeUntil", nil, &metav1.Time{Time: until}, until, false, time.Time{}},
+		{"Before the window", &metav1.Time{Time: from}, &metav1.Time{Time: until}, from.Add(-time.Hour), false, from},
+		{"Within the window", &metav1.Time{Time: from}, &metav1.Time{Time: until}, from.Add(time.Hour), true, until},
+		{"After the window", &metav1.Time{Time: from}, &metav1.Time{Time: until}, until.Add(time.Hour), false, time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{ActiveFrom: tc.activeFrom, ActiveUntil: tc.activeUntil}}
+			if got := isActiveRRset(rrset, tc.now); got != tc.wantActive {
+				t.Errorf("got active %v, want %v", got, tc.wantActive)
+			}
+			if got := getNextActivityChange(rrset, tc.now); !got.Equal(tc.wantNext) {
+				t.Errorf("got next change %v, want %v", got, tc.wantNext)
+			}
+		})
+	}
+}
+
+func TestGetInactiveRRset(t *testing.T) {
+	rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"192.0.2.1"}}}
+	inactive := getInactiveRRset(rrset)
+	if !isAbsentRRset(inactive) || inactive.GetSpec().Records != nil {
+		t.Errorf("got %v, want an absent RRset without records", inactive.GetSpec())
+	}
+	if diff := cmp.Diff([]string{"192.0.2.1"}, rrset.Spec.Records); diff != "" {
+		t.Errorf("unexpected change of the RRset (-want +got):\n%s", diff)
+	}
+}
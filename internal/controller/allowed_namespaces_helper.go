@@ -0,0 +1,114 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dnsutil"
+)
+
+const (
+	RrsetReasonNamespaceNotAllowed  = "NamespaceNotAllowed"
+	RrsetMessageNamespaceNotAllowed = "Namespace of the RRset not allowed by the zone:"
+)
+
+// isNamespaceAllowed return True if the namespace is allowed to reference the zone.
+// The resources referencing a Zone are in its namespace, only the namespaces allowed by a ClusterZone are checked.
+func isNamespaceAllowed(ctx context.Context, name string, zone dnsv1alpha2.GenericZone, cl client.Reader) (bool, error) {
+	if zone.GetNamespace() != "" || zone.GetSpec().AllowedNamespaces == nil {
+		return true, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+		return false, err
+	}
+	return dnsutil.IsNamespaceAllowed(zone.GetSpec().AllowedNamespaces, namespace)
+}
+
+// rrsetNamespaceReconcile return True if the namespace of the RRset is allowed by the zone. Otherwise, the RRset is
+// failed, and its records already applied are removed from PowerDNS: they are applied again once it is allowed.
+func rrsetNamespaceReconcile(ctx context.Context, rrset *dnsv1alpha2.RRset, zone dnsv1alpha2.GenericZone, opts RRsetOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (bool, error) {
+	allowed, err := isNamespaceAllowed(ctx, rrset.Namespace, zone, cl)
+	if err != nil {
+		log.Error(err, "Failed to get namespace")
+		return false, err
+	}
+	if allowed {
+		return true, nil
+	}
+
+	original := rrset.DeepCopy()
+	// The records are kept in a frozen zone, they are removed on the first reconciliation once unfrozen
+	if hasBeenApplied(rrset) {
+		hold, err := deleteRRsetRecords(ctx, rrset, zone, nil, opts, cl, PDNSClient, log)
+		if err != nil {
+			return false, err
+		}
+		if hold == nil {
+			rrset.Status.AppliedHash = nil
+			rrset.Status.AppliedZoneSerial = nil
+		}
+	}
+	rrset.Status.SyncStatus = ptr.To(FAILED_STATUS)
+	rrset.Status.ObservedGeneration = &rrset.Generation
+	condition := metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             RrsetReasonNamespaceNotAllowed,
+		Message:            RrsetMessageNamespaceNotAllowed + " " + zone.GetName(),
+	}
+	meta.SetStatusCondition(&rrset.Status.Conditions, condition)
+	setStalledCondition(&rrset.Status.Conditions, condition)
+	if err := commitRrsetStatus(ctx, rrset, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return false, err
+	}
+	return false, nil
+}
+
+// isNamespaceAllowedAgain return True if the RRset was failed because its namespace was not allowed by the zone
+func isNamespaceAllowedAgain(rrset *dnsv1alpha2.RRset) bool {
+	condition := meta.FindStatusCondition(rrset.Status.Conditions, "Available")
+	return condition != nil && condition.Reason == RrsetReasonNamespaceNotAllowed
+}
+
+// enqueueRRsetsOfNamespace return an event handler enqueuing the RRsets of a namespace
+func enqueueRRsetsOfNamespace(cl client.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var rrsets dnsv1alpha2.RRsetList
+		if err := cl.List(ctx, &rrsets, client.InNamespace(obj.GetName())); err != nil {
+			return nil
+		}
+		return getRRsetRequests(rrsets.Items)
+	})
+}
+
+func getRRsetRequests(rrsets []dnsv1alpha2.RRset) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(rrsets))
+	for _, rrset := range rrsets {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rrset)})
+	}
+	return requests
+}
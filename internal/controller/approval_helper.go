@@ -0,0 +1,66 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"strconv"
+
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dnsutil"
+)
+
+const (
+	// APPROVED_ANNOTATION approves the changes of a generation of a RRset referencing a zone requiring approval,
+	// the approvers are checked by the validating webhook
+	APPROVED_ANNOTATION = dnsutil.APPROVED_ANNOTATION
+
+	RrsetReasonPendingApproval          = "PendingApproval"
+	RrsetMessagePendingApproval         = "Changes pending approval, the annotation " + APPROVED_ANNOTATION + " set to the generation of the RRset applies them:"
+	RrsetMessagePendingDeletionApproval = "Deletion pending approval, the annotation " + APPROVED_ANNOTATION + " set to the generation of the RRset removes its records:"
+	RrsetMessageApprovalUnverified      = "Changes pending approval, the approvals are ignored without the admission webhooks checking the approvers"
+)
+
+// isApproved return True if the generation of the RRset is approved. The approvals are only trusted when
+// the admission webhooks check the approvers are granted the approve verb.
+func isApproved(rrset dnsv1alpha2.GenericRRset, verified bool) bool {
+	return verified && rrset.GetAnnotations()[APPROVED_ANNOTATION] == strconv.FormatInt(rrset.GetGeneration(), 10)
+}
+
+// isAwaitingApproval return True if the desired state of the RRset is held until approved: the zone requires approval,
+// the generation of the RRset is not approved and the desired state was not already applied
+func isAwaitingApproval(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, appliedHash string, verified bool) bool {
+	return ptr.Deref(zone.GetSpec().RequireApproval, false) &&
+		!isApproved(rrset, verified) &&
+		ptr.Deref(rrset.GetStatus().AppliedHash, "") != appliedHash
+}
+
+// isAwaitingDeletionApproval return True if the removal of the records of a deleted RRset is held until approved:
+// the zone requires approval, the RRset was applied and the generation of its deletion is not approved
+func isAwaitingDeletionApproval(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, verified bool) bool {
+	return ptr.Deref(zone.GetSpec().RequireApproval, false) &&
+		!isApproved(rrset, verified) &&
+		rrset.GetStatus().AppliedHash != nil
+}
+
+// getApprovalHold return the hold of the changes, or of the deletion, of a RRset pending approval
+func getApprovalHold(rrset dnsv1alpha2.GenericRRset, verified bool, deletion bool) *rrsetHold {
+	generation := strconv.FormatInt(rrset.GetGeneration(), 10)
+	switch {
+	case !verified:
+		return &rrsetHold{RrsetReasonPendingApproval, RrsetMessageApprovalUnverified}
+	case deletion:
+		return &rrsetHold{RrsetReasonPendingApproval, RrsetMessagePendingDeletionApproval + " " + generation}
+	}
+	return &rrsetHold{RrsetReasonPendingApproval, RrsetMessagePendingApproval + " " + generation}
+}
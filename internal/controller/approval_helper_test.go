@@ -0,0 +1,83 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsAwaitingApproval(t *testing.T) {
+	protected := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{RequireApproval: ptr.To(true)}}
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		annotations map[string]string
+		appliedHash *string
+		verified    bool
+		want        bool
+	}{
+		{"Zone without approval", &dnsv1alpha2.Zone{}, nil, nil, true, false},
+		{"New RRset", protected, nil, nil, true, true},
+		{"Changed RRset", protected, nil, ptr.To("previous"), true, true},
+		{"Approved generation", protected, map[string]string{APPROVED_ANNOTATION: "3"}, ptr.To("previous"), true, false},
+		{"Approval not verified", protected, map[string]string{APPROVED_ANNOTATION: "3"}, ptr.To("previous"), false, true},
+		{"Previous generation approved", protected, map[string]string{APPROVED_ANNOTATION: "2"}, ptr.To("previous"), true, true},
+		{"Already applied", protected, nil, ptr.To("desired"), true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3, Annotations: tc.annotations},
+				Status:     dnsv1alpha2.RRsetStatus{AppliedHash: tc.appliedHash},
+			}
+			if got := isAwaitingApproval(rrset, tc.zone, "desired", tc.verified); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsAwaitingDeletionApproval(t *testing.T) {
+	protected := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{RequireApproval: ptr.To(true)}}
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		annotations map[string]string
+		appliedHash *string
+		want        bool
+	}{
+		{"Zone without approval", &dnsv1alpha2.Zone{}, nil, ptr.To("applied"), false},
+		{"Applied RRset", protected, nil, ptr.To("applied"), true},
+		{"Never applied", protected, nil, nil, false},
+		{"Deletion approved", protected, map[string]string{APPROVED_ANNOTATION: "4"}, ptr.To("applied"), false},
+		{"Last change approved", protected, map[string]string{APPROVED_ANNOTATION: "3"}, ptr.To("applied"), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The generation is incremented on deletion
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Generation: 4, Annotations: tc.annotations},
+				Status:     dnsv1alpha2.RRsetStatus{AppliedHash: tc.appliedHash},
+			}
+			if got := isAwaitingDeletionApproval(rrset, tc.zone, true); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var buildInfoMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "powerdns_operator_build_info",
+		Help: "Build and configuration of the operator (always 1)",
+	},
+	[]string{"version", "git_commit", "go_version", "pdns_endpoint_hash", "features"},
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(buildInfoMetric)
+}
+
+// SetBuildInfoMetric publish the version of the operator, the hash of the PowerDNS endpoint (URL and vhost,
+// not exposed as is) and the features enabled, sorted
+func SetBuildInfoMetric(version string, gitCommit string, apiURL string, apiVhost string, features map[string]bool) {
+	enabled := []string{}
+	for feature, on := range features {
+		if on {
+			enabled = append(enabled, feature)
+		}
+	}
+	slices.Sort(enabled)
+	buildInfoMetric.Reset()
+	buildInfoMetric.With(prometheus.Labels{
+		"version":            version,
+		"git_commit":         gitCommit,
+		"go_version":         runtime.Version(),
+		"pdns_endpoint_hash": getEndpointHash(apiURL, apiVhost),
+		"features":           strings.Join(enabled, ","),
+	}).Set(1)
+}
+
+// getEndpointHash return a short hash identifying the PowerDNS endpoint, the operators of a fleet using
+// the same PowerDNS server share the same hash
+func getEndpointHash(apiURL string, apiVhost string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.TrimSuffix(apiURL, "/")+"|"+apiVhost)))[:12]
+}
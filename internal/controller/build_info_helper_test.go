@@ -0,0 +1,47 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSetBuildInfoMetric(t *testing.T) {
+	SetBuildInfoMetric("v1.2.0", "0123abc", "https://pdns:8081/", "localhost", map[string]bool{"webhooks": true, "dry-run": true, "istio-source": false})
+	value := testutil.ToFloat64(buildInfoMetric.With(prometheus.Labels{
+		"version":            "v1.2.0",
+		"git_commit":         "0123abc",
+		"go_version":         runtime.Version(),
+		"pdns_endpoint_hash": getEndpointHash("https://pdns:8081", "localhost"),
+		"features":           "dry-run,webhooks",
+	}))
+	if value != 1 {
+		t.Errorf("got %v, want 1", value)
+	}
+	if count := testutil.CollectAndCount(buildInfoMetric); count != 1 {
+		t.Errorf("got %d series, want 1", count)
+	}
+}
+
+func TestGetEndpointHash(t *testing.T) {
+	hash := getEndpointHash("https://pdns:8081", "localhost")
+	if len(hash) != 12 {
+		t.Errorf("got %q, want 12 characters", hash)
+	}
+	if other := getEndpointHash("https://pdns:8081", "other"); other == hash {
+		t.Errorf("got the same hash %q for another vhost", other)
+	}
+}
@@ -15,16 +15,20 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 )
@@ -34,16 +38,23 @@ type ClusterRRsetReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	PDNSClient PdnsClienter
+	// RRsetOptions are the settings of the synchronization of the RRsets
+	RRsetOptions
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+	// Resync enqueues the resources failed during a PowerDNS outage when the API is available again, if not nil
+	Resync source.Source
 }
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(clusterRrsetsStatusesMetric)
+	metrics.Registry.MustRegister(clusterRrsetsStatusesMetric, clusterRrsetsLastSyncMetric)
 }
 
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterrrsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterrrsets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterrrsets/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 
 func (r *ClusterRRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -84,7 +95,7 @@ func (r *ClusterRRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	original := rrset.DeepCopy()
 	if !isDeleted && isModified {
 		meta.RemoveStatusCondition(&rrset.Status.Conditions, "Available")
-		if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+		if err := patchStatusIfChanged(ctx, rrset, original, r.Client); err != nil {
 			log.Error(err, "unable to patch ClusterRRSet status")
 			return ctrl.Result{}, err
 		}
@@ -134,11 +145,10 @@ func (r *ClusterRRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request
 					Reason:             RrsetReasonZoneNotAvailable,
 					Message:            RrsetMessageNonExistentZone + err.Error(),
 				})
-				if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+				if err := commitRrsetStatus(ctx, rrset, original, r.Client); err != nil {
 					log.Error(err, "unable to patch RRSet status")
 					return ctrl.Result{}, err
 				}
-				updateRrsetsMetrics(getRRsetName(rrset), rrset)
 			}
 
 			// Race condition when creating Zone+RRset at the same time
@@ -151,58 +161,54 @@ func (r *ClusterRRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 	// If a Zone/ClusterZone exists but is in Failed Status
-	zoneIsInFailedStatus := (zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS)
-	if zoneIsInFailedStatus {
-		original = rrset.DeepCopy()
-		rrset.Status.SyncStatus = ptr.To(FAILED_STATUS)
-		rrset.Status.ObservedGeneration = &rrset.Generation
-		meta.SetStatusCondition(&rrset.Status.Conditions, metav1.Condition{
-			Type:               "Available",
-			Status:             metav1.ConditionFalse,
-			LastTransitionTime: metav1.NewTime(time.Now().UTC()),
-			Reason:             RrsetReasonZoneNotAvailable,
-			Message:            RrsetMessageUnavailableZone + zone.GetName(),
-		})
-		if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
-			log.Error(err, "unable to patch RRSet status")
-			return ctrl.Result{}, err
-		}
-
-		// Update metrics
-		updateRrsetsMetrics(getRRsetName(rrset), rrset)
-
-		if isDeleted {
-			if controllerutil.ContainsFinalizer(rrset, METRICS_FINALIZER_NAME) {
-				controllerutil.RemoveFinalizer(rrset, METRICS_FINALIZER_NAME)
-				// Remove resource metrics
-				removeRrsetMetrics(rrset)
-				if err := r.Update(ctx, rrset); err != nil {
-					log.Error(err, "Failed to remove finalizer")
-					return ctrl.Result{}, err
-				}
-			}
-		}
-
-		return ctrl.Result{}, nil
+	if zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS {
+		return ctrl.Result{}, rrsetFailedZoneReconcile(ctx, rrset, zone, isDeleted, r.Client, log)
 	}
 
-	return rrsetReconcile(ctx, rrset, zone, isModified, isDeleted, lastUpdateTime, r.Scheme, r.Client, r.PDNSClient, log)
+	opts := r.RRsetOptions
+	opts.DryRun = isDryRun(rrset, r.DryRun)
+	return rrsetReconcile(ctx, rrset, zone, isModified, isDeleted, opts, lastUpdateTime, r.Scheme, r.Client, r.PDNSClient, log)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterRRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one ClusterRRset/RRset exists for DNS entry
+	// We use indexer to find the ClusterRRsets claiming a DNS entry, whatever their status
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.Entry.Name", func(rawObj client.Object) []string {
-		// grab the ClusterRRset object, extract its name...
-		var RRsetName string
-		if rawObj.(*dnsv1alpha2.ClusterRRset).Status.SyncStatus == nil || *rawObj.(*dnsv1alpha2.ClusterRRset).Status.SyncStatus == SUCCEEDED_STATUS {
-			RRsetName = getRRsetName(rawObj.(*dnsv1alpha2.ClusterRRset)) + "/" + rawObj.(*dnsv1alpha2.ClusterRRset).Spec.Type
-		}
-		return []string{RRsetName}
+		return []string{getRRsetEntryIndexKey(rawObj.(*dnsv1alpha2.ClusterRRset))}
 	}); err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	// We use indexer to find the DNAME redirections above a name
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.DNAME", func(rawObj client.Object) []string {
+		return getDNAMEIndexKeys(rawObj.(*dnsv1alpha2.ClusterRRset))
+	}); err != nil {
+		return err
+	}
+	// We use indexer to list the ClusterRRsets of a zone without listing all of them
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.ZoneRef", func(rawObj client.Object) []string {
+		return []string{getZoneRefIndexKey(rawObj.(*dnsv1alpha2.ClusterRRset).Spec.ZoneRef)}
+	}); err != nil {
+		return err
+	}
+	// We use indexer to find the ClusterRRsets resolved from a Service when its addresses change
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterRRset{}, "ClusterRRset.TargetRef", func(rawObj client.Object) []string {
+		return getTargetRefIndexKeys(rawObj.(*dnsv1alpha2.ClusterRRset))
+	}); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.ClusterRRset{}).
-		Complete(r)
+		Watches(&dnsv1alpha2.ClusterRRset{}, enqueueDeletionsFirst()).
+		Watches(&corev1.Service{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.ClusterRRsetList{}, "ClusterRRset.TargetRef")).
+		// The approvals and the windows required, and the freeze, of a zone are checked again on its changes
+		Watches(&dnsv1alpha2.ClusterZone{}, enqueueRRsetsOfZone(r.Client, true), builder.WithPredicates(zoneChangedPredicate()))
+	if isResolvingConflicts(r.ConflictPolicy) {
+		// The RRsets claiming the same name and type are elected again on the changes of any of them
+		b = b.Watches(&dnsv1alpha2.RRset{}, enqueueContendingRRsets(r.Client, true), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&dnsv1alpha2.ClusterRRset{}, enqueueContendingRRsets(r.Client, true), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+	if r.Resync != nil {
+		b = b.WatchesRawSource(r.Resync)
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
 }
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -112,7 +113,7 @@ var _ = Describe("ClusterRRset Controller", func() {
 				},
 				Type:    resourceType,
 				Name:    resourceDNSName,
-				TTL:     resourceTTL,
+				TTL:     ptr.To(resourceTTL),
 				Records: resourceRecords,
 				Comment: &comment,
 			}
@@ -215,7 +216,7 @@ var _ = Describe("ClusterRRset Controller", func() {
 				resource.Spec = dnsv1alpha2.RRsetSpec{
 					Type:    recreationResourceType,
 					Name:    recreationResourceDNSName,
-					TTL:     recreationResourceTTL,
+					TTL:     ptr.To(recreationResourceTTL),
 					Records: recreationResourceRecords,
 					Comment: &recreationResourceComment,
 					ZoneRef: dnsv1alpha2.ZoneRef{
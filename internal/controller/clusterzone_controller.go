@@ -13,14 +13,18 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 )
@@ -30,16 +34,24 @@ type ClusterZoneReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	PDNSClient PdnsClienter
+	// ZoneOptions are the settings of the synchronization of the zones
+	ZoneOptions
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+	// Resync enqueues the resources failed during a PowerDNS outage when the API is available again, if not nil
+	Resync source.Source
 }
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(clusterZonesStatusesMetric)
+	metrics.Registry.MustRegister(clusterZonesStatusesMetric, clusterZonesDNSSECStatusMetric, clusterZonesDNSSECKeyRolloverMetric, clusterZonesPeerDivergenceMetric, clusterZonesLastSyncMetric)
 }
 
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 func (r *ClusterZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -75,13 +87,15 @@ func (r *ClusterZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if !isDeleted && isModified {
 		isModified = true
 		meta.RemoveStatusCondition(&zone.Status.Conditions, "Available")
-		if err := r.Status().Patch(ctx, zone, client.MergeFrom(original)); err != nil {
+		if err := patchStatusIfChanged(ctx, zone, original, r.Client); err != nil {
 			log.Error(err, "unable to patch ClusterZone status")
 			return ctrl.Result{}, err
 		}
 	}
 
-	return zoneReconcile(ctx, zone, isModified, isDeleted, r.Client, r.PDNSClient, log)
+	opts := r.ZoneOptions
+	opts.DryRun = isDryRun(zone, r.DryRun)
+	return zoneReconcile(ctx, zone, isModified, isDeleted, opts, r.Client, r.PDNSClient, log)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -97,9 +111,25 @@ func (r *ClusterZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.MasterServices", func(rawObj client.Object) []string {
+		return getMasterServicesIndexKeys(rawObj.(*dnsv1alpha2.ClusterZone))
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.ClusterZone{}, "ClusterZone.ZoneFileFrom", func(rawObj client.Object) []string {
+		return getZoneFileSourceIndexKeys(rawObj.(*dnsv1alpha2.ClusterZone))
+	}); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.ClusterZone{}).
-		Owns(&dnsv1alpha2.ClusterRRset{}).
-		Owns(&dnsv1alpha2.RRset{}).
-		Complete(r)
+		Watches(&dnsv1alpha2.ClusterZone{}, enqueueDeletionsFirst()).
+		Owns(&dnsv1alpha2.ClusterRRset{}, builder.WithPredicates(ownedRRsetChangedPredicate())).
+		Owns(&dnsv1alpha2.RRset{}, builder.WithPredicates(ownedRRsetChangedPredicate())).
+		Watches(&corev1.Service{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.ClusterZoneList{}, "ClusterZone.MasterServices"), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.ClusterZoneList{}, "ClusterZone.ZoneFileFrom"), builder.OnlyMetadata)
+	if r.Resync != nil {
+		b = b.WatchesRawSource(r.Resync)
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
 }
@@ -12,7 +12,11 @@
 package controller
 
 import (
+	"cmp"
 	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,69 +33,219 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+// syncResult is the outcome of the synchronization of a resource, reported in its Available condition.
+// A nil status is a synchronization without any failure so far.
+type syncResult struct {
+	status          *string
+	conditionStatus metav1.ConditionStatus
+	reason          string
+	message         string
+	// synced is True once PowerDNS was actually read or written, not when the desired state is known to be applied
+	synced bool
+}
+
+// fail records the failure of a synchronization step, the last failure is reported
+func (r *syncResult) fail(reason string, message string) {
+	r.status = ptr.To(FAILED_STATUS)
+	r.conditionStatus = metav1.ConditionFalse
+	r.reason = reason
+	r.message = message
+}
+
+// failed return True if a synchronization step failed
+func (r *syncResult) failed() bool {
+	return ptr.Deref(r.status, "") == FAILED_STATUS
+}
+
+func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, opts ZoneOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
 	isInFailedStatus := (gz.GetStatus().SyncStatus != nil && *gz.GetStatus().SyncStatus == FAILED_STATUS)
 
 	// examine DeletionTimestamp to determine if object is under deletion
-	if !isDeleted {
-		// The object is not being deleted, so if it does not have our finalizer,
-		// then lets add the finalizer and update the object. This is equivalent
-		// to registering our finalizer.
-		if !controllerutil.ContainsFinalizer(gz, RESOURCES_FINALIZER_NAME) {
-			controllerutil.AddFinalizer(gz, RESOURCES_FINALIZER_NAME)
-			if err := cl.Update(ctx, gz); err != nil {
+	if isDeleted {
+		return zoneDeletionReconcile(ctx, gz, opts, cl, PDNSClient, log)
+	}
+	// The object is not being deleted, so if it does not have our finalizer,
+	// then lets add the finalizer and update the object. This is equivalent
+	// to registering our finalizer.
+	if !controllerutil.ContainsFinalizer(gz, RESOURCES_FINALIZER_NAME) {
+		controllerutil.AddFinalizer(gz, RESOURCES_FINALIZER_NAME)
+		if err := cl.Update(ctx, gz); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Synchronization requested through annotation: the Zone is reconciled as if modified
+	// The value handled is recorded in the status once synchronized
+	if isReconcileRequested(gz, gz.GetStatus().LastHandledReconcileAt) {
+		isModified = true
+	}
+
+	// We cannot exit previously (at the early moments of reconcile), because we have to allow deletion process
+	// Transient failures are retried, permanent ones are parked until the Zone is modified
+	if isInFailedStatus && !isModified && !isTransientFailure(gz.GetStatus().Conditions) {
+		// Update resource metrics
+		updateZonesMetrics(gz)
+		return ctrl.Result{}, nil
+	}
+
+	if duplicated, err := zoneDuplicationReconcile(ctx, gz, cl, log); err != nil || duplicated {
+		return ctrl.Result{}, err
+	}
+	if resolved, err := zoneMasterServicesReconcile(ctx, gz, opts.getObjectReader(cl), cl, log); err != nil || !resolved {
+		// The rate limiter of the controller backs off exponentially between the retries
+		return ctrl.Result{Requeue: err == nil}, err
+	}
+
+	// Get zone
+	// A failed read is reported in the status: the zones failed on an unavailable PowerDNS API are resynchronized
+	// as soon as it is available again
+	zoneRes, err := getZoneExternalResources(ctx, gz.GetObjectMeta().Name, PDNSClient, log)
+	if err != nil {
+		return ctrl.Result{}, zoneReadFailureReconcile(ctx, gz, err, cl, log)
+	}
+
+	becomingSecondary := isBecomingSecondaryZone(gz, zoneRes)
+	isNewZone := zoneRes.Name == nil
+	result, err := zoneExternalResourcesReconcile(ctx, zoneRes, gz, opts.DryRun, PDNSClient, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := zoneRetransferReconcile(ctx, gz, becomingSecondary, opts.DryRun, &result, cl, PDNSClient, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := zonePurgeReconcile(ctx, gz, opts, &result, cl, PDNSClient, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	cloned := zoneCloneReconcile(ctx, gz, isNewZone, opts, &result, cl, PDNSClient, log)
+
+	// The records of the zone file are synchronized, except the ones managed by RRsets
+	zoneFileSyncReconcile(ctx, gz, zoneRes, &result, opts, cl, PDNSClient, log)
+
+	// The description travels with the zone in its metadata
+	if result.status == nil && !isFrozenZone(gz) && !opts.DryRun {
+		if err := zoneDescriptionReconcile(ctx, gz, PDNSClient, log); err != nil {
+			result.fail(ZoneReasonDescriptionFailed, err.Error())
+		}
+	}
+
+	if result.status == nil {
+		result.status = ptr.To(SUCCEEDED_STATUS)
+	}
+	return zoneStatusReconcile(ctx, gz, cloned, &result, opts, cl, PDNSClient, log)
+}
+
+// zoneDeletionReconcile delete the zone from PowerDNS, with its delegation in the parent zone, and remove the finalizers
+func zoneDeletionReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, opts ZoneOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+	finalizerRemoved := false
+	if controllerutil.ContainsFinalizer(gz, RESOURCES_FINALIZER_NAME) {
+		// A zone still in use is not deleted, unless forced
+		if gz.GetAnnotations()[FORCE_DELETE_ANNOTATION] != FORCE_DELETE_ANNOTATION_VALUE {
+			blocked, err := zoneDeletionBlockersReconcile(ctx, gz, opts.ClusterID, cl, PDNSClient, log)
+			if err != nil {
 				return ctrl.Result{}, err
 			}
+			if blocked {
+				return ctrl.Result{RequeueAfter: ZONE_DELETION_RETRY_INTERVAL}, nil
+			}
 		}
-	} else {
-		// The object is being deleted
-		finalizerRemoved := false
-		if controllerutil.ContainsFinalizer(gz, RESOURCES_FINALIZER_NAME) {
-			// our finalizer is present, so lets handle any external dependency
+
+		// The RRsets, and their records, are deleted before the zone on request
+		if ptr.Deref(gz.GetSpec().CascadeDeletion, "") == CASCADE_DELETION_FOREGROUND {
+			pending, err := deleteOwnedRRsets(ctx, gz, cl, log)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if pending != 0 {
+				log.Info("Waiting for the deletion of the owned RRsets", "pending", pending)
+				return ctrl.Result{RequeueAfter: CASCADE_DELETION_RETRY_INTERVAL}, nil
+			}
+		}
+
+		// our finalizer is present, so lets handle any external dependency
+		// The zone, and its records, are kept in PowerDNS while frozen or in dry-run mode
+		if isFrozenZone(gz) {
+			log.Info("Frozen zone, external resources are not deleted")
+		} else if opts.DryRun {
+			log.Info("Dry-run mode, external resources are not deleted")
+		} else {
+			parent, err := getParentZone(ctx, gz, cl)
+			if err != nil {
+				log.Error(err, "unable to find parent zone")
+				return ctrl.Result{}, err
+			}
+			if err := dsParentZoneReconcile(ctx, gz, parent, nil, opts.ClusterID, PDNSClient, log); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := delegationParentZoneReconcile(ctx, gz, parent, false, opts.ClusterID, PDNSClient, log); err != nil {
+				return ctrl.Result{}, err
+			}
 			if err := deleteZoneExternalResources(ctx, gz, PDNSClient, log); err != nil {
 				// if fail to delete the external resource, return with error
 				// so that it can be retried
 				return ctrl.Result{}, err
 			}
-			// remove our finalizer from the list and update it.
-			controllerutil.RemoveFinalizer(gz, RESOURCES_FINALIZER_NAME)
-			finalizerRemoved = true
-		}
-		if controllerutil.ContainsFinalizer(gz, METRICS_FINALIZER_NAME) {
-			// Remove resource metrics and finalizer
-			removeZonesMetrics(gz)
-			controllerutil.RemoveFinalizer(gz, METRICS_FINALIZER_NAME)
-			finalizerRemoved = true
-		}
-		if finalizerRemoved {
-			if err := cl.Update(ctx, gz); err != nil {
-				return ctrl.Result{}, err
-			}
 		}
-
-		// Stop reconciliation as the item is being deleted
-		return ctrl.Result{}, nil
+		// remove our finalizer from the list and update it.
+		controllerutil.RemoveFinalizer(gz, RESOURCES_FINALIZER_NAME)
+		finalizerRemoved = true
+	}
+	if controllerutil.ContainsFinalizer(gz, METRICS_FINALIZER_NAME) {
+		// Remove resource metrics and finalizer
+		removeZonesMetrics(gz)
+		controllerutil.RemoveFinalizer(gz, METRICS_FINALIZER_NAME)
+		finalizerRemoved = true
+	}
+	if finalizerRemoved {
+		if err := cl.Update(ctx, gz); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	// We cannot exit previously (at the early moments of reconcile), because we have to allow deletion process
-	if isInFailedStatus && !isModified {
-		// Update resource metrics
-		updateZonesMetrics(gz)
-		return ctrl.Result{}, nil
+	// Stop reconciliation as the item is being deleted
+	return ctrl.Result{}, nil
+}
+
+// zoneDeletionBlockersReconcile return True, and report them in the status, if resources still use the zone being deleted
+func zoneDeletionBlockersReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, clusterID string, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (bool, error) {
+	blockers, err := getDeletionBlockers(ctx, gz, clusterID, cl, PDNSClient)
+	if err != nil {
+		log.Error(err, "unable to check zone deletion")
+		return false, err
+	}
+	if len(blockers) == 0 {
+		return false, nil
 	}
+	log.Info("Zone deletion blocked", "blockers", blockers)
+	original := gz.Copy()
+	conditions := gz.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             ZoneReasonDeletionBlocked,
+		Message:            getDeletionBlockedMessage(blockers),
+	})
+	status := gz.GetStatus()
+	status.Conditions = conditions
+	gz.SetStatus(status)
+	if err := patchStatusIfChanged(ctx, gz, original, cl); err != nil {
+		log.Error(err, "unable to patch Zone status")
+		return true, err
+	}
+	return true, nil
+}
 
-	// If a Zone already exists with the same DNS name:
-	// * Stop reconciliation
-	// * Append a Failed Status on Zone
+// zoneDuplicationReconcile return True, and fail the zone, if another Zone or ClusterZone exists with the same DNS name
+func zoneDuplicationReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, cl client.Client, log logr.Logger) (bool, error) {
 	var existingZones dnsv1alpha2.ZoneList
 	if err := cl.List(ctx, &existingZones, client.MatchingFields{"Zone.Entry.Name": gz.GetName()}); err != nil {
 		log.Error(err, "unable to find Zone related to the DNS Name")
-		return ctrl.Result{}, err
+		return false, err
 	}
 	var existingClusterZones dnsv1alpha2.ClusterZoneList
 	if err := cl.List(ctx, &existingClusterZones, client.MatchingFields{"ClusterZone.Entry.Name": gz.GetName()}); err != nil {
 		log.Error(err, "unable to find ClusterZone related to the DNS Name")
-		return ctrl.Result{}, err
+		return false, err
 	}
 
 	// Multiple use-cases:
@@ -99,60 +253,321 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 	// In that case: len(existingZones.Items) > 1
 	// 1 Zone (example.com in NS example1) + 1 ClusterZone (example.com)
 	// In that case: len(existingZones.Items) >= 1 AND len(existingClusterZones.Items) >= 1
-	if len(existingZones.Items) > 1 || (len(existingZones.Items) >= 1 && len(existingClusterZones.Items) >= 1) {
-		original := gz.Copy()
-		conditions := gz.GetStatus().Conditions
-		meta.SetStatusCondition(&conditions, metav1.Condition{
-			Type:               "Available",
-			Status:             metav1.ConditionFalse,
-			LastTransitionTime: metav1.Time{Time: time.Now().UTC()},
-			Reason:             ZoneReasonDuplicated,
-			Message:            ZoneMessageDuplicated,
-		})
-		gz.SetStatus(dnsv1alpha2.ZoneStatus{
-			SyncStatus:         ptr.To(FAILED_STATUS),
-			ObservedGeneration: &gz.GetObjectMeta().Generation,
-			Conditions:         conditions,
-		})
-		if err := cl.Status().Patch(ctx, gz, client.MergeFrom(original)); err != nil {
-			log.Error(err, "unable to patch RRSet status")
-			return ctrl.Result{}, err
+	if len(existingZones.Items) <= 1 && (len(existingZones.Items) == 0 || len(existingClusterZones.Items) == 0) {
+		return false, nil
+	}
+	original := gz.Copy()
+	conditions := gz.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now().UTC()},
+		Reason:             ZoneReasonDuplicated,
+		Message:            ZoneMessageDuplicated,
+	})
+	gz.SetStatus(dnsv1alpha2.ZoneStatus{
+		SyncStatus:             ptr.To(FAILED_STATUS),
+		ObservedGeneration:     &gz.GetObjectMeta().Generation,
+		Conditions:             conditions,
+		LastHandledReconcileAt: getHandledReconcileRequest(gz, gz.GetStatus().LastHandledReconcileAt, false),
+	})
+	if err := commitZoneStatus(ctx, gz, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return true, err
+	}
+	return true, nil
+}
+
+// zoneMasterServicesReconcile resolve the Services of the primaries to their IP addresses, added to the masters
+// applied to PowerDNS. It return False, and fail the zone, if they cannot be resolved.
+func zoneMasterServicesReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, reader client.Reader, cl client.Client, log logr.Logger) (bool, error) {
+	if len(gz.GetSpec().MasterServices) == 0 || !isSecondaryZone(gz) {
+		return true, nil
+	}
+	masters, err := resolveMasterServices(ctx, gz, reader)
+	if err == nil {
+		gz.GetSpec().Masters = masters
+		return true, nil
+	}
+	log.Error(err, "unable to resolve the Services of the primaries")
+	original := gz.Copy()
+	conditions := gz.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now().UTC()},
+		Reason:             ZoneReasonMasterServicesUnavailable,
+		Message:            err.Error(),
+	})
+	status := gz.GetStatus()
+	status.SyncStatus = ptr.To(FAILED_STATUS)
+	status.ObservedGeneration = &gz.GetObjectMeta().Generation
+	status.Conditions = conditions
+	gz.SetStatus(status)
+	if err := commitZoneStatus(ctx, gz, original, cl); err != nil {
+		log.Error(err, "unable to patch Zone status")
+		return false, err
+	}
+	return false, nil
+}
+
+// zoneReadFailureReconcile fail the zone on an error reading it from PowerDNS, and return the error to retry
+func zoneReadFailureReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, readErr error, cl client.Client, log logr.Logger) error {
+	original := gz.Copy()
+	conditions := gz.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Time{Time: time.Now().UTC()},
+		Reason:             getFailureReason(readErr, ZoneReasonSynchronizationFailed),
+		Message:            readErr.Error(),
+	})
+	status := gz.GetStatus()
+	status.SyncStatus = ptr.To(FAILED_STATUS)
+	status.ObservedGeneration = &gz.GetObjectMeta().Generation
+	status.Conditions = conditions
+	gz.SetStatus(status)
+	if err := commitZoneStatus(ctx, gz, original, cl); err != nil {
+		log.Error(err, "unable to patch Zone status")
+	}
+	return readErr
+}
+
+// zoneRetransferReconcile retrieve the content of a secondary zone from its primaries, on request through annotation
+// or when the zone is turned into a secondary zone
+func zoneRetransferReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, becomingSecondary bool, dryRun bool, result *syncResult, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	retransferRequested := gz.GetAnnotations()[RETRANSFER_ANNOTATION] == RETRANSFER_ANNOTATION_VALUE
+	if retransferRequested || (becomingSecondary && result.status == nil) {
+		if isFrozenZone(gz) {
+			log.Info("Ignoring retransfer on a frozen zone")
+		} else if dryRun {
+			log.Info("Ignoring retransfer in dry-run mode")
+		} else if isSecondaryZone(gz) {
+			if err := retransferZoneExternalResources(ctx, gz, PDNSClient, log); err != nil {
+				result.fail(ZoneReasonRetransferFailed, err.Error())
+			}
+		} else {
+			log.Info("Ignoring retransfer annotation on a non-secondary zone", "Zone.Kind", gz.GetSpec().Kind)
 		}
+	}
+	if !retransferRequested {
+		return nil
+	}
+	// The annotation is a one-shot trigger, it is cleared whatever the result
+	original := gz.Copy()
+	annotations := gz.GetAnnotations()
+	delete(annotations, RETRANSFER_ANNOTATION)
+	gz.SetAnnotations(annotations)
+	if err := cl.Patch(ctx, gz, client.MergeFrom(original)); err != nil {
+		log.Error(err, "unable to remove retransfer annotation")
+		return err
+	}
+	return nil
+}
 
-		// Update resource metrics
-		updateZonesMetrics(gz)
+// zonePurgeReconcile delete the records of the zone on request through annotation, confirmed by the zone name,
+// and the RRsets owned by the zone too on request
+func zonePurgeReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, opts ZoneOptions, result *syncResult, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	purge, ok := gz.GetAnnotations()[PURGE_ANNOTATION]
+	if !ok {
+		return nil
+	}
+	if purge != gz.GetName() {
+		log.Info("Ignoring purge annotation not confirmed by the zone name", "annotation", PURGE_ANNOTATION)
+	} else if isFrozenZone(gz) {
+		log.Info("Ignoring purge annotation on a frozen zone", "annotation", PURGE_ANNOTATION)
+	} else if opts.DryRun {
+		log.Info("Ignoring purge annotation in dry-run mode", "annotation", PURGE_ANNOTATION)
+	} else if isSecondaryZone(gz) {
+		log.Info("Ignoring purge annotation on a secondary zone", "Zone.Kind", gz.GetSpec().Kind)
+	} else if err := purgeZoneExternalResources(ctx, gz, opts.ClusterID, PDNSClient, log); err != nil {
+		result.fail(ZoneReasonPurgeFailed, err.Error())
+	} else if gz.GetAnnotations()[PURGE_RRSETS_ANNOTATION] == PURGE_RRSETS_ANNOTATION_VALUE {
+		if _, err := deleteOwnedRRsets(ctx, gz, cl, log); err != nil {
+			result.fail(ZoneReasonPurgeFailed, err.Error())
+		}
+	}
+	// The annotations are a one-shot trigger, they are cleared whatever the result
+	original := gz.Copy()
+	annotations := gz.GetAnnotations()
+	delete(annotations, PURGE_ANNOTATION)
+	delete(annotations, PURGE_RRSETS_ANNOTATION)
+	gz.SetAnnotations(annotations)
+	if err := cl.Patch(ctx, gz, client.MergeFrom(original)); err != nil {
+		log.Error(err, "unable to remove purge annotation")
+		return err
+	}
+	return nil
+}
 
-		return ctrl.Result{}, nil
+// zoneCloneReconcile copy once the records of the source zone into the zone created in PowerDNS, and return
+// the Cloned condition recording it. A clone skipped in dry-run mode or while the zone is frozen, or failed,
+// is recorded as pending by the Cloned condition, and attempted again.
+func zoneCloneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isNewZone bool, opts ZoneOptions, result *syncResult, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) *metav1.Condition {
+	cloneFrom := gz.GetSpec().CloneFrom
+	if cloneFrom == nil {
+		return nil
+	}
+	clonedCondition := meta.FindStatusCondition(gz.GetStatus().Conditions, "Cloned")
+	if clonedCondition == nil && !isNewZone {
+		log.Info("Ignoring cloneFrom on a zone existing in PowerDNS")
+		return nil
+	}
+	if clonedCondition != nil && clonedCondition.Status == metav1.ConditionTrue {
+		return nil
+	}
+	if isSecondaryZone(gz) {
+		log.Info("Ignoring cloneFrom on a secondary zone", "Zone.Kind", gz.GetSpec().Kind)
+		return nil
+	}
+	pending := &metav1.Condition{
+		Type:               "Cloned",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionFalse,
+		Reason:             ZoneReasonClonePending,
+	}
+	switch {
+	case result.status != nil:
+		pending.Message = ZoneMessageClonePendingFailed
+		return pending
+	case opts.DryRun:
+		pending.Message = ZoneMessageClonePendingDryRun
+		return pending
+	case isFrozenZone(gz):
+		pending.Message = ZoneMessageClonePendingFrozen
+		return pending
+	}
+	if err := cloneZoneExternalResources(ctx, gz, cl, PDNSClient, log); err != nil {
+		var sourceErr *cloneSourceError
+		result.fail(getFailureReason(err, ZoneReasonCloneFailed), err.Error())
+		if stderrors.As(err, &sourceErr) {
+			// The source zone may be available later on
+			result.reason = FailureReasonZoneMissing
+		}
+		pending.Reason = ZoneReasonCloneFailed
+		pending.Message = err.Error()
+		return pending
+	}
+	return &metav1.Condition{
+		Type:               "Cloned",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionTrue,
+		Reason:             ZoneReasonCloned,
+		Message:            ZoneMessageCloned + " " + getZoneRefIndexKey(cloneFrom.ZoneRef),
 	}
+}
 
-	// Get zone
+// zoneFileSyncReconcile synchronize the records of the zone file, except the ones managed by RRsets.
+// The records of the zone file are not synchronized while the zone is frozen, or in dry-run mode.
+func zoneFileSyncReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, result *syncResult, opts ZoneOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) {
+	if result.status != nil || isSecondaryZone(gz) || isFrozenZone(gz) || opts.DryRun {
+		return
+	}
+	zoneFile, err := getZoneFile(ctx, gz, opts.getObjectReader(cl))
+	if err != nil {
+		log.Error(err, "unable to read the zone file")
+		result.fail(ZoneReasonZoneFileUnavailable, err.Error())
+		return
+	}
+	if err := zoneFileReconcile(ctx, gz, zoneFile, zoneRes, cl, PDNSClient, log); err != nil {
+		var zoneFileErr *zoneFileError
+		result.fail(getFailureReason(err, ZoneReasonZoneFileFailed), err.Error())
+		if stderrors.As(err, &zoneFileErr) {
+			result.reason = ZoneReasonZoneFileInvalid
+		}
+	}
+}
+
+// zoneParentReconcile publish the DS records and the NS delegation of the zone in the managed parent zone, if any,
+// and return the name of the parent zone
+func zoneParentReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, dnssecKeys []dnsv1alpha2.DNSSECKey, opts ZoneOptions, result *syncResult, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (*string, error) {
+	parent, err := getParentZone(ctx, gz, cl)
+	if err != nil {
+		log.Error(err, "unable to find parent zone")
+		return nil, err
+	}
+	if parent == nil {
+		return nil, nil
+	}
+	// The DS and delegation records are records of the parent zone, written by the child zone
+	if isFrozenZone(gz) || isFrozenZone(parent) {
+		log.Info("Frozen zone or parent zone, DS and delegation records are not modified", "parent", parent.GetName())
+		return ptr.To(parent.GetName()), nil
+	}
+	if opts.DryRun {
+		log.Info("Dry-run mode, DS and delegation records are not modified", "parent", parent.GetName())
+		return ptr.To(parent.GetName()), nil
+	}
+	if err := dsParentZoneReconcile(ctx, gz, parent, dnssecKeys, opts.ClusterID, PDNSClient, log); err != nil {
+		result.fail(ZoneReasonDSSynchronizationFailed, err.Error())
+	}
+	if err := delegationParentZoneReconcile(ctx, gz, parent, ptr.Deref(gz.GetSpec().Delegate, false), opts.ClusterID, PDNSClient, log); err != nil {
+		result.fail(ZoneReasonDelegationFailed, err.Error())
+	}
+	return ptr.To(parent.GetName()), nil
+}
+
+// zoneStatusReconcile report the zone in PowerDNS, its owned RRsets, peers and transfers in its status
+func zoneStatusReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, cloned *metav1.Condition, result *syncResult, opts ZoneOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+	// Update ZoneStatus
 	zoneRes, err := getZoneExternalResources(ctx, gz.GetObjectMeta().Name, PDNSClient, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	syncStatus, conditionMessage, conditionReason, conditionStatus, err := zoneExternalResourcesReconcile(ctx, zoneRes, gz, PDNSClient, log)
+	dnssecKeys, err := getDNSSECKeysExternalResources(ctx, gz, zoneRes, PDNSClient, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-
-	if syncStatus == nil {
-		syncStatus = ptr.To(SUCCEEDED_STATUS)
+	parentZone, err := zoneParentReconcile(ctx, gz, dnssecKeys, opts, result, cl, PDNSClient, log)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// Update ZoneStatus
-	zoneRes, err = getZoneExternalResources(ctx, gz.GetObjectMeta().Name, PDNSClient, log)
+	// The RRsets left by a previous zone with the same name are owned again, then the statuses of the RRsets
+	// owned by the zone are summarized, the zone is reconciled on their changes
+	referencingRRsets, err := getReferencingRRsets(ctx, gz, cl)
 	if err != nil {
+		log.Error(err, "unable to find RRsets referencing the zone")
+		return ctrl.Result{}, err
+	}
+	if err := reownOrphanedRRsets(ctx, gz, referencingRRsets, cl, log); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Conflict on RRSet owner reference, retrying")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to set owner reference")
 		return ctrl.Result{}, err
 	}
+	others := []metav1.Condition{getRecordsReadyCondition(getOwnedRRsets(gz, referencingRRsets))}
+	if cloned != nil {
+		others = append(others, *cloned)
+	}
+
+	// The serial of the zone is compared against the peers serving the same data
+	var divergent map[string]string
+	if len(PDNSClient.Peers) != 0 {
+		divergent = getDivergentPeers(ctx, gz, zoneRes.Serial, PDNSClient.Peers, log)
+		others = append(others, getConsistencyCondition(divergent))
+	}
+
+	// The serial of a secondary zone is compared against its primaries, the transfers are not exposed by PowerDNS
+	var transfer *dnsv1alpha2.ZoneTransferStatus
+	if isSecondaryZone(gz) {
+		query := PDNSClient.SOASerials
+		if query == nil {
+			query = querySOASerial
+		}
+		transfer = getTransferStatus(ctx, gz, zoneRes, query, time.Now().UTC(), log)
+		others = append(others, getTransferCondition(transfer))
+	}
 
-	err = patchZoneStatus(ctx, gz, zoneRes, syncStatus, cl, metav1.Condition{
+	err = patchZoneStatus(ctx, gz, zoneRes, dnssecKeys, parentZone, transfer, result.status, cl, metav1.Condition{
 		Type:               "Available",
 		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
-		Status:             conditionStatus,
-		Reason:             conditionReason,
-		Message:            conditionMessage,
-	})
+		Status:             result.conditionStatus,
+		Reason:             result.reason,
+		Message:            result.message,
+	}, others...)
 	if err != nil {
 		if errors.IsConflict(err) {
 			log.Info("Object has been modified, forcing a new reconciliation")
@@ -162,178 +577,694 @@ func zoneReconcile(ctx context.Context, gz dnsv1alpha2.GenericZone, isModified b
 	}
 
 	// Update resource metrics
-	updateZonesMetrics(gz)
-
-	return ctrl.Result{}, nil
+	updateZonesDNSSECMetrics(gz, opts.DNSSECKeyRolloverDays)
+	updateZonesPeerMetrics(gz, PDNSClient.Peers, divergent)
+	if *result.status == SUCCEEDED_STATUS {
+		updateZonesLastSyncMetrics(gz)
+	}
+	return getZoneRequeueResult(gz, result, PDNSClient, log), nil
 }
 
-func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, lastUpdateTime *metav1.Time, scheme *runtime.Scheme, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
-	isInFailedStatus := (gr.GetStatus().SyncStatus != nil && *gr.GetStatus().SyncStatus == FAILED_STATUS)
+// getZoneRequeueResult return when the zone is reconciled again: on transient failures, and periodically
+// to check the peers, the transfers and the key rollover
+func getZoneRequeueResult(gz dnsv1alpha2.GenericZone, result *syncResult, PDNSClient PdnsClienter, log logr.Logger) ctrl.Result {
+	// The rate limiter of the controller backs off exponentially between the retries
+	if result.failed() && isTransientFailureReason(result.reason) {
+		log.Info("Transient synchronization failure, retrying", "reason", result.reason)
+		return ctrl.Result{Requeue: true}
+	}
 
-	// initialize syncStatus
-	var syncStatus *string
-	conditionStatus := metav1.ConditionTrue
-	conditionReason := RrsetReasonSynced
-	conditionMessage := RrsetMessageSyncSucceeded
+	// Zones are periodically reconciled to check their consistency against the peers
+	if len(PDNSClient.Peers) != 0 {
+		return ctrl.Result{RequeueAfter: PEER_CONSISTENCY_CHECK_INTERVAL}
+	}
+	// Secondary zones are periodically reconciled to check their transfers
+	if isSecondaryZone(gz) {
+		return ctrl.Result{RequeueAfter: TRANSFER_STATUS_CHECK_INTERVAL}
+	}
+	// Signed zones are periodically reconciled to keep the key rollover metric up to date
+	if ptr.Deref(gz.GetStatus().DNSsec, false) {
+		return ctrl.Result{RequeueAfter: DNSSEC_METRICS_REFRESH_INTERVAL}
+	}
+	return ctrl.Result{}
+}
 
+func rrsetReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, isModified bool, isDeleted bool, opts RRsetOptions, lastUpdateTime *metav1.Time, scheme *runtime.Scheme, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
 	// examine DeletionTimestamp to determine if object is under deletion
-	if !isDeleted {
-		// The object is not being deleted, so if it does not have our finalizer,
-		// then lets add the finalizer and update the object. This is equivalent
-		// to registering our finalizer.
-		if !controllerutil.ContainsFinalizer(gr, RESOURCES_FINALIZER_NAME) {
-			controllerutil.AddFinalizer(gr, RESOURCES_FINALIZER_NAME)
-			lastUpdateTime = &metav1.Time{Time: time.Now().UTC()}
-			if err := cl.Update(ctx, gr); err != nil {
-				log.Error(err, "Failed to add finalizer")
-				return ctrl.Result{}, err
-			}
-		}
-	} else {
-		// The object is being deleted
-		finalizerRemoved := false
-		if controllerutil.ContainsFinalizer(gr, RESOURCES_FINALIZER_NAME) {
-			// our finalizer is present, so lets handle any external dependency
-			if err := deleteRrsetExternalResources(ctx, zone, gr, PDNSClient, log); err != nil {
-				// if fail to delete the external resource, return with error
-				// so that it can be retried
-				log.Error(err, "Failed to delete external resources")
-				return ctrl.Result{}, err
-			}
-			// remove our finalizer from the list.
-			controllerutil.RemoveFinalizer(gr, RESOURCES_FINALIZER_NAME)
-			finalizerRemoved = true
-		}
-		if controllerutil.ContainsFinalizer(gr, METRICS_FINALIZER_NAME) {
-			// Remove resource metrics and finalizer
-			removeRrsetMetrics(gr)
-			controllerutil.RemoveFinalizer(gr, METRICS_FINALIZER_NAME)
-			finalizerRemoved = true
-		}
-		if finalizerRemoved {
-			if err := cl.Update(ctx, gr); err != nil {
-				log.Error(err, "Failed to remove finalizer")
-				return ctrl.Result{}, err
-			}
-		}
-		//nolint:ineffassign
+	if isDeleted {
+		return rrsetDeletionReconcile(ctx, gr, zone, opts, cl, PDNSClient, log)
+	}
+	// The object is not being deleted, so if it does not have our finalizer,
+	// then lets add the finalizer and update the object. This is equivalent
+	// to registering our finalizer.
+	if !controllerutil.ContainsFinalizer(gr, RESOURCES_FINALIZER_NAME) {
+		controllerutil.AddFinalizer(gr, RESOURCES_FINALIZER_NAME)
 		lastUpdateTime = &metav1.Time{Time: time.Now().UTC()}
+		if err := cl.Update(ctx, gr); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
 
-		// Stop reconciliation as the item is being deleted
-		return ctrl.Result{}, nil
+	// Rollback requested through annotation: the records of the revision are restored in the specification,
+	// the new generation is applied on the next reconciliation
+	if revision, ok := gr.GetAnnotations()[ROLLBACK_ANNOTATION]; ok {
+		return rrsetRollbackReconcile(ctx, gr, revision, cl, log)
+	}
+
+	// Synchronization requested through annotation: the RRset is reconciled as if modified, and PowerDNS queried
+	// The value handled is recorded in the status once synchronized
+	reconcileRequested := isReconcileRequested(gr, gr.GetStatus().LastHandledReconcileAt)
+	if reconcileRequested {
+		isModified = true
 	}
 
 	// We cannot exit previously (at the early moments of reconcile), because we have to allow deletion process
-	if isInFailedStatus && !isModified {
+	if !isModified && isParkedRRset(gr, opts.ConflictPolicy) {
 		// Update resource metrics
 		updateRrsetsMetrics(getRRsetName(gr), gr)
 		return ctrl.Result{}, nil
 	}
 
-	// If a RRset already exists with the same DNS name:
-	// * Stop reconciliation
-	// * Append a Failed Status on RRset
-	var existingRRsets dnsv1alpha2.RRsetList
-	if err := cl.List(ctx, &existingRRsets, client.MatchingFields{"RRset.Entry.Name": getRRsetName(gr) + "/" + gr.GetSpec().Type}); err != nil {
+	// Outside of its activity window, the RRset is not published
+	now := time.Now().UTC()
+	active := isActiveRRset(gr, now)
+	desired, resolved, err := getDesiredRRset(ctx, gr, active, cl, log)
+	if err != nil || !resolved {
+		// The rate limiter of the controller backs off exponentially between the retries
+		return ctrl.Result{Requeue: err == nil}, err
+	}
+
+	failedReason, failedMessage, others, err := getRRsetDuplication(ctx, gr, active, now, opts.ConflictPolicy, cl)
+	if err != nil {
 		log.Error(err, "unable to find RRsets related to the DNS Name")
 		return ctrl.Result{}, err
 	}
-	var existingClusterRRsets dnsv1alpha2.ClusterRRsetList
-	if err := cl.List(ctx, &existingClusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Name": getRRsetName(gr) + "/" + gr.GetSpec().Type}); err != nil {
+	// An inactive RRset removes its records from PowerDNS, once applied and unless another RRset publishes the name
+	unpublished := !active && (!hasBeenApplied(gr) || others != 0)
+	if failedReason == "" {
+		failedReason, failedMessage, err = getRRsetConflict(ctx, gr, zone, desired, cl)
+		if err != nil {
+			log.Error(err, "unable to find RRsets related to the DNS Name")
+			return ctrl.Result{}, err
+		}
+	}
+	// Outside of the maintenance windows, the changes are queued until the next window opens
+	windowOpen, nextWindow, err := getMaintenanceWindowState(getMaintenanceWindows(zone, opts.MaintenanceWindows), now)
+	if failedReason == "" && err != nil {
+		failedReason, failedMessage = RrsetReasonInvalidMaintenanceWindow, err.Error()
+	}
+	if failedReason != "" {
+		return ctrl.Result{}, rrsetFailureReconcile(ctx, gr, failedReason, failedMessage, lastUpdateTime, cl, log)
+	}
+
+	applied, err := getAppliedRRset(ctx, gr, desired, opts.DefaultTTL, cl)
+	if err != nil {
 		log.Error(err, "unable to find RRsets related to the DNS Name")
 		return ctrl.Result{}, err
 	}
 
+	result := syncResult{conditionStatus: metav1.ConditionTrue, reason: RrsetReasonSynced, message: RrsetMessageSyncSucceeded}
+	if !active {
+		result = syncResult{conditionStatus: metav1.ConditionFalse, reason: RrsetReasonInactive, message: RrsetMessageInactive}
+	}
+	appliedHash := getAppliedHash(applied, zone, opts.DefaultTTL, opts.ClusterID)
+	hold := getRRsetHold(gr, zone, appliedHash, windowOpen, nextWindow, opts)
+	pendingChanges, appliedZoneSerial, changed := rrsetExternalResourcesReconcile(ctx, gr, zone, applied, appliedHash, hold, unpublished, reconcileRequested, opts, &result, PDNSClient, log)
+	if changed {
+		lastUpdateTime = &metav1.Time{Time: time.Now().UTC()}
+	}
+
+	// Set OwnerReference
+	if err := ownObject(ctx, zone, gr, scheme, cl, log); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Conflict on RRSet owner reference, retrying")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to set owner reference")
+		return ctrl.Result{}, err
+	}
+
+	if result.status == nil {
+		result.status = ptr.To(SUCCEEDED_STATUS)
+	}
+	if err := rrsetStatusReconcile(ctx, gr, zone, applied, appliedHash, appliedZoneSerial, pendingChanges, &result, lastUpdateTime, cl, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	return getRRsetRequeueResult(gr, &result, nextWindow, now, log), nil
+}
+
+// isParkedRRset return True if the RRset failed permanently, it is not reconciled until modified
+// Transient failures are retried, and when the conflicts are resolved, a duplicated RRset is reconciled again
+// to take over the name of a deleted winner
+func isParkedRRset(gr dnsv1alpha2.GenericRRset, conflictPolicy string) bool {
+	isInFailedStatus := (gr.GetStatus().SyncStatus != nil && *gr.GetStatus().SyncStatus == FAILED_STATUS)
+	return isInFailedStatus && !isTransientFailure(gr.GetStatus().Conditions) && !(isResolvingConflicts(conflictPolicy) && isDuplicatedRRset(gr))
+}
+
+// rrsetFailedZoneReconcile fail the RRset of a zone in Failed status, and remove its metrics finalizer when deleted
+func rrsetFailedZoneReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, isDeleted bool, cl client.Client, log logr.Logger) error {
+	original := gr.Copy()
+	conditions := gr.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             RrsetReasonZoneNotAvailable,
+		Message:            RrsetMessageUnavailableZone + zone.GetName(),
+	})
+	status := gr.GetStatus()
+	status.SyncStatus = ptr.To(FAILED_STATUS)
+	status.ObservedGeneration = &gr.GetObjectMeta().Generation
+	status.Conditions = conditions
+	gr.SetStatus(status)
+	if err := commitRrsetStatus(ctx, gr, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return err
+	}
+
+	if isDeleted && controllerutil.ContainsFinalizer(gr, METRICS_FINALIZER_NAME) {
+		controllerutil.RemoveFinalizer(gr, METRICS_FINALIZER_NAME)
+		// Remove resource metrics
+		removeRrsetMetrics(gr)
+		if err := cl.Update(ctx, gr); err != nil {
+			log.Error(err, "Failed to remove finalizer")
+			return err
+		}
+	}
+	return nil
+}
+
+// rrsetDeletionReconcile delete the records of the RRset from PowerDNS, and remove the finalizers
+func rrsetDeletionReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, opts RRsetOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (ctrl.Result, error) {
+	finalizerRemoved := false
+	if controllerutil.ContainsFinalizer(gr, RESOURCES_FINALIZER_NAME) {
+		if !opts.DryRun && isAwaitingDeletionApproval(gr, zone, opts.VerifiedApprovals) {
+			// The records and the finalizers are kept until approved, the approval triggers a new reconciliation
+			log.Info("Deletion pending approval, external resources are not deleted")
+			return ctrl.Result{}, rrsetHeldDeletionReconcile(ctx, gr, getApprovalHold(gr, opts.VerifiedApprovals, true), cl, log)
+		}
+		// Outside of the maintenance windows, the deletions are queued until the next window opens,
+		// an invalid schedule, reported by the zone, does not hold them
+		var windowHold *rrsetHold
+		windowOpen, nextWindow, err := getMaintenanceWindowState(getMaintenanceWindows(zone, opts.MaintenanceWindows), time.Now().UTC())
+		if err == nil && !windowOpen {
+			windowHold = &rrsetHold{RrsetReasonPendingWindow, RrsetMessagePendingWindow + " " + nextWindow.Format(time.RFC3339)}
+		}
+		// our finalizer is present, so lets handle any external dependency
+		hold, err := deleteRRsetRecords(ctx, gr, zone, windowHold, opts, cl, PDNSClient, log)
+		if err != nil {
+			// if fail to delete the external resource, return with error
+			// so that it can be retried
+			return ctrl.Result{}, err
+		}
+		if hold != nil {
+			// The records and the finalizers are kept until the zone is unfrozen, unfreezing triggers a new reconciliation,
+			// or until the next maintenance window opens
+			if err := rrsetHeldDeletionReconcile(ctx, gr, hold, cl, log); err != nil {
+				return ctrl.Result{}, err
+			}
+			if hold == windowHold {
+				return ctrl.Result{RequeueAfter: max(time.Until(nextWindow), time.Second)}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+		// remove our finalizer from the list.
+		controllerutil.RemoveFinalizer(gr, RESOURCES_FINALIZER_NAME)
+		finalizerRemoved = true
+	}
+	if controllerutil.ContainsFinalizer(gr, METRICS_FINALIZER_NAME) {
+		// Remove resource metrics and finalizer
+		removeRrsetMetrics(gr)
+		controllerutil.RemoveFinalizer(gr, METRICS_FINALIZER_NAME)
+		finalizerRemoved = true
+	}
+	if finalizerRemoved {
+		if err := cl.Update(ctx, gr); err != nil {
+			log.Error(err, "Failed to remove finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Stop reconciliation as the item is being deleted
+	return ctrl.Result{}, nil
+}
+
+// rrsetHeldDeletionReconcile report in the status of a deleted RRset why its records are not removed yet
+func rrsetHeldDeletionReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, hold *rrsetHold, cl client.Client, log logr.Logger) error {
+	original := gr.Copy()
+	status := gr.GetStatus()
+	lastUpdateTime := &metav1.Time{Time: time.Now().UTC()}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: *lastUpdateTime,
+		Reason:             hold.reason,
+		Message:            hold.message,
+	})
+	status.SyncStatus = ptr.To(PENDING_STATUS)
+	status.ObservedGeneration = &gr.GetObjectMeta().Generation
+	gr.SetStatus(status)
+	if err := commitRrsetStatus(ctx, gr, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return err
+	}
+	return nil
+}
+
+// deleteRRsetRecords delete the records of the RRset from PowerDNS, unless they do not belong to it.
+// It return the hold of the deletion if the records are kept until the zone is unfrozen, or while the window is closed.
+func deleteRRsetRecords(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, windowHold *rrsetHold, opts RRsetOptions, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) (*rrsetHold, error) {
+	// The records of a deleted winner are taken over by the next winner
+	handedOver, err := hasConflictSuccessor(ctx, gr, opts.ConflictPolicy, cl)
+	if err != nil {
+		return nil, err
+	}
+	// An absent RRset has no external resources
+	switch {
+	case opts.DryRun:
+		log.Info("Dry-run mode, external resources are not deleted")
+	case isFrozenZone(zone):
+		log.Info("Frozen zone, external resources are not deleted")
+		return &rrsetHold{RrsetReasonZoneFrozen, RrsetMessageZoneFrozen}, nil
+	case isRemovedRRset(gr, zone, opts.DefaultTTL, opts.ClusterID):
+		// Not applied, or already removed outside of its activity window
+		log.Info("Removed RRset, no external resources to delete")
+	case isAbsentRRset(gr):
+		log.Info("Absent RRset, no external resources to delete")
+	case isProtectedApexRRset(gr, zone):
+		// The apex records belong to the zone
+		log.Info("Protected apex RRset, no external resources to delete")
+	case isDuplicatedRRset(gr):
+		// The records belong to the RRset owning the name and type
+		log.Info("Duplicated RRset, no external resources to delete")
+	case handedOver:
+		log.Info("Contended RRset, external resources taken over by the next winner")
+	case windowHold != nil:
+		log.Info("Maintenance window closed, external resources are not deleted")
+		return windowHold, nil
+	case isMergeStrategy(gr):
+		// Only the records of the RRset are removed, the records of the other contributors are kept
+		if err := deleteMergedRrsetExternalResources(ctx, zone, gr, opts.DefaultTTL, opts.ClusterID, cl, PDNSClient, log); err != nil {
+			log.Error(err, "Failed to delete external resources")
+			return nil, err
+		}
+	default:
+		if err := deleteRrsetExternalResources(ctx, zone, gr, opts.ClusterID, PDNSClient, log); err != nil {
+			log.Error(err, "Failed to delete external resources")
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// rrsetRollbackReconcile restore the records of the revision in the specification of the RRset
+func rrsetRollbackReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, revision string, cl client.Client, log logr.Logger) (ctrl.Result, error) {
+	rolledBack := rollbackRRset(gr, revision)
+	// The annotation is a one-shot trigger, it is cleared whatever the result
+	annotations := gr.GetAnnotations()
+	delete(annotations, ROLLBACK_ANNOTATION)
+	gr.SetAnnotations(annotations)
+	if err := cl.Update(ctx, gr); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Conflict on RRSet rollback, retrying")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to roll back RRset")
+		return ctrl.Result{}, err
+	}
+	if !rolledBack {
+		log.Info("Ignoring rollback annotation on an unknown revision, or on a RRset with a target", "revision", revision)
+	} else {
+		log.Info("RRset rolled back", "revision", revision)
+	}
+	return ctrl.Result{}, nil
+}
+
+// getDesiredRRset return the RRset to apply to PowerDNS: without records outside of its activity window,
+// with the current addresses of its target if any. It return False, and fail the RRset, if the target cannot be resolved.
+// The records are set on a copy: the specification of the RRset is updated with its owner reference
+func getDesiredRRset(ctx context.Context, gr dnsv1alpha2.GenericRRset, active bool, cl client.Client, log logr.Logger) (dnsv1alpha2.GenericRRset, bool, error) {
+	if !active {
+		return getInactiveRRset(gr), true, nil
+	}
+	if gr.GetSpec().TargetRef == nil || isAbsentRRset(gr) {
+		return gr, true, nil
+	}
+	records, err := resolveTargetRef(ctx, gr, cl)
+	if err == nil {
+		desired := gr.Copy()
+		desired.GetSpec().Records = records
+		return desired, true, nil
+	}
+	log.Error(err, "unable to resolve the target of the RRset")
+	original := gr.Copy()
+	conditions := gr.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             RrsetReasonTargetUnavailable,
+		Message:            err.Error(),
+	})
+	status := gr.GetStatus()
+	status.SyncStatus = ptr.To(FAILED_STATUS)
+	status.ObservedGeneration = &gr.GetObjectMeta().Generation
+	status.Conditions = conditions
+	gr.SetStatus(status)
+	if err := commitRrsetStatus(ctx, gr, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return nil, false, err
+	}
+	return nil, false, nil
+}
+
+// getRRsetEntryIndexKey return the key the RRsets and ClusterRRsets are indexed with, whatever their status, to find
+// the ones claiming the same name, case-insensitively, and type
+func getRRsetEntryIndexKey(rrset dnsv1alpha2.GenericRRset) string {
+	return getEntryIndexKey(getRRsetName(rrset), rrset.GetSpec().Type)
+}
+
+// getEntryIndexKey return the index key of a canonical name and a type, see getRRsetEntryIndexKey
+func getEntryIndexKey(name, rrType string) string {
+	return strings.ToLower(name) + "/" + rrType
+}
+
+// listRRsetEntryOwners return the RRsets and ClusterRRsets owning the index key of a name and type:
+// the ones synchronized, or not reconciled yet
+func listRRsetEntryOwners(ctx context.Context, key string, cl client.Reader) ([]dnsv1alpha2.RRset, []dnsv1alpha2.ClusterRRset, error) {
+	var existingRRsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &existingRRsets, client.MatchingFields{"RRset.Entry.Name": key}); err != nil {
+		return nil, nil, err
+	}
+	var existingClusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &existingClusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Name": key}); err != nil {
+		return nil, nil, err
+	}
+	rrsets := slices.DeleteFunc(existingRRsets.Items, func(rrset dnsv1alpha2.RRset) bool { return !isEntryOwner(&rrset) })
+	clusterRRsets := slices.DeleteFunc(existingClusterRRsets.Items, func(rrset dnsv1alpha2.ClusterRRset) bool { return !isEntryOwner(&rrset) })
+	return rrsets, clusterRRsets, nil
+}
+
+// isEntryOwner return True if the RRset is synchronized, or not reconciled yet
+func isEntryOwner(rrset dnsv1alpha2.GenericRRset) bool {
+	status := rrset.GetStatus().SyncStatus
+	return status == nil || *status == SUCCEEDED_STATUS
+}
+
+// getRRsetDuplication return the failure of the RRset if another RRset owns the same name and type,
+// and the number of the other active RRsets claiming them
+func getRRsetDuplication(ctx context.Context, gr dnsv1alpha2.GenericRRset, active bool, now time.Time, conflictPolicy string, cl client.Client) (string, string, int, error) {
+	rrsets, clusterRRsets, err := listRRsetEntryOwners(ctx, getRRsetEntryIndexKey(gr), cl)
+	if err != nil {
+		return "", "", 0, err
+	}
+	existingRRsets := dnsv1alpha2.RRsetList{Items: rrsets}
+	existingClusterRRsets := dnsv1alpha2.ClusterRRsetList{Items: clusterRRsets}
+
 	// Multiple use-cases:
 	// 1 RRset (test.example.com in NS example1) + 1 RRset (test.example.com in NS example3)
 	// In that case: len(existingRRsets.Items) > 1
 	// 1 RRset (test.example.com in NS example1) + 1 ClusterRRset (test.example.com)
 	// In that case: len(existingRRsets.Items) >= 1 AND len(existingClusterRRsets.Items) >= 1
-	if len(existingRRsets.Items) > 1 || (len(existingRRsets.Items) >= 1 && len(existingClusterRRsets.Items) >= 1) {
-		original := gr.Copy()
-		conditions := gr.GetStatus().Conditions
-		meta.SetStatusCondition(&conditions, metav1.Condition{
-			Type:               "Available",
-			Status:             metav1.ConditionFalse,
-			LastTransitionTime: *lastUpdateTime,
-			Reason:             RrsetReasonDuplicated,
-			Message:            RrsetMessageDuplicated,
-		})
-		name := getRRsetName(gr)
-		gr.SetStatus(dnsv1alpha2.RRsetStatus{
-			LastUpdateTime:     lastUpdateTime,
-			DnsEntryName:       &name,
-			SyncStatus:         ptr.To(FAILED_STATUS),
-			ObservedGeneration: &gr.GetObjectMeta().Generation,
-			Conditions:         conditions,
-		})
-		if err := cl.Status().Patch(ctx, gr, client.MergeFrom(original)); err != nil {
-			log.Error(err, "unable to patch RRSet status")
-			return ctrl.Result{}, err
+	// RRsets all using the Patch strategy, or all the Merge strategy, are not duplicated, each of them manages its own records
+	// The RRsets outside of their activity window claim no name, an inactive RRset is not a duplicate
+	existingRRsets.Items = slices.DeleteFunc(existingRRsets.Items, func(rrset dnsv1alpha2.RRset) bool { return !isActiveRRset(&rrset, now) })
+	existingClusterRRsets.Items = slices.DeleteFunc(existingClusterRRsets.Items, func(rrset dnsv1alpha2.ClusterRRset) bool { return !isActiveRRset(&rrset, now) })
+	others := len(existingRRsets.Items) + len(existingClusterRRsets.Items)
+	if !active {
+		return "", "", others, nil
+	}
+	if isResolvingConflicts(conflictPolicy) {
+		// The name and type are given to a single winner, elected among all the RRsets claiming them
+		contenders, err := getContendingRRsets(ctx, gr, cl)
+		if err != nil {
+			return "", "", 0, err
+		}
+		contenders = slices.DeleteFunc(contenders, func(rrset dnsv1alpha2.GenericRRset) bool { return !isActiveRRset(rrset, now) })
+		if winner := getConflictWinner(contenders, conflictPolicy); winner.GetUID() != gr.GetUID() && !areSharedRRsets(contenders) {
+			return RrsetReasonDuplicated, RrsetMessageDuplicated + ", owned by " + getContenderKey(winner), others, nil
 		}
+		return "", "", others, nil
+	}
+	sameNameRRsets := []dnsv1alpha2.GenericRRset{gr}
+	for i := range existingRRsets.Items {
+		sameNameRRsets = append(sameNameRRsets, &existingRRsets.Items[i])
+	}
+	for i := range existingClusterRRsets.Items {
+		sameNameRRsets = append(sameNameRRsets, &existingClusterRRsets.Items[i])
+	}
+	if (len(existingRRsets.Items) > 1 || (len(existingRRsets.Items) >= 1 && len(existingClusterRRsets.Items) >= 1)) && !areSharedRRsets(sameNameRRsets) {
+		return RrsetReasonDuplicated, RrsetMessageDuplicated, others, nil
+	}
+	return "", "", others, nil
+}
+
+// getRRsetConflict return the failure of the RRset if its records cannot be published in the zone:
+// apex records of the zone, or records conflicting with the other RRsets at the same name or below a DNAME
+func getRRsetConflict(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, desired dnsv1alpha2.GenericRRset, cl client.Client) (string, string, error) {
+	if isProtectedApexRRset(gr, zone) {
+		// Overwriting, or deleting, the apex SOA or NS records breaks the zone
+		return RrsetReasonApexProtected, RrsetMessageApexProtected, nil
+	}
+	if isAbsentRRset(desired) {
+		return "", "", nil
+	}
+	// Some types cannot coexist at the same name (e.g. ALIAS and A), PowerDNS would serve inconsistent answers
+	conflictingType, err := getConflictingRRsetType(ctx, gr, cl)
+	if err != nil {
+		return "", "", err
+	}
+	if conflictingType != "" {
+		return RrsetReasonConflict, RrsetMessageConflict + " " + conflictingType, nil
+	}
+	// A DNAME redirects the whole subtree below its owner name, no other data can exist there
+	conflictingName, err := getDNAMEConflict(ctx, gr, cl)
+	if err != nil {
+		return "", "", err
+	}
+	if conflictingName != "" {
+		return RrsetReasonConflict, RrsetMessageDNAMEConflict + " " + conflictingName, nil
+	}
+	return "", "", nil
+}
+
+// getAppliedRRset return the RRset applied to PowerDNS: with the Merge strategy, the union of the records
+// of all the contributing RRsets
+func getAppliedRRset(ctx context.Context, gr dnsv1alpha2.GenericRRset, desired dnsv1alpha2.GenericRRset, defaultTTL uint32, cl client.Client) (dnsv1alpha2.GenericRRset, error) {
+	if !isMergeStrategy(gr) {
+		return desired, nil
+	}
+	contributors, err := getContendingRRsets(ctx, gr, cl)
+	if err != nil {
+		return nil, err
+	}
+	// The RRset is the first contender, it contributes its desired records, none once inactive
+	contributors[0] = desired
+	merged := getMergedRRset(gr, contributors, defaultTTL, time.Now().UTC())
+	if len(merged.GetSpec().Records) == 0 {
+		// Without contributor, the external RRset is removed
+		return desired, nil
+	}
+	return merged, nil
+}
+
+// rrsetFailureReconcile report the failure of the RRset in its status, the records already applied are kept
+func rrsetFailureReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, reason string, message string, lastUpdateTime *metav1.Time, cl client.Client, log logr.Logger) error {
+	original := gr.Copy()
+	conditions := gr.GetStatus().Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               "Available",
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: *lastUpdateTime,
+		Reason:             reason,
+		Message:            message,
+	})
+	name := getRRsetName(gr)
+	gr.SetStatus(dnsv1alpha2.RRsetStatus{
+		LastUpdateTime:     lastUpdateTime,
+		DnsEntryName:       &name,
+		SyncStatus:         ptr.To(FAILED_STATUS),
+		ObservedGeneration: &gr.GetObjectMeta().Generation,
+		Conditions:         conditions,
+		AppliedHash:        gr.GetStatus().AppliedHash,
+		AppliedZoneSerial:  gr.GetStatus().AppliedZoneSerial,
+		Revisions:          gr.GetStatus().Revisions,
+		// A permanent failure handles the request
+		LastHandledReconcileAt: getHandledReconcileRequest(gr, gr.GetStatus().LastHandledReconcileAt, false),
+	})
+	if err := commitRrsetStatus(ctx, gr, original, cl); err != nil {
+		log.Error(err, "unable to patch RRSet status")
+		return err
+	}
+	return nil
+}
+
+// rrsetHold is the reason why the changes of a RRset are only reported, PowerDNS is not modified
+type rrsetHold struct {
+	reason  string
+	message string
+}
 
-		// Update resource metrics
-		updateRrsetsMetrics(getRRsetName(gr), gr)
+// getRRsetHold return why the changes of the RRset are held, or nil if they are applied:
+// in dry-run mode, in a frozen zone, until approved in a zone requiring approval, or outside of the maintenance windows
+func getRRsetHold(gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, appliedHash string, windowOpen bool, nextWindow time.Time, opts RRsetOptions) *rrsetHold {
+	pending := ptr.Deref(gr.GetStatus().AppliedHash, "") != appliedHash
+	switch {
+	case opts.DryRun:
+		return &rrsetHold{RrsetReasonDryRun, RrsetMessageDryRun}
+	case isFrozenZone(zone) && pending:
+		// In a frozen zone, the changes are only reported and the records already applied are not written again
+		return &rrsetHold{RrsetReasonZoneFrozen, RrsetMessageZoneFrozen}
+	case isAwaitingApproval(gr, zone, appliedHash, opts.VerifiedApprovals):
+		return getApprovalHold(gr, opts.VerifiedApprovals, false)
+	case !windowOpen && pending:
+		return &rrsetHold{RrsetReasonPendingWindow, RrsetMessagePendingWindow + " " + nextWindow.Format(time.RFC3339)}
+	}
+	return nil
+}
 
-		return ctrl.Result{}, nil
+// rrsetExternalResourcesReconcile apply the records of the RRset to PowerDNS, or only compute the pending changes
+// when held. It return the pending changes, the serial of the zone once applied, and True if PowerDNS was modified.
+// PowerDNS is not queried when the desired state is already applied and the zone was not modified since
+func rrsetExternalResourcesReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, applied dnsv1alpha2.GenericRRset, appliedHash string, hold *rrsetHold, unpublished bool, reconcileRequested bool, opts RRsetOptions, result *syncResult, PDNSClient PdnsClienter, log logr.Logger) ([]string, *uint32, bool) {
+	// The records found in PowerDNS before the first apply, not written by the operator, win the conflict
+	checkUnmanaged := opts.CheckUnmanagedRecords && isCheckingUnmanagedRecords(gr, opts.ConflictPolicy)
+	switch {
+	case unpublished:
+		log.Info("Inactive RRset, no records to remove, PowerDNS not modified")
+	case hold != nil:
+		return rrsetPendingChangesReconcile(ctx, zone, applied, hold, checkUnmanaged, opts, result, PDNSClient, log), nil, false
+	case isFrozenZone(zone):
+		log.Info("Frozen zone, RRset already applied, PowerDNS not modified")
+	case !reconcileRequested && isAlreadyApplied(gr, zone, appliedHash):
+		log.Info("RRset already applied, PowerDNS not queried")
+	default:
+		changed, err := createOrUpdateRrsetExternalResources(ctx, zone, applied, opts.DefaultTTL, opts.ClusterID, checkUnmanaged, PDNSClient)
+		var conflict *ownershipConflictError
+		var unmanaged *unmanagedRecordsError
+		if stderrors.As(err, &unmanaged) {
+			// The records already in PowerDNS are left untouched
+			log.Info("Records not written by the operator, not overwritten", "records", unmanaged.records)
+			result.fail(RrsetReasonUnmanagedRecords, RrsetMessageUnmanagedRecords+" "+strings.Join(unmanaged.records, ", "))
+		} else if stderrors.As(err, &conflict) {
+			// The records of another cluster are left untouched, the conflict is only reported
+			log.Info("Records owned by the operator of another cluster, not modified", "owner", conflict.owner)
+			result.fail(RrsetReasonOwnershipConflict, RrsetMessageOwnershipConflict+" "+conflict.owner)
+		} else if err != nil {
+			log.Error(err, "Failed to create or update external resources")
+			result.fail(getFailureReason(err, RrsetReasonSynchronizationFailed), err.Error())
+		}
+		if err != nil {
+			return nil, nil, changed
+		}
+		result.synced = true
+		// The apply bumps the serial of the zone: the serial read afterwards is the one checked by the next reconciliations
+		serial, err := getZoneSerial(ctx, zone.GetName(), PDNSClient)
+		if err != nil {
+			log.Error(err, "Failed to get the serial of the zone")
+		}
+		return nil, serial, changed
 	}
+	return nil, nil, false
+}
 
-	// Create or Update
-	changed, err := createOrUpdateRrsetExternalResources(ctx, zone, gr, PDNSClient)
+// rrsetPendingChangesReconcile return the changes of the RRset not applied to PowerDNS while held
+func rrsetPendingChangesReconcile(ctx context.Context, zone dnsv1alpha2.GenericZone, applied dnsv1alpha2.GenericRRset, hold *rrsetHold, checkUnmanaged bool, opts RRsetOptions, result *syncResult, PDNSClient PdnsClienter, log logr.Logger) []string {
+	externalRecord, err := getRrsetExternalResources(ctx, zone, applied, PDNSClient)
 	if err != nil {
-		log.Error(err, "Failed to create or update external resources")
-		syncStatus = ptr.To(FAILED_STATUS)
-		conditionStatus = metav1.ConditionFalse
-		conditionReason = RrsetReasonSynchronizationFailed
-		conditionMessage = err.Error()
+		log.Error(err, "Failed to get external resources")
+		result.fail(getFailureReason(err, RrsetReasonSynchronizationFailed), err.Error())
+		return nil
 	}
-	if changed {
-		lastUpdateTime = &metav1.Time{Time: time.Now().UTC()}
+	if owner := getOwnershipConflict(externalRecord, opts.ClusterID); owner != "" {
+		result.fail(RrsetReasonOwnershipConflict, RrsetMessageOwnershipConflict+" "+owner)
+		return nil
 	}
-
-	// Set OwnerReference
-	if err := ownObject(ctx, zone, gr, scheme, cl, log); err != nil {
-		if errors.IsConflict(err) {
-			log.Info("Conflict on RRSet owner reference, retrying")
-			return ctrl.Result{Requeue: true}, nil
-		}
-		log.Error(err, "Failed to set owner reference")
-		return ctrl.Result{}, err
+	if checkUnmanaged && hasUnmanagedRecords(applied, externalRecord) {
+		result.fail(RrsetReasonUnmanagedRecords, RrsetMessageUnmanagedRecords+" "+strings.Join(recordsContent(*externalRecord), ", "))
+		return nil
 	}
+	result.status = ptr.To(PENDING_STATUS)
+	result.conditionStatus = metav1.ConditionFalse
+	result.reason = hold.reason
+	result.message = hold.message
+	return rrsetPendingChanges(applied, externalRecord, opts.DefaultTTL, opts.ClusterID)
+}
 
+// rrsetStatusReconcile report the result of the synchronization in the status of the RRset, with the state applied
+// to PowerDNS and its revision on success
+func rrsetStatusReconcile(ctx context.Context, gr dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, applied dnsv1alpha2.GenericRRset, appliedHash string, appliedZoneSerial *uint32, pendingChanges []string, result *syncResult, lastUpdateTime *metav1.Time, cl client.Client, log logr.Logger) error {
 	// This Patch is very important:
 	// When an update on RRSet is applied, a reconcile event is triggered on Zone
 	// But, sometimes, Zone reonciliation finish before RRSet update is applied
 	// In that case, the Serial in Zone Status is false
 	// This update permits triggering a new event after RRSet update applied
 	original := gr.Copy()
-	if syncStatus == nil {
-		syncStatus = ptr.To(SUCCEEDED_STATUS)
-	}
 	conditions := gr.GetStatus().Conditions
-	meta.SetStatusCondition(&conditions, metav1.Condition{
+	available := metav1.Condition{
 		Type:               "Available",
 		LastTransitionTime: *lastUpdateTime,
-		Status:             conditionStatus,
-		Reason:             conditionReason,
-		Message:            conditionMessage,
-	})
+		Status:             result.conditionStatus,
+		Reason:             result.reason,
+		Message:            result.message,
+	}
+	meta.SetStatusCondition(&conditions, available)
+	setStalledCondition(&conditions, available)
 	name := getRRsetName(gr)
-	gr.SetStatus(dnsv1alpha2.RRsetStatus{
+	status := dnsv1alpha2.RRsetStatus{
 		LastUpdateTime:     lastUpdateTime,
 		DnsEntryName:       &name,
-		SyncStatus:         syncStatus,
+		SyncStatus:         result.status,
 		ObservedGeneration: &gr.GetObjectMeta().Generation,
-	})
-	if err := cl.Status().Patch(ctx, gr, client.MergeFrom(original)); err != nil {
+		Conditions:         conditions,
+		PendingChanges:     pendingChanges,
+		Revisions:          gr.GetStatus().Revisions,
+		// A transient failure is retried with the request
+		LastHandledReconcileAt: getHandledReconcileRequest(gr, gr.GetStatus().LastHandledReconcileAt, result.failed() && isTransientFailureReason(result.reason)),
+	}
+	succeeded := *result.status == SUCCEEDED_STATUS
+	if succeeded {
+		status.AppliedHash = &appliedHash
+		// The serial read after the apply, the serial of the zone status if PowerDNS was not modified
+		status.AppliedZoneSerial = cmp.Or(appliedZoneSerial, zone.GetStatus().Serial)
+		if !isAbsentRRset(applied) {
+			status.Revisions = appendRRsetRevision(status.Revisions, applied, *lastUpdateTime)
+		}
+	} else {
+		// Kept to remember the RRset was already applied
+		status.AppliedHash = gr.GetStatus().AppliedHash
+		status.AppliedZoneSerial = gr.GetStatus().AppliedZoneSerial
+	}
+	gr.SetStatus(status)
+	if err := commitRrsetStatus(ctx, gr, original, cl); err != nil {
 		log.Error(err, "unable to patch RRSet status")
-		return ctrl.Result{}, err
+		return err
 	}
 
-	// Metrics calculation
-	updateRrsetsMetrics(getRRsetName(gr), gr)
+	// The RRsets already applied, PowerDNS not queried, keep the time of their last synchronization
+	if succeeded && result.synced {
+		updateRrsetsLastSyncMetrics(gr)
+	}
+	return nil
+}
 
-	return ctrl.Result{}, nil
+// getRRsetRequeueResult return when the RRset is reconciled again: on transient failures, when the next maintenance
+// window opens, and when it is published or removed
+func getRRsetRequeueResult(gr dnsv1alpha2.GenericRRset, result *syncResult, nextWindow time.Time, now time.Time, log logr.Logger) ctrl.Result {
+	// The rate limiter of the controller backs off exponentially between the retries
+	if result.failed() && isTransientFailureReason(result.reason) {
+		log.Info("Transient synchronization failure, retrying", "reason", result.reason)
+		return ctrl.Result{Requeue: true}
+	}
+	if result.reason == RrsetReasonPendingWindow {
+		return ctrl.Result{RequeueAfter: max(time.Until(nextWindow), time.Second)}
+	}
+	// The RRset is reconciled again when it is published, or removed
+	if next := getNextActivityChange(gr, now); !next.IsZero() {
+		return ctrl.Result{RequeueAfter: max(time.Until(next), time.Second)}
+	}
+	return ctrl.Result{}
 }
 
 func getZoneExternalResources(ctx context.Context, domain string, PDNSClient PdnsClienter, log logr.Logger) (*powerdns.Zone, error) {
@@ -366,7 +1297,9 @@ func createZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZo
 		DNSsec:      ptr.To(false),
 		SOAEditAPI:  zone.GetSpec().SOAEditAPI,
 		Nameservers: zone.GetSpec().Nameservers,
+		Masters:     zone.GetSpec().Masters,
 		Catalog:     catalog,
+		Presigned:   zone.GetSpec().Presigned,
 	}
 
 	_, err := PDNSClient.Zones.Add(ctx, &z)
@@ -391,8 +1324,10 @@ func updateZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZo
 		Name:        &zone.GetObjectMeta().Name,
 		Kind:        &zoneKind,
 		Nameservers: zone.GetSpec().Nameservers,
+		Masters:     zone.GetSpec().Masters,
 		Catalog:     catalog,
 		SOAEditAPI:  zone.GetSpec().SOAEditAPI,
+		Presigned:   ptr.To(ptr.Deref(zone.GetSpec().Presigned, false)),
 	})
 	if err != nil {
 		log.Error(err, "Failed to update zone")
@@ -425,28 +1360,154 @@ func deleteZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZo
 	return nil
 }
 
-func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone, gz dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) (*string, string, string, metav1.ConditionStatus, error) {
-	// Initialization
-	var syncStatus *string
-	conditionStatus := metav1.ConditionTrue
-	conditionReason := ZoneReasonSynced
-	conditionMessage := ZoneMessageSyncSucceeded
+func retransferZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) error {
+	result, err := PDNSClient.Zones.AxfrRetrieve(ctx, zone.GetObjectMeta().Name)
+	if err != nil {
+		log.Error(err, "Failed to retrieve zone from primaries")
+		return err
+	}
+	log.Info("Zone retransfer requested", "result", ptr.Deref(result.Result, ""))
+	return nil
+}
+
+func purgeZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, clusterID string, PDNSClient PdnsClienter, log logr.Logger) error {
+	externalZone, err := PDNSClient.Zones.Get(ctx, zone.GetObjectMeta().Name)
+	if err != nil {
+		log.Error(err, "Failed to get zone")
+		return err
+	}
+	purged := getPurgedRRsets(externalZone, clusterID)
+	if len(purged.Sets) == 0 {
+		return nil
+	}
+	if err := PDNSClient.Records.Patch(ctx, zone.GetObjectMeta().Name, purged); err != nil {
+		log.Error(err, "Failed to purge zone")
+		return err
+	}
+	log.Info("Zone purged", "rrsets", len(purged.Sets))
+	return nil
+}
+
+func cloneZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	cloneFrom := zone.GetSpec().CloneFrom
+	source, err := getZone(ctx, cloneFrom.ZoneRef, zone.GetNamespace(), cl)
+	if client.IgnoreNotFound(err) != nil {
+		log.Error(err, "Failed to get source zone")
+		return err
+	}
+	if err != nil || ptr.Deref(source.GetStatus().SyncStatus, "") != SUCCEEDED_STATUS {
+		return &cloneSourceError{source: getZoneRefIndexKey(cloneFrom.ZoneRef)}
+	}
+	sourceZone, err := PDNSClient.Zones.Get(ctx, source.GetName())
+	if err != nil {
+		log.Error(err, "Failed to get source zone")
+		return err
+	}
+	rrsets := getClonedRRsets(sourceZone, source.GetName(), zone.GetName())
+	if len(rrsets) != 0 {
+		if err := PDNSClient.Records.Patch(ctx, zone.GetName(), &powerdns.RRsets{Sets: rrsets}); err != nil {
+			log.Error(err, "Failed to clone zone")
+			return err
+		}
+	}
+	log.Info("Zone cloned", "source", getZoneRefIndexKey(cloneFrom.ZoneRef), "rrsets", len(rrsets))
+	if ptr.Deref(cloneFrom.RegenerateRRsets, false) {
+		return regenerateRRsets(ctx, zone.GetNamespace(), getZoneRef(zone), rrsets, cl, log)
+	}
+	return nil
+}
+
+func zoneDescriptionReconcile(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) error {
+	externalDescription, err := PDNSClient.Metadata.Get(ctx, zone.GetObjectMeta().Name, ZONE_DESCRIPTION_METADATA)
+	if err != nil {
+		log.Error(err, "Failed to get zone description")
+		return err
+	}
+	if descriptionIsIdenticalToExternalMetadata(zone, externalDescription) {
+		return nil
+	}
+	if zone.GetSpec().Description == nil {
+		if err := PDNSClient.Metadata.Delete(ctx, zone.GetObjectMeta().Name, ZONE_DESCRIPTION_METADATA); err != nil {
+			log.Error(err, "Failed to remove zone description")
+			return err
+		}
+		log.Info("Zone description removed")
+		return nil
+	}
+	if _, err := PDNSClient.Metadata.Set(ctx, zone.GetObjectMeta().Name, ZONE_DESCRIPTION_METADATA, []string{*zone.GetSpec().Description}); err != nil {
+		log.Error(err, "Failed to update zone description")
+		return err
+	}
+	log.Info("Zone description updated")
+	return nil
+}
+
+// zoneFileReconcile synchronize the records of the zone file of the zone in PowerDNS, in a single request.
+// The records written from the zone file are deleted when removed from it.
+func zoneFileReconcile(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneFile *string, zoneRes *powerdns.Zone, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	if zoneFile == nil && !slices.ContainsFunc(zoneRes.RRsets, isSynchronizedFromZoneFile) {
+		return nil
+	}
+	desired := []powerdns.RRset{}
+	if zoneFile != nil {
+		var err error
+		if desired, err = parseBINDZoneFile(zone.GetName(), *zoneFile); err != nil {
+			return &zoneFileError{err: err}
+		}
+	}
+	// The zone may have been modified since (purge, cloning)
+	externalZone, err := getZoneExternalResources(ctx, zone.GetName(), PDNSClient, log)
+	if err != nil {
+		return err
+	}
+	// The records managed by RRset resources are left to them
+	rrsets, err := getReferencingRRsets(ctx, zone, cl)
+	if err != nil {
+		return err
+	}
+	managed := make([]string, 0, len(rrsets))
+	for _, rrset := range rrsets {
+		managed = append(managed, strings.ToLower(getRRsetName(rrset))+"/"+strings.ToUpper(rrset.GetSpec().Type))
+	}
+	changes := getZoneFileChanges(zone.GetName(), externalZone.RRsets, desired, managed)
+	if len(changes.Sets) == 0 {
+		return nil
+	}
+	if err := PDNSClient.Records.Patch(ctx, zone.GetName(), changes); err != nil {
+		log.Error(err, "Failed to synchronize zone file")
+		return err
+	}
+	log.Info("Zone file synchronized", "changes", len(changes.Sets))
+	return nil
+}
+
+func exportZoneExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) (string, error) {
+	export, err := PDNSClient.Zones.Export(ctx, zone.GetObjectMeta().Name)
+	if err != nil {
+		log.Error(err, "Failed to export zone")
+		return "", err
+	}
+	return string(export), nil
+}
+
+func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone, gz dnsv1alpha2.GenericZone, dryRun bool, PDNSClient PdnsClienter, log logr.Logger) (syncResult, error) {
+	result := syncResult{conditionStatus: metav1.ConditionTrue, reason: ZoneReasonSynced, message: ZoneMessageSyncSucceeded}
 
-	if zoneRes.Name == nil {
+	if zoneRes.Name == nil && dryRun {
+		log.Info("Dry-run mode, zone is not created")
+		result.conditionStatus, result.reason, result.message = metav1.ConditionFalse, ZoneReasonDryRun, ZoneMessageDryRunNotCreated
+	} else if zoneRes.Name == nil {
 		// If Zone does not exist, create it
 		err := createZoneExternalResources(ctx, gz, PDNSClient, log)
 		if err != nil {
 			log.Error(err, "Failed to create external resources")
-			syncStatus = ptr.To(FAILED_STATUS)
-			conditionStatus = metav1.ConditionFalse
-			conditionReason = ZoneReasonSynchronizationFailed
-			conditionMessage = err.Error()
+			result.fail(getFailureReason(err, ZoneReasonSynchronizationFailed), err.Error())
 		}
 	} else {
 		// If Zone exists, compare content and update it if necessary
 		ns, err := PDNSClient.Records.Get(ctx, gz.GetObjectMeta().Name, gz.GetObjectMeta().Name, ptr.To(powerdns.RRTypeNS))
 		if err != nil {
-			return nil, "", "", "", err
+			return result, err
 		}
 
 		// An issue exist on GET API Calls, comments for another RRSet are included although we filter
@@ -467,41 +1528,272 @@ func zoneExternalResourcesReconcile(ctx context.Context, zoneRes *powerdns.Zone,
 		// Nameservers changes  => patch RRSet
 		// Other changes        => patch Zone
 		zoneIdentical, nsIdentical := zoneIsIdenticalToExternalZone(gz, zoneRes, nameservers)
+		if (!zoneIdentical || !nsIdentical) && isFrozenZone(gz) {
+			// The changes of the zone, as the ones of its records, are held until unfrozen
+			log.Info("Frozen zone, zone changes are not applied")
+			result.reason, result.message = ZoneReasonFrozen, ZoneMessageFrozen
+			return result, nil
+		}
+		if (!zoneIdentical || !nsIdentical) && dryRun {
+			log.Info("Dry-run mode, zone changes are not applied")
+			result.reason, result.message = ZoneReasonDryRun, ZoneMessageDryRun
+			return result, nil
+		}
 
-		// Nameservers changes
-		if !nsIdentical {
+		// Nameservers changes, the NS records of secondary zones are retrieved from their primaries
+		if !nsIdentical && !isSecondaryZone(gz) {
 			ttl := ptr.To(DEFAULT_TTL_FOR_NS_RECORDS)
 			if filteredRRset.TTL != nil {
 				ttl = filteredRRset.TTL
 			}
 			err := updateNsOnZoneExternalResources(ctx, gz, *ttl, PDNSClient, log)
 			if err != nil {
-				syncStatus = ptr.To(FAILED_STATUS)
-				conditionStatus = metav1.ConditionFalse
-				conditionReason = ZoneReasonNSSynchronizationFailed
-				conditionMessage = err.Error()
+				result.fail(ZoneReasonNSSynchronizationFailed, err.Error())
 			}
 		}
 		// Other changes
 		if !zoneIdentical {
 			err := updateZoneExternalResources(ctx, gz, PDNSClient, log)
 			if err != nil {
-				syncStatus = ptr.To(FAILED_STATUS)
-				conditionStatus = metav1.ConditionFalse
-				conditionReason = ZoneReasonSynchronizationFailed
-				conditionMessage = err.Error()
+				result.fail(getFailureReason(err, ZoneReasonSynchronizationFailed), err.Error())
+			}
+		}
+	}
+	return result, nil
+}
+
+func getDNSSECKeysExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, PDNSClient PdnsClienter, log logr.Logger) ([]dnsv1alpha2.DNSSECKey, error) {
+	// Presigned zones do not hold any key locally
+	if !ptr.Deref(zoneRes.DNSsec, false) || ptr.Deref(zoneRes.Presigned, false) {
+		return nil, nil
+	}
+
+	cryptokeys, err := PDNSClient.Cryptokeys.List(ctx, zone.GetObjectMeta().Name)
+	if err != nil {
+		log.Error(err, "Failed to list cryptokeys")
+		return nil, err
+	}
+
+	// PowerDNS does not expose keys creation time, so keep track of the first time each key is observed
+	firstSeen := map[uint64]metav1.Time{}
+	for _, k := range zone.GetStatus().DNSSECKeys {
+		firstSeen[k.ID] = k.FirstSeen
+	}
+	now := metav1.NewTime(time.Now().UTC())
+	keys := make([]dnsv1alpha2.DNSSECKey, 0, len(cryptokeys))
+	for _, ck := range cryptokeys {
+		id := ptr.Deref(ck.ID, 0)
+		seen, ok := firstSeen[id]
+		if !ok {
+			seen = now
+		}
+		keys = append(keys, dnsv1alpha2.DNSSECKey{
+			ID:        id,
+			KeyType:   ptr.Deref(ck.KeyType, ""),
+			Active:    ptr.Deref(ck.Active, false),
+			FirstSeen: seen,
+			DS:        ck.DS,
+		})
+	}
+	return keys, nil
+}
+
+// getParentZone return the closest managed Zone/ClusterZone the zone is a child of, or nil if there is none
+func getParentZone(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) (dnsv1alpha2.GenericZone, error) {
+	var zones dnsv1alpha2.ZoneList
+	if err := cl.List(ctx, &zones); err != nil {
+		return nil, err
+	}
+	var clusterZones dnsv1alpha2.ClusterZoneList
+	if err := cl.List(ctx, &clusterZones); err != nil {
+		return nil, err
+	}
+	candidates := make([]dnsv1alpha2.GenericZone, 0, len(zones.Items)+len(clusterZones.Items))
+	for i := range zones.Items {
+		candidates = append(candidates, &zones.Items[i])
+	}
+	for i := range clusterZones.Items {
+		candidates = append(candidates, &clusterZones.Items[i])
+	}
+
+	var parent dnsv1alpha2.GenericZone
+	name := makeCanonical(zone.GetName())
+	for _, c := range candidates {
+		candidateName := makeCanonical(c.GetName())
+		isParent := strings.HasSuffix(name, "."+candidateName)
+		isAvailable := c.GetDeletionTimestamp().IsZero() && ptr.Deref(c.GetStatus().SyncStatus, "") == SUCCEEDED_STATUS
+		if isParent && isAvailable && (parent == nil || len(candidateName) > len(makeCanonical(parent.GetName()))) {
+			parent = c
+		}
+	}
+	return parent, nil
+}
+
+// dsParentZoneReconcile publishes, in the managed parent zone, the DS records matching the active keys of the zone.
+// DS records previously published by the operator are removed when the zone has no more active keys.
+func dsParentZoneReconcile(ctx context.Context, zone dnsv1alpha2.GenericZone, parent dnsv1alpha2.GenericZone, keys []dnsv1alpha2.DNSSECKey, clusterID string, PDNSClient PdnsClienter, log logr.Logger) error {
+	if parent == nil {
+		return nil
+	}
+
+	name := makeCanonical(zone.GetName())
+	records, err := PDNSClient.Records.Get(ctx, parent.GetName(), name, ptr.To(powerdns.RRTypeDS))
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Failed to get DS records in parent zone", "parent", parent.GetName())
+		return err
+	}
+	// An issue exist on GET API Calls, comments for another RRSet are included although we filter
+	// See https://github.com/PowerDNS/pdns/issues/14539
+	var existing powerdns.RRset
+	for _, r := range records {
+		if ptr.Deref(r.Name, "") == name && ptr.Deref(r.Type, "") == powerdns.RRTypeDS {
+			existing = r
+		}
+	}
+
+	ds := dsRecordsFromKeys(keys)
+	if len(ds) == 0 {
+		// Only remove the DS records published by the operator instance for the zone, not the ones of a RRset
+		if existing.Name == nil || !isWrittenByZone(existing, DS_RECORDS_COMMENT, clusterID) {
+			return nil
+		}
+		if err := PDNSClient.Records.Delete(ctx, parent.GetName(), name, powerdns.RRTypeDS); err != nil {
+			log.Error(err, "Failed to delete DS records in parent zone", "parent", parent.GetName())
+			return err
+		}
+		log.Info("DS records removed from parent zone", "parent", parent.GetName())
+		return nil
+	}
+
+	if slices.Equal(ds, recordsContent(existing)) {
+		return nil
+	}
+	if owner := getOwnershipConflict(&existing, clusterID); owner != "" {
+		// The records of another cluster are left untouched
+		log.Info("DS records owned by the operator of another cluster, not modified", "parent", parent.GetName(), "owner", owner)
+		return nil
+	}
+
+	comments := powerdns.WithComments(powerdns.Comment{Content: ptr.To(DS_RECORDS_COMMENT), Account: ptr.To(getOwnerAccount(clusterID))})
+	if err := PDNSClient.Records.Change(ctx, parent.GetName(), name, powerdns.RRTypeDS, DEFAULT_TTL_FOR_DS_RECORDS, ds, comments); err != nil {
+		log.Error(err, "Failed to publish DS records in parent zone", "parent", parent.GetName())
+		return err
+	}
+	log.Info("DS records published in parent zone", "parent", parent.GetName())
+	return nil
+}
+
+// delegationParentZoneReconcile keeps, in the managed parent zone, the NS delegation of the zone and the glue records
+// of its in-bailiwick nameservers. Delegation records previously published by the operator, marked with the
+// delegation comment, are removed when the delegation is disabled.
+func delegationParentZoneReconcile(ctx context.Context, zone dnsv1alpha2.GenericZone, parent dnsv1alpha2.GenericZone, delegate bool, clusterID string, PDNSClient PdnsClienter, log logr.Logger) error {
+	if parent == nil {
+		return nil
+	}
+
+	name := makeCanonical(zone.GetName())
+	desired := map[string]powerdns.RRset{}
+	if delegate {
+		var err error
+		if desired, err = getDesiredDelegation(ctx, zone, PDNSClient, log); err != nil {
+			return err
+		}
+	}
+
+	parentRes, err := PDNSClient.Zones.Get(ctx, parent.GetName())
+	if err != nil {
+		log.Error(err, "Failed to get parent zone", "parent", parent.GetName())
+		return err
+	}
+	existing := map[string]powerdns.RRset{}
+	for _, r := range parentRes.RRsets {
+		rrName, rrType := ptr.Deref(r.Name, ""), ptr.Deref(r.Type, "")
+		isDelegation := rrName == name && rrType == powerdns.RRTypeNS
+		isGlue := isInZone(rrName, name) && (rrType == powerdns.RRTypeA || rrType == powerdns.RRTypeAAAA)
+		if isDelegation || isGlue {
+			existing[rrName+string(rrType)] = r
+		}
+	}
+
+	for key, r := range desired {
+		current, ok := existing[key]
+		if ok && isClusterManagedRRset(current, clusterID) && slices.Equal(recordsContent(current), recordsContent(r)) {
+			continue
+		}
+		if owner := getOwnershipConflict(&current, clusterID); ok && owner != "" {
+			// The records of another cluster are left untouched
+			log.Info("Delegation owned by the operator of another cluster, not modified", "parent", parent.GetName(), "name", *r.Name, "type", *r.Type, "owner", owner)
+			continue
+		}
+		comments := powerdns.WithComments(powerdns.Comment{Content: ptr.To(DELEGATION_COMMENT), Account: ptr.To(getOwnerAccount(clusterID))})
+		if err := PDNSClient.Records.Change(ctx, parent.GetName(), *r.Name, *r.Type, DEFAULT_TTL_FOR_NS_RECORDS, recordsContent(r), comments); err != nil {
+			log.Error(err, "Failed to publish delegation in parent zone", "parent", parent.GetName(), "name", *r.Name, "type", *r.Type)
+			return err
+		}
+		log.Info("Delegation published in parent zone", "parent", parent.GetName(), "name", *r.Name, "type", *r.Type)
+	}
+
+	// Only remove the records written by the delegation of the operator instance, not the glue records of RRsets
+	for key, r := range existing {
+		if _, ok := desired[key]; ok || !isWrittenByZone(r, DELEGATION_COMMENT, clusterID) {
+			continue
+		}
+		if err := PDNSClient.Records.Delete(ctx, parent.GetName(), *r.Name, *r.Type); err != nil {
+			log.Error(err, "Failed to delete delegation in parent zone", "parent", parent.GetName(), "name", *r.Name, "type", *r.Type)
+			return err
+		}
+		log.Info("Delegation removed from parent zone", "parent", parent.GetName(), "name", *r.Name, "type", *r.Type)
+	}
+	return nil
+}
+
+// getDesiredDelegation return the NS delegation of the zone and the glue records of its in-bailiwick nameservers,
+// by name and type
+func getDesiredDelegation(ctx context.Context, zone dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) (map[string]powerdns.RRset, error) {
+	name := makeCanonical(zone.GetName())
+	nameservers := []string{}
+	for _, ns := range zone.GetSpec().Nameservers {
+		nameservers = append(nameservers, makeCanonical(ns))
+	}
+	desired := map[string]powerdns.RRset{
+		name + string(powerdns.RRTypeNS): {Name: ptr.To(name), Type: ptr.To(powerdns.RRTypeNS), Records: toPdnsRecords(nameservers)},
+	}
+
+	// Glue records are only required for nameservers inside the delegated zone
+	for _, ns := range nameservers {
+		if !isInZone(ns, name) {
+			continue
+		}
+		for _, rrType := range []powerdns.RRType{powerdns.RRTypeA, powerdns.RRTypeAAAA} {
+			records, err := PDNSClient.Records.Get(ctx, zone.GetName(), ns, ptr.To(rrType))
+			if err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to get glue records in zone", "nameserver", ns)
+				return nil, err
+			}
+			for _, r := range records {
+				if ptr.Deref(r.Name, "") == ns && ptr.Deref(r.Type, "") == rrType && len(r.Records) > 0 {
+					desired[ns+string(rrType)] = powerdns.RRset{Name: ptr.To(ns), Type: ptr.To(rrType), Records: r.Records}
+				}
 			}
 		}
 	}
-	return syncStatus, conditionMessage, conditionReason, conditionStatus, nil
+	return desired, nil
 }
 
-func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, status *string, cl client.Client, condition metav1.Condition) error {
+func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, dnssecKeys []dnsv1alpha2.DNSSECKey, parentZone *string, transfer *dnsv1alpha2.ZoneTransferStatus, status *string, cl client.Client, condition metav1.Condition, others ...metav1.Condition) error {
 	original := zone.Copy()
 
 	kind := string(ptr.Deref(zoneRes.Kind, ""))
 	conditions := zone.GetStatus().Conditions
 	meta.SetStatusCondition(&conditions, condition)
+	setStalledCondition(&conditions, condition)
+	for _, other := range others {
+		meta.SetStatusCondition(&conditions, other)
+	}
+	// A zone no longer secondary has no transfers
+	if transfer == nil {
+		meta.RemoveStatusCondition(&conditions, "Transferred")
+	}
 	zone.SetStatus(dnsv1alpha2.ZoneStatus{
 		ID:                 zoneRes.ID,
 		Name:               zoneRes.Name,
@@ -509,18 +1801,53 @@ func patchZoneStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes
 		Serial:             zoneRes.Serial,
 		NotifiedSerial:     zoneRes.NotifiedSerial,
 		EditedSerial:       zoneRes.EditedSerial,
+		SOAEditAPI:         zoneRes.SOAEditAPI,
 		Masters:            zoneRes.Masters,
 		DNSsec:             zoneRes.DNSsec,
+		Presigned:          zoneRes.Presigned,
+		DNSSECKeys:         dnssecKeys,
+		ParentZone:         parentZone,
+		Transfer:           transfer,
 		SyncStatus:         status,
 		Catalog:            zoneRes.Catalog,
 		ObservedGeneration: ptr.To(zone.GetGeneration()),
 		Conditions:         conditions,
+		// A transient failure is retried with the request
+		LastHandledReconcileAt: getHandledReconcileRequest(zone, zone.GetStatus().LastHandledReconcileAt,
+			ptr.Deref(status, "") == FAILED_STATUS && isTransientFailureReason(condition.Reason)),
 	})
-	return cl.Status().Patch(ctx, zone, client.MergeFrom(original))
+	return commitZoneStatus(ctx, zone, original, cl)
 }
 
-func deleteRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter, log logr.Logger) error {
-	err := PDNSClient.Records.Delete(ctx, zone.GetObjectMeta().Name, getRRsetName(rrset), powerdns.RRType(rrset.GetSpec().Type))
+func deleteRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, clusterID string, PDNSClient PdnsClienter, log logr.Logger) error {
+	externalRecord, err := getRrsetExternalResources(ctx, zone, rrset, PDNSClient)
+	if err != nil {
+		log.Error(err, "Failed to get record")
+		return err
+	}
+	if externalRecord == nil {
+		return nil
+	}
+	// The records of another cluster are left untouched
+	if owner := getOwnershipConflict(externalRecord, clusterID); owner != "" {
+		log.Info("Records owned by the operator of another cluster, not deleted", "owner", owner)
+		return nil
+	}
+	// With the Patch strategy, only the records of the RRset are removed, the RRset is deleted with its last record
+	if isPatchStrategy(rrset) {
+		remaining := slices.DeleteFunc(recordsContent(*externalRecord), func(r string) bool {
+			return slices.Contains(getRRsetRecords(rrset), r)
+		})
+		if len(remaining) != 0 {
+			err = PDNSClient.Records.Change(ctx, zone.GetObjectMeta().Name, getRRsetName(rrset), powerdns.RRType(rrset.GetSpec().Type), ptr.Deref(externalRecord.TTL, 0), remaining)
+			if err != nil {
+				log.Error(err, "Failed to remove records")
+				return err
+			}
+			return nil
+		}
+	}
+	err = PDNSClient.Records.Delete(ctx, zone.GetObjectMeta().Name, getRRsetName(rrset), powerdns.RRType(rrset.GetSpec().Type))
 	if err != nil {
 		log.Error(err, "Failed to delete record")
 		return err
@@ -529,35 +1856,157 @@ func deleteRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZ
 	return nil
 }
 
-func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter) (bool, error) {
+// deleteMergedRrsetExternalResources remove the records of a RRset using the Merge strategy: the union of the records
+// of the other contributing RRsets is applied, the external RRset is deleted with its last contributor
+func deleteMergedRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, defaultTTL uint32, clusterID string, cl client.Client, PDNSClient PdnsClienter, log logr.Logger) error {
+	contributors, err := getContendingRRsets(ctx, rrset, cl)
+	if err != nil {
+		log.Error(err, "Failed to get contributing RRsets")
+		return err
+	}
+	// The RRset being deleted is the first contender
+	merged := getMergedRRset(rrset, contributors[1:], defaultTTL, time.Now().UTC())
+	if len(merged.GetSpec().Records) == 0 {
+		return deleteRrsetExternalResources(ctx, zone, rrset, clusterID, PDNSClient, log)
+	}
+	if _, err := createOrUpdateRrsetExternalResources(ctx, zone, merged, defaultTTL, clusterID, false, PDNSClient); err != nil {
+		var conflict *ownershipConflictError
+		if stderrors.As(err, &conflict) {
+			log.Info("Records owned by the operator of another cluster, not deleted", "owner", conflict.owner)
+			return nil
+		}
+		log.Error(err, "Failed to remove records")
+		return err
+	}
+	return nil
+}
+
+// getRrsetExternalResources return the external RRset with the same Name and Type as the RRset, nil if it does not exist
+func getRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, PDNSClient PdnsClienter) (*powerdns.RRset, error) {
 	name := getRRsetName(rrset)
 	rrType := powerdns.RRType(rrset.GetSpec().Type)
 	// Looking for a record with same Name and Type
 	records, err := PDNSClient.Records.Get(ctx, zone.GetObjectMeta().Name, name, &rrType)
 	if err != nil && !errors.IsNotFound(err) {
-		return false, err
+		return nil, err
 	}
 	// An issue exist on GET API Calls, comments for another RRSet are included although we filter
 	// See https://github.com/PowerDNS/pdns/issues/14539
 	// See https://github.com/PowerDNS/pdns/pull/14045
-	var filteredRecord powerdns.RRset
 	for _, fr := range records {
 		if *fr.Name == makeCanonical(name) {
-			filteredRecord = fr
-			break
+			return &fr, nil
+		}
+	}
+	return nil, nil
+}
+
+// getConflictingRRsetType return the type of a RRset or ClusterRRset existing with the same name and a type conflicting with the RRset,
+// or an empty string
+func getConflictingRRsetType(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Client) (string, error) {
+	for _, rrType := range rrsetConflictingTypes[gr.GetSpec().Type] {
+		rrsets, clusterRRsets, err := listRRsetEntryOwners(ctx, getEntryIndexKey(getRRsetName(gr), rrType), cl)
+		if err != nil {
+			return "", err
+		}
+		if len(rrsets) > 0 || len(clusterRRsets) > 0 {
+			return rrType, nil
+		}
+	}
+	return "", nil
+}
+
+// getDNAMEConflict return the name of a RRset or ClusterRRset conflicting with a DNAME redirection of the RRset,
+// or with the RRset below a DNAME redirection, or an empty string
+func getDNAMEConflict(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Client) (string, error) {
+	var rrsets []dnsv1alpha2.GenericRRset
+	// The RRsets below a DNAME redirection are RRsets of its zone
+	if gr.GetSpec().Type == string(powerdns.RRTypeDNAME) {
+		zoneRRsets, err := listIndexedRRsets(ctx, "ZoneRef", getZoneRefIndexKey(gr.GetSpec().ZoneRef), cl)
+		if err != nil {
+			return "", err
+		}
+		rrsets = append(rrsets, zoneRRsets...)
+	}
+	// The DNAME redirections above the RRset are found by their owner names
+	for _, ancestor := range getAncestorNames(getRRsetName(gr)) {
+		dnameRRsets, err := listIndexedRRsets(ctx, "DNAME", ancestor, cl)
+		if err != nil {
+			return "", err
+		}
+		rrsets = append(rrsets, dnameRRsets...)
+	}
+	return getDNAMEConflictingRRset(gr, rrsets), nil
+}
+
+// listIndexedRRsets return the RRsets and ClusterRRsets with the key in their RRset.<index> and ClusterRRset.<index> indexes
+func listIndexedRRsets(ctx context.Context, index string, key string, cl client.Client) ([]dnsv1alpha2.GenericRRset, error) {
+	var existingRRsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &existingRRsets, client.MatchingFields{"RRset." + index: key}); err != nil {
+		return nil, err
+	}
+	var existingClusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &existingClusterRRsets, client.MatchingFields{"ClusterRRset." + index: key}); err != nil {
+		return nil, err
+	}
+	rrsets := make([]dnsv1alpha2.GenericRRset, 0, len(existingRRsets.Items)+len(existingClusterRRsets.Items))
+	for i := range existingRRsets.Items {
+		rrsets = append(rrsets, &existingRRsets.Items[i])
+	}
+	for i := range existingClusterRRsets.Items {
+		rrsets = append(rrsets, &existingClusterRRsets.Items[i])
+	}
+	return rrsets, nil
+}
+
+func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, defaultTTL uint32, clusterID string, checkUnmanaged bool, PDNSClient PdnsClienter) (bool, error) {
+	name := getRRsetName(rrset)
+	rrType := powerdns.RRType(rrset.GetSpec().Type)
+	externalRecord, err := getRrsetExternalResources(ctx, zone, rrset, PDNSClient)
+	if err != nil {
+		return false, err
+	}
+	if owner := getOwnershipConflict(externalRecord, clusterID); owner != "" {
+		return false, &ownershipConflictError{owner: owner}
+	}
+	if checkUnmanaged && hasUnmanagedRecords(rrset, externalRecord) {
+		return false, &unmanagedRecordsError{records: recordsContent(*externalRecord)}
+	}
+	// An absent RRset is deleted if found
+	if isAbsentRRset(rrset) {
+		if externalRecord == nil {
+			return false, nil
+		}
+		if err := PDNSClient.Records.Delete(ctx, zone.GetObjectMeta().Name, name, rrType); err != nil {
+			return false, err
 		}
+		return true, nil
 	}
-	if filteredRecord.Name != nil && rrsetIsIdenticalToExternalRRset(rrset, filteredRecord) {
+	if externalRecord != nil && rrsetIsIdenticalToExternalRRset(rrset, *externalRecord, defaultTTL, clusterID) {
 		return false, nil
 	}
 
+	// PowerDNS keeps the existing comments when none are sent: the comment removal requires
+	// to delete the RRset and to create it again, in a single request to avoid any resolution gap
+	comment := getRRsetComment(rrset, clusterID)
+	if comment == nil && externalRecord != nil && len(externalRecord.Comments) != 0 && !isPatchStrategy(rrset) {
+		err = PDNSClient.Records.Patch(ctx, zone.GetObjectMeta().Name, &powerdns.RRsets{Sets: []powerdns.RRset{
+			{Name: ptr.To(name), Type: ptr.To(rrType), ChangeType: ptr.To(powerdns.ChangeTypeDelete)},
+			{Name: ptr.To(name), Type: ptr.To(rrType), ChangeType: ptr.To(powerdns.ChangeTypeReplace), TTL: ptr.To(getRRsetTTL(rrset, defaultTTL)), Records: toPdnsRecords(getRRsetDesiredRecords(rrset, externalRecord))},
+		}})
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
 	// Create or Update
-	operatorAccount := "powerdns-operator"
 	comments := func(*powerdns.RRset) {}
-	if rrset.GetSpec().Comment != nil {
-		comments = powerdns.WithComments(powerdns.Comment{Content: rrset.GetSpec().Comment, Account: &operatorAccount})
+	if comment != nil {
+		comments = powerdns.WithComments(*comment)
 	}
-	err = PDNSClient.Records.Change(ctx, zone.GetObjectMeta().Name, name, rrType, rrset.GetSpec().TTL, rrset.GetSpec().Records, comments)
+	// The records are replaced, with the Patch strategy the records not managed by the RRset are sent back
+	err = PDNSClient.Records.Change(ctx, zone.GetObjectMeta().Name, name, rrType, getRRsetTTL(rrset, defaultTTL), getRRsetDesiredRecords(rrset, externalRecord), comments)
 	if err != nil {
 		return false, err
 	}
@@ -565,6 +2014,42 @@ func createOrUpdateRrsetExternalResources(ctx context.Context, zone dnsv1alpha2.
 	return true, nil
 }
 
+// ownershipConflictError is returned when the external RRset is owned by the operator of another cluster
+type ownershipConflictError struct {
+	owner string
+}
+
+func (e *ownershipConflictError) Error() string {
+	return fmt.Sprintf("records owned by the operator of cluster %s", e.owner)
+}
+
+// unmanagedRecordsError is returned when the external RRset holds records not written by the operator
+type unmanagedRecordsError struct {
+	records []string
+}
+
+func (e *unmanagedRecordsError) Error() string {
+	return fmt.Sprintf("records not written by the operator: %s", strings.Join(e.records, ", "))
+}
+
+// cloneSourceError is returned when the zone to clone is not available (missing or not synchronized)
+type cloneSourceError struct {
+	source string
+}
+
+func (e *cloneSourceError) Error() string {
+	return fmt.Sprintf("source zone %s not available", e.source)
+}
+
+// zoneFileError is returned when the zone file of the zone cannot be parsed
+type zoneFileError struct {
+	err error
+}
+
+func (e *zoneFileError) Error() string {
+	return fmt.Sprintf("invalid zone file: %v", e.err)
+}
+
 func ownObject(ctx context.Context, zone dnsv1alpha2.GenericZone, rrset dnsv1alpha2.GenericRRset, scheme *runtime.Scheme, cl client.Client, log logr.Logger) error {
 	err := ctrl.SetControllerReference(zone, rrset, scheme)
 	if err != nil {
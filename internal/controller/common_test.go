@@ -16,13 +16,16 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/joeig/go-powerdns/v3"
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -34,8 +37,10 @@ var (
 func init() {
 	m = NewMockClient()
 	PDNSClient = PdnsClienter{
-		Records: m.Records,
-		Zones:   m.Zones,
+		Records:    m.Records,
+		Zones:      m.Zones,
+		Cryptokeys: m.Cryptokeys,
+		Metadata:   m.Metadata,
 	}
 }
 
@@ -207,6 +212,80 @@ func TestUpdateExternalResources(t *testing.T) {
 	}
 }
 
+func TestZoneExternalResourcesReconcileFrozen(t *testing.T) {
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	zoneRes, err := PDNSClient.Zones.Get(ctx, "example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "example"},
+		Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns3.example.org"}, Frozen: ptr.To(true)},
+	}
+	result, err := zoneExternalResourcesReconcile(ctx, zoneRes, zone, false, PDNSClient, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.reason != ZoneReasonFrozen || result.failed() {
+		t.Errorf("got reason %s, want %s", result.reason, ZoneReasonFrozen)
+	}
+	if zoneRes, _ = PDNSClient.Zones.Get(ctx, "example.org"); string(ptr.Deref(zoneRes.Kind, "")) != MASTER_KIND_ZONE {
+		t.Errorf("the kind of a frozen zone should not be changed, got %s", ptr.Deref(zoneRes.Kind, ""))
+	}
+}
+
+func TestZoneExternalResourcesReconcileDryRun(t *testing.T) {
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	zoneRes, err := PDNSClient.Zones.Get(ctx, "example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testCases = []struct {
+		description string
+		zoneRes     *powerdns.Zone
+		zoneName    string
+		reason      string
+		status      metav1.ConditionStatus
+	}{
+		{"Zone changes", zoneRes, "example.org", ZoneReasonDryRun, metav1.ConditionTrue},
+		{"Zone missing in PowerDNS", &powerdns.Zone{}, "dryrun.org", ZoneReasonDryRun, metav1.ConditionFalse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.zoneName, Namespace: "example"},
+				Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: []string{"ns3.example.org"}},
+			}
+			result, err := zoneExternalResourcesReconcile(ctx, tc.zoneRes, zone, true, PDNSClient, log)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.reason != tc.reason || result.conditionStatus != tc.status || result.failed() {
+				t.Errorf("got reason %s and status %s, want %s and %s", result.reason, result.conditionStatus, tc.reason, tc.status)
+			}
+		})
+	}
+	if zoneRes, _ = PDNSClient.Zones.Get(ctx, "example.org"); string(ptr.Deref(zoneRes.Kind, "")) != MASTER_KIND_ZONE {
+		t.Errorf("the kind of a zone should not be changed in dry-run mode, got %s", ptr.Deref(zoneRes.Kind, ""))
+	}
+	if zoneRes, _ = PDNSClient.Zones.Get(ctx, "dryrun.org"); zoneRes != nil && zoneRes.Name != nil {
+		t.Error("the zone should not be created in dry-run mode")
+	}
+}
+
 func TestUpdateNsOnExternalResources(t *testing.T) {
 	var (
 		name        = "example.org"
@@ -322,8 +401,8 @@ func TestDeleteRrsetExternalResources(t *testing.T) {
 		rrset       *dnsv1alpha2.RRset
 		e           error
 	}{
-		{"Existing RRset", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: rrsetTTL1, Records: rrsetRecords1, Comment: &rrsetComment1}}, nil},
-		{"Inexisting RRset", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn2, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType2, Name: rrsetName2, TTL: rrsetTTL2, Records: rrsetRecords2, Comment: &rrsetComment2}}, nil},
+		{"Existing RRset", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1, Comment: &rrsetComment1}}, nil},
+		{"Inexisting RRset", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn2, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType2, Name: rrsetName2, TTL: ptr.To(rrsetTTL2), Records: rrsetRecords2, Comment: &rrsetComment2}}, nil},
 	}
 
 	// Mock initialization
@@ -332,7 +411,7 @@ func TestDeleteRrsetExternalResources(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			err := deleteRrsetExternalResources(ctx, tc.genericZone, tc.rrset, PDNSClient, log)
+			err := deleteRrsetExternalResources(ctx, tc.genericZone, tc.rrset, "", PDNSClient, log)
 			if !cmp.Equal(err, tc.e) {
 				t.Errorf("got %v, want %v", err, tc.e)
 			}
@@ -359,6 +438,7 @@ func TestCreateOrUpdateRrsetExternalResources(t *testing.T) {
 		rrsetTTL2     = uint32(1500)
 		rrsetRecords2 = []string{"1.1.1.3", "2.2.2.4"}
 		rrsetComment2 = "What you want"
+		rrsetComment3 = "What you need"
 
 		catalog      = "catalog.org."
 		nameservers1 = []string{"ns1.example1.org", "ns2.example1.org"}
@@ -372,9 +452,12 @@ func TestCreateOrUpdateRrsetExternalResources(t *testing.T) {
 		want        bool
 		e           error
 	}{
-		{"RRset creation", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn2, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType2, Name: rrsetName2, TTL: rrsetTTL2, Records: rrsetRecords2, Comment: &rrsetComment2}}, true, nil},
-		{"RRset update", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: rrsetTTL1, Records: rrsetRecords1, Comment: &rrsetComment1}}, true, nil},
-		{"RRset identical", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: rrsetTTL1, Records: rrsetRecords1, Comment: &rrsetComment1}}, false, nil},
+		{"RRset creation", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn2, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType2, Name: rrsetName2, TTL: ptr.To(rrsetTTL2), Records: rrsetRecords2, Comment: &rrsetComment2}}, true, nil},
+		{"RRset update", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1, Comment: &rrsetComment1}}, true, nil},
+		{"RRset identical", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1, Comment: &rrsetComment1}}, false, nil},
+		{"RRset comment update", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1, Comment: &rrsetComment3}}, true, nil},
+		{"RRset comment removal", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1}}, true, nil},
+		{"RRset identical without comment", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers1, Catalog: &catalog, SOAEditAPI: &soaEditApi}}, &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: rrsetFqdn1, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}, Type: rrsetType1, Name: rrsetName1, TTL: ptr.To(rrsetTTL1), Records: rrsetRecords1}}, false, nil},
 	}
 
 	// Mock initialization
@@ -383,7 +466,7 @@ func TestCreateOrUpdateRrsetExternalResources(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			modified, err := createOrUpdateRrsetExternalResources(ctx, tc.genericZone, tc.rrset, PDNSClient)
+			modified, err := createOrUpdateRrsetExternalResources(ctx, tc.genericZone, tc.rrset, DEFAULT_TTL, "", false, PDNSClient)
 			if !cmp.Equal(modified, tc.want) {
 				t.Errorf("got %v, want %v", modified, tc.want)
 			}
@@ -393,3 +476,362 @@ func TestCreateOrUpdateRrsetExternalResources(t *testing.T) {
 		})
 	}
 }
+
+func TestRetransferZoneExternalResources(t *testing.T) {
+	var (
+		name       = "example.org"
+		namespace  = "example"
+		soaEditApi = "DEFAULT"
+
+		name1 = "example1.org"
+
+		nameservers = []string{"ns1.example.org", "ns2.example.org"}
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	var testCases = []struct {
+		description string
+		genericZone dnsv1alpha2.GenericZone
+		e           error
+	}{
+		{"Existing Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: SLAVE_KIND_ZONE, Nameservers: nameservers, SOAEditAPI: &soaEditApi}}, nil},
+		{"Non-existing Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name1, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: SLAVE_KIND_ZONE, Nameservers: nameservers, SOAEditAPI: &soaEditApi}}, powerdns.Error{StatusCode: 404, Status: "404 Not Found", Message: "Not Found"}},
+		{"communication error", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: FAKE_SITE, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: SLAVE_KIND_ZONE, Nameservers: nameservers, SOAEditAPI: &soaEditApi}}, &powerdns.Error{StatusCode: 500, Status: "500 Internal Server Error", Message: "Internal Server Error"}},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := retransferZoneExternalResources(ctx, tc.genericZone, PDNSClient, log)
+			if !cmp.Equal(err, tc.e) {
+				t.Errorf("got %v, want %v", err, tc.e)
+			}
+		})
+	}
+}
+
+func TestGetDNSSECKeysExternalResources(t *testing.T) {
+	var (
+		name      = "example.org"
+		namespace = "example"
+
+		nameservers = []string{"ns1.example.org", "ns2.example.org"}
+		firstSeen   = metav1.NewTime(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	var testCases = []struct {
+		description string
+		genericZone dnsv1alpha2.GenericZone
+		zoneRes     *powerdns.Zone
+		want        int
+		e           error
+	}{
+		{"Unsigned Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers}}, &powerdns.Zone{Name: &name, DNSsec: ptr.To(false)}, 0, nil},
+		{"Presigned Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: SLAVE_KIND_ZONE, Nameservers: nameservers}}, &powerdns.Zone{Name: &name, DNSsec: ptr.To(true), Presigned: ptr.To(true)}, 0, nil},
+		{"Signed Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers}, Status: dnsv1alpha2.ZoneStatus{DNSSECKeys: []dnsv1alpha2.DNSSECKey{{ID: 1, KeyType: "ksk", Active: true, FirstSeen: firstSeen}}}}, &powerdns.Zone{Name: &name, DNSsec: ptr.To(true)}, 2, nil},
+		{"communication error", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: FAKE_SITE, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: MASTER_KIND_ZONE, Nameservers: nameservers}}, &powerdns.Zone{Name: ptr.To(FAKE_SITE), DNSsec: ptr.To(true)}, 0, &powerdns.Error{StatusCode: 500, Status: "500 Internal Server Error", Message: "Internal Server Error"}},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+	zone, _ := readFromZonesMap(makeCanonical(name))
+	zone.DNSsec = ptr.To(true)
+	writeToZonesMap(makeCanonical(name), zone)
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			keys, err := getDNSSECKeysExternalResources(ctx, tc.genericZone, tc.zoneRes, PDNSClient, log)
+			if !cmp.Equal(len(keys), tc.want) {
+				t.Errorf("got %v, want %v", len(keys), tc.want)
+			}
+			if !cmp.Equal(err, tc.e) {
+				t.Errorf("got %v, want %v", err, tc.e)
+			}
+			// First seen time of already known keys must be kept
+			for _, k := range keys {
+				if k.ID == 1 && !k.FirstSeen.Equal(&firstSeen) {
+					t.Errorf("got %v, want %v", k.FirstSeen, firstSeen)
+				}
+			}
+		})
+	}
+}
+
+func TestDelegationParentZoneReconcile(t *testing.T) {
+	var (
+		name      = "sub.example.org"
+		namespace = "example"
+
+		nameservers = []string{"ns1.sub.example.org", "ns2.example.net"}
+		parent      = &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: namespace}}
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	var testCases = []struct {
+		description string
+		genericZone dnsv1alpha2.GenericZone
+		parent      dnsv1alpha2.GenericZone
+		want        []string
+		e           error
+	}{
+		{"No parent Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: nameservers, Delegate: ptr.To(true)}}, nil, nil, nil},
+		{"Delegated Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: nameservers, Delegate: ptr.To(true)}}, parent, []string{"ns1.sub.example.org.", "ns2.example.net."}, nil},
+		{"communication error", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, Nameservers: nameservers, Delegate: ptr.To(true)}}, &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: FAKE_SITE, Namespace: namespace}}, nil, &powerdns.Error{StatusCode: 500, Status: "500 Internal Server Error", Message: "Internal Server Error"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// Mock initialization
+			teardownTestCase := setupTestCase()
+			defer teardownTestCase()
+
+			err := delegationParentZoneReconcile(ctx, tc.genericZone, tc.parent, true, "", PDNSClient, log)
+			if !cmp.Equal(err, tc.e) {
+				t.Errorf("got %v, want %v", err, tc.e)
+			}
+			var got []string
+			if rrset, ok := readFromRecordsMap(makeCanonical(name)); ok {
+				got = recordsContent(*rrset)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestZoneCloneReconcile(t *testing.T) {
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+	cloneFrom := &dnsv1alpha2.ZoneCloneSource{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}
+	cloned := metav1.Condition{Type: "Cloned", Status: metav1.ConditionTrue, Reason: ZoneReasonCloned}
+	pending := metav1.Condition{Type: "Cloned", Status: metav1.ConditionFalse, Reason: ZoneReasonClonePending}
+
+	var testCases = []struct {
+		description string
+		conditions  []metav1.Condition
+		isNewZone   bool
+		opts        ZoneOptions
+		frozen      bool
+		failed      bool
+		want        *metav1.Condition
+	}{
+		{"Zone existing in PowerDNS", nil, false, ZoneOptions{}, false, false, nil},
+		{"Zone already cloned", []metav1.Condition{cloned}, true, ZoneOptions{}, false, false, nil},
+		{"Dry-run", nil, true, ZoneOptions{DryRun: true}, false, false, &metav1.Condition{Type: "Cloned", Status: metav1.ConditionFalse, Reason: ZoneReasonClonePending, Message: ZoneMessageClonePendingDryRun}},
+		{"Frozen zone", nil, true, ZoneOptions{}, true, false, &metav1.Condition{Type: "Cloned", Status: metav1.ConditionFalse, Reason: ZoneReasonClonePending, Message: ZoneMessageClonePendingFrozen}},
+		{"Pending clone still frozen", []metav1.Condition{pending}, false, ZoneOptions{}, true, false, &metav1.Condition{Type: "Cloned", Status: metav1.ConditionFalse, Reason: ZoneReasonClonePending, Message: ZoneMessageClonePendingFrozen}},
+		{"Failed synchronization", nil, true, ZoneOptions{}, false, true, &metav1.Condition{Type: "Cloned", Status: metav1.ConditionFalse, Reason: ZoneReasonClonePending, Message: ZoneMessageClonePendingFailed}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{Name: "copy.example.org", Namespace: "example"},
+				Spec:       dnsv1alpha2.ZoneSpec{Kind: NATIVE_KIND_ZONE, CloneFrom: cloneFrom, Frozen: ptr.To(tc.frozen)},
+				Status:     dnsv1alpha2.ZoneStatus{Conditions: tc.conditions},
+			}
+			result := &syncResult{}
+			if tc.failed {
+				result.fail(ZoneReasonSynchronizationFailed, "")
+			}
+			got := zoneCloneReconcile(ctx, zone, tc.isNewZone, tc.opts, result, nil, PDNSClient, log)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExportZoneExternalResources(t *testing.T) {
+	var (
+		name      = "example.org"
+		namespace = "example"
+
+		name1 = "example1.org"
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	var testCases = []struct {
+		description string
+		genericZone dnsv1alpha2.GenericZone
+		want        string
+		e           error
+	}{
+		{"Existing Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, "test.example.org.\t1500\tIN\tA\t1.1.1.2", nil},
+		{"Non-existing Zone", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name1, Namespace: namespace}}, "", powerdns.Error{StatusCode: 404, Status: "404 Not Found", Message: "Not Found"}},
+		{"communication error", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: FAKE_SITE, Namespace: namespace}}, "", &powerdns.Error{StatusCode: 500, Status: "500 Internal Server Error", Message: "Internal Server Error"}},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zoneFile, err := exportZoneExternalResources(ctx, tc.genericZone, PDNSClient, log)
+			if !strings.Contains(zoneFile, tc.want) {
+				t.Errorf("got %v, want %v", zoneFile, tc.want)
+			}
+			if !cmp.Equal(err, tc.e) {
+				t.Errorf("got %v, want %v", err, tc.e)
+			}
+		})
+	}
+}
+
+func TestGetRRsetHold(t *testing.T) {
+	var (
+		nextWindow  = time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC)
+		appliedHash = "applied"
+	)
+	rrset := func(appliedHash string, annotations map[string]string) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2, Annotations: annotations},
+			Status:     dnsv1alpha2.RRsetStatus{AppliedHash: ptr.To(appliedHash)},
+		}
+	}
+	zone := func(spec dnsv1alpha2.ZoneSpec) *dnsv1alpha2.Zone {
+		return &dnsv1alpha2.Zone{Spec: spec}
+	}
+
+	var testCases = []struct {
+		description string
+		rrset       *dnsv1alpha2.RRset
+		zone        *dnsv1alpha2.Zone
+		windowOpen  bool
+		dryRun      bool
+		reason      string
+	}{
+		{"Applied", rrset("", nil), zone(dnsv1alpha2.ZoneSpec{}), true, false, ""},
+		{"Dry-run", rrset(appliedHash, nil), zone(dnsv1alpha2.ZoneSpec{}), true, true, RrsetReasonDryRun},
+		{"Dry-run before frozen", rrset("", nil), zone(dnsv1alpha2.ZoneSpec{Frozen: ptr.To(true)}), true, true, RrsetReasonDryRun},
+		{"Frozen zone with pending changes", rrset("", nil), zone(dnsv1alpha2.ZoneSpec{Frozen: ptr.To(true)}), true, false, RrsetReasonZoneFrozen},
+		{"Frozen zone already applied", rrset(appliedHash, nil), zone(dnsv1alpha2.ZoneSpec{Frozen: ptr.To(true)}), true, false, ""},
+		{"Awaiting approval", rrset("", nil), zone(dnsv1alpha2.ZoneSpec{RequireApproval: ptr.To(true)}), true, false, RrsetReasonPendingApproval},
+		{"Approved", rrset("", map[string]string{APPROVED_ANNOTATION: "2"}), zone(dnsv1alpha2.ZoneSpec{RequireApproval: ptr.To(true)}), true, false, ""},
+		{"Outside of the maintenance windows", rrset("", nil), zone(dnsv1alpha2.ZoneSpec{}), false, false, RrsetReasonPendingWindow},
+		{"Outside of the maintenance windows already applied", rrset(appliedHash, nil), zone(dnsv1alpha2.ZoneSpec{}), false, false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var reason string
+			if hold := getRRsetHold(tc.rrset, tc.zone, appliedHash, tc.windowOpen, nextWindow, RRsetOptions{DryRun: tc.dryRun, VerifiedApprovals: true}); hold != nil {
+				reason = hold.reason
+			}
+			if !cmp.Equal(reason, tc.reason) {
+				t.Errorf("got %v, want %v", reason, tc.reason)
+			}
+		})
+	}
+}
+
+func TestDeleteRRsetRecords(t *testing.T) {
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+	windowHold := &rrsetHold{RrsetReasonPendingWindow, RrsetMessagePendingWindow}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	zone := func(frozen bool) *dnsv1alpha2.Zone {
+		return &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Spec: dnsv1alpha2.ZoneSpec{Frozen: ptr.To(frozen)}}
+	}
+	rrset := func(activeUntil *metav1.Time) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "held", Type: "A", Records: []string{"192.0.2.1"}, ActiveUntil: activeUntil, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	}
+	applied := dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS)}
+	until := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	removed := dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: ptr.To(getAppliedHash(getInactiveRRset(rrset(until)), zone(false), 0, ""))}
+	removalHeld := dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: ptr.To(getAppliedHash(rrset(until), zone(false), 0, ""))}
+	var testCases = []struct {
+		description string
+		zone        *dnsv1alpha2.Zone
+		ensure      string
+		activeUntil *metav1.Time
+		status      dnsv1alpha2.RRsetStatus
+		windowHold  *rrsetHold
+		reason      string
+		kept        bool
+	}{
+		{"Deleted", zone(false), "", nil, applied, nil, "", false},
+		{"Frozen zone", zone(true), "", nil, applied, windowHold, RrsetReasonZoneFrozen, true},
+		{"Outside of the maintenance windows", zone(false), "", nil, applied, windowHold, RrsetReasonPendingWindow, true},
+		{"Absent RRset outside of the maintenance windows", zone(false), RRSET_ABSENT_ENSURE, nil, applied, windowHold, "", true},
+		{"Not applied", zone(false), "", nil, dnsv1alpha2.RRsetStatus{}, nil, "", true},
+		{"Removed at activeUntil", zone(false), "", until, removed, nil, "", true},
+		{"Removal at activeUntil held", zone(false), "", until, removalHeld, nil, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			writeToRecordsMap("held.example.org.", &powerdns.RRset{Name: ptr.To("held.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"192.0.2.1"})})
+			rrset := rrset(tc.activeUntil)
+			rrset.Status = tc.status
+			if tc.ensure != "" {
+				rrset.Spec.Ensure = ptr.To(tc.ensure)
+			}
+			hold, err := deleteRRsetRecords(ctx, rrset, tc.zone, tc.windowHold, RRsetOptions{}, nil, PDNSClient, log)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var reason string
+			if hold != nil {
+				reason = hold.reason
+			}
+			if reason != tc.reason {
+				t.Errorf("got reason %v, want %v", reason, tc.reason)
+			}
+			if _, kept := readFromRecordsMap("held.example.org."); kept != tc.kept {
+				t.Errorf("got records kept %v, want %v", kept, tc.kept)
+			}
+		})
+	}
+	deleteFromRecordsMap("held.example.org.")
+}
+
+func TestRrsetExternalResourcesReconcileSynced(t *testing.T) {
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Status: dnsv1alpha2.ZoneStatus{Serial: ptr.To(uint32(2025010101))}}
+	rrset := func(status dnsv1alpha2.RRsetStatus) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "synced", Type: "A", Records: []string{"192.0.2.1"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}, Status: status}
+	}
+	appliedHash := getAppliedHash(rrset(dnsv1alpha2.RRsetStatus{}), zone, DEFAULT_TTL, "")
+	var testCases = []struct {
+		description string
+		status      dnsv1alpha2.RRsetStatus
+		synced      bool
+	}{
+		{"Applied", dnsv1alpha2.RRsetStatus{}, true},
+		{"Already applied", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: ptr.To(appliedHash), AppliedZoneSerial: ptr.To(uint32(2025010101))}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			gr := rrset(tc.status)
+			result := syncResult{conditionStatus: metav1.ConditionTrue, reason: RrsetReasonSynced, message: RrsetMessageSyncSucceeded}
+			rrsetExternalResourcesReconcile(ctx, gr, zone, gr, appliedHash, nil, false, false, RRsetOptions{DefaultTTL: DEFAULT_TTL}, &result, PDNSClient, log)
+			if result.failed() || result.synced != tc.synced {
+				t.Errorf("got synced %v (%v), want %v", result.synced, result.message, tc.synced)
+			}
+		})
+	}
+	deleteFromRecordsMap("synced.example.org.")
+}
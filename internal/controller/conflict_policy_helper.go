@@ -0,0 +1,157 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// Policies resolving the conflicts between RRsets and ClusterRRsets claiming the same name and type
+const (
+	// CONFLICT_POLICY_FAIL keeps the first RRset synchronized, the others are Failed until it is deleted
+	CONFLICT_POLICY_FAIL = "fail"
+	// CONFLICT_POLICY_OLDEST_WINS gives the name and type to the oldest RRset
+	CONFLICT_POLICY_OLDEST_WINS = "oldest-wins"
+	// CONFLICT_POLICY_PRIORITY gives the name and type to the RRset with the highest priority, then to the oldest one
+	CONFLICT_POLICY_PRIORITY = "priority"
+)
+
+// CONFLICT_POLICIES are the supported conflict policies
+var CONFLICT_POLICIES = []string{CONFLICT_POLICY_FAIL, CONFLICT_POLICY_OLDEST_WINS, CONFLICT_POLICY_PRIORITY}
+
+// isResolvingConflicts return True if the conflicts are resolved by electing a winner, instead of failing the newcomers
+func isResolvingConflicts(conflictPolicy string) bool {
+	return conflictPolicy == CONFLICT_POLICY_OLDEST_WINS || conflictPolicy == CONFLICT_POLICY_PRIORITY
+}
+
+// getContendingRRsets return the RRsets and ClusterRRsets, not being deleted, claiming the name and type of the RRset,
+// the RRset included
+func getContendingRRsets(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Client) ([]dnsv1alpha2.GenericRRset, error) {
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets, client.MatchingFields{"RRset.Entry.Name": getRRsetEntryIndexKey(gr)}); err != nil {
+		return nil, err
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets, client.MatchingFields{"ClusterRRset.Entry.Name": getRRsetEntryIndexKey(gr)}); err != nil {
+		return nil, err
+	}
+	all := make([]dnsv1alpha2.GenericRRset, 0, len(rrsets.Items)+len(clusterRRsets.Items))
+	for i := range rrsets.Items {
+		all = append(all, &rrsets.Items[i])
+	}
+	for i := range clusterRRsets.Items {
+		all = append(all, &clusterRRsets.Items[i])
+	}
+
+	contenders := []dnsv1alpha2.GenericRRset{gr}
+	for _, rrset := range all {
+		if rrset.GetUID() != gr.GetUID() && rrset.GetDeletionTimestamp().IsZero() && isContending(rrset, gr) {
+			contenders = append(contenders, rrset)
+		}
+	}
+	return contenders, nil
+}
+
+// hasConflictSuccessor return True if, with a policy resolving the conflicts, another active RRset claims the name
+// and type of the deleted RRset, without sharing them: it takes the records over, they are not removed from PowerDNS
+func hasConflictSuccessor(ctx context.Context, gr dnsv1alpha2.GenericRRset, conflictPolicy string, cl client.Client) (bool, error) {
+	if !isResolvingConflicts(conflictPolicy) {
+		return false, nil
+	}
+	contenders, err := getContendingRRsets(ctx, gr, cl)
+	if err != nil {
+		return false, err
+	}
+	// The RRsets sharing the name and type each manage their own records, there is no winner
+	now := time.Now().UTC()
+	claiming := slices.DeleteFunc(contenders, func(rrset dnsv1alpha2.GenericRRset) bool {
+		return rrset.GetUID() != gr.GetUID() && (!isActiveRRset(rrset, now) || isAbsentRRset(rrset))
+	})
+	return len(claiming) > 1 && !areSharedRRsets(claiming), nil
+}
+
+// isContending return True if both RRsets claim the same name and type
+func isContending(a, b dnsv1alpha2.GenericRRset) bool {
+	return a.GetSpec().Type == b.GetSpec().Type && strings.EqualFold(getRRsetName(a), getRRsetName(b))
+}
+
+// getConflictWinner return the RRset owning the name and type among the contending RRsets, according to the policy:
+// the highest priority first with the priority policy, then the oldest. Ties are broken by namespace and name.
+func getConflictWinner(contenders []dnsv1alpha2.GenericRRset, conflictPolicy string) dnsv1alpha2.GenericRRset {
+	return slices.MinFunc(contenders, func(a, b dnsv1alpha2.GenericRRset) int {
+		if conflictPolicy == CONFLICT_POLICY_PRIORITY {
+			if pa, pb := ptr.Deref(a.GetSpec().Priority, 0), ptr.Deref(b.GetSpec().Priority, 0); pa != pb {
+				return int(pb) - int(pa)
+			}
+		}
+		if c := a.GetCreationTimestamp().Compare(b.GetCreationTimestamp().Time); c != 0 {
+			return c
+		}
+		return strings.Compare(getContenderKey(a), getContenderKey(b))
+	})
+}
+
+// getContenderKey return the key of a RRset ("namespace/name") or of a ClusterRRset ("name")
+func getContenderKey(rrset dnsv1alpha2.GenericRRset) string {
+	if rrset.GetNamespace() == "" {
+		return rrset.GetName()
+	}
+	return rrset.GetNamespace() + "/" + rrset.GetName()
+}
+
+// enqueueContendingRRsets return an event handler enqueuing, on the changes of a RRset or a ClusterRRset, the RRsets
+// (or the ClusterRRsets, with cluster) claiming the same name and type: they take over the name of a deleted winner,
+// or give it up to a new winner
+func enqueueContendingRRsets(cl client.Client, cluster bool) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		changed, ok := obj.(dnsv1alpha2.GenericRRset)
+		if !ok {
+			return nil
+		}
+		var candidates []dnsv1alpha2.GenericRRset
+		if cluster {
+			var list dnsv1alpha2.ClusterRRsetList
+			if err := cl.List(ctx, &list, client.MatchingFields{"ClusterRRset.Entry.Name": getRRsetEntryIndexKey(changed)}); err != nil {
+				return nil
+			}
+			for i := range list.Items {
+				candidates = append(candidates, &list.Items[i])
+			}
+		} else {
+			var list dnsv1alpha2.RRsetList
+			if err := cl.List(ctx, &list, client.MatchingFields{"RRset.Entry.Name": getRRsetEntryIndexKey(changed)}); err != nil {
+				return nil
+			}
+			for i := range list.Items {
+				candidates = append(candidates, &list.Items[i])
+			}
+		}
+		requests := []reconcile.Request{}
+		for _, candidate := range candidates {
+			if candidate.GetUID() != changed.GetUID() && isContending(candidate, changed) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: candidate.GetNamespace(), Name: candidate.GetName()}})
+			}
+		}
+		return requests
+	})
+}
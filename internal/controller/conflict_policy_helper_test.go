@@ -0,0 +1,84 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetConflictWinner(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldest := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "team-b", CreationTimestamp: metav1.NewTime(created)},
+		Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+	}
+	sameAge := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "team-a", CreationTimestamp: metav1.NewTime(created)},
+		Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+	}
+	prioritized := &dnsv1alpha2.ClusterRRset{
+		ObjectMeta: metav1.ObjectMeta{Name: "www", CreationTimestamp: metav1.NewTime(created.Add(time.Hour))},
+		Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Priority: ptr.To(int32(10)), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}},
+	}
+
+	var testCases = []struct {
+		description string
+		contenders  []dnsv1alpha2.GenericRRset
+		policy      string
+		want        string
+	}{
+		{"Oldest wins", []dnsv1alpha2.GenericRRset{prioritized, oldest}, CONFLICT_POLICY_OLDEST_WINS, "team-b/www"},
+		{"Same age, first key wins", []dnsv1alpha2.GenericRRset{oldest, sameAge}, CONFLICT_POLICY_OLDEST_WINS, "team-a/www"},
+		{"Highest priority wins", []dnsv1alpha2.GenericRRset{oldest, prioritized}, CONFLICT_POLICY_PRIORITY, "www"},
+		{"Same priority, oldest wins", []dnsv1alpha2.GenericRRset{oldest, sameAge}, CONFLICT_POLICY_PRIORITY, "team-a/www"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			winner := getContenderKey(getConflictWinner(tc.contenders, tc.policy))
+			if winner != tc.want {
+				t.Errorf("got %v, want %v", winner, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsContending(t *testing.T) {
+	rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	var testCases = []struct {
+		description string
+		other       dnsv1alpha2.GenericRRset
+		want        bool
+	}{
+		{"Same name and type", &dnsv1alpha2.ClusterRRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "WWW.example.org.", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}}}, true},
+		{"Other type", &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "AAAA", Name: "www", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}, false},
+		{"Other name", &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "web", ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isContending(rrset, tc.other); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			// The contenders are found through their index key
+			if got := getRRsetEntryIndexKey(rrset) == getRRsetEntryIndexKey(tc.other); got != tc.want {
+				t.Errorf("got index keys %s and %s", getRRsetEntryIndexKey(rrset), getRRsetEntryIndexKey(tc.other))
+			}
+		})
+	}
+}
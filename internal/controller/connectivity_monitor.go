@@ -0,0 +1,131 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// DEFAULT_PDNS_HEALTH_CHECK_INTERVAL is the default interval between the probes of the PowerDNS API
+const DEFAULT_PDNS_HEALTH_CHECK_INTERVAL = 30 * time.Second
+
+type pdnsServersClienter interface {
+	Get(ctx context.Context, vHost string) (*powerdns.Server, error)
+}
+
+// ConnectivityMonitor probes the PowerDNS API periodically. When the API comes back after an outage, the resources
+// whose synchronization failed because it was unavailable are reconciled at once, rather than on their backoff.
+type ConnectivityMonitor struct {
+	Client  client.Client
+	Servers pdnsServersClienter
+	Vhost   string
+	// Interval between the probes of the PowerDNS API
+	Interval time.Duration
+
+	watched []monitoredKind
+}
+
+// monitoredKind is a kind of resource whose failed resources are enqueued through the channel on recovery
+type monitoredKind struct {
+	list    client.ObjectList
+	channel chan event.GenericEvent
+}
+
+// Source return the source of a controller, enqueuing the resources of the list failed during the outage on recovery,
+// or nil if the monitor is nil (disabled). It must be called before the monitor is started.
+func (m *ConnectivityMonitor) Source(list client.ObjectList) source.Source {
+	if m == nil {
+		return nil
+	}
+	channel := make(chan event.GenericEvent)
+	m.watched = append(m.watched, monitoredKind{list: list, channel: channel})
+	return source.Channel(channel, &handler.EnqueueRequestForObject{})
+}
+
+// Start probes the PowerDNS API until the context is done
+func (m *ConnectivityMonitor) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("connectivity-monitor")
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+	available := true
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, m.Interval)
+		_, err := m.Servers.Get(probeCtx, m.Vhost)
+		cancel()
+		switch {
+		case err != nil && available:
+			log.Error(err, "PowerDNS API unavailable")
+			available = false
+		case err == nil && !available:
+			log.Info("PowerDNS API available again, resynchronizing the failed resources")
+			available = true
+			m.resync(ctx, log)
+		}
+	}
+}
+
+// resync enqueue the resources whose synchronization failed because the PowerDNS API was unavailable
+func (m *ConnectivityMonitor) resync(ctx context.Context, log logr.Logger) {
+	for _, watched := range m.watched {
+		list := watched.list.DeepCopyObject().(client.ObjectList)
+		if err := m.Client.List(ctx, list); err != nil {
+			log.Error(err, "unable to list the resources to resynchronize")
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			continue
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !isFailedOnUnavailableServer(obj) {
+				continue
+			}
+			select {
+			case watched.channel <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// isFailedOnUnavailableServer return True if the last synchronization of the resource failed because the PowerDNS API was unavailable
+func isFailedOnUnavailableServer(obj client.Object) bool {
+	var conditions []metav1.Condition
+	switch o := obj.(type) {
+	case dnsv1alpha2.GenericZone:
+		conditions = o.GetStatus().Conditions
+	case dnsv1alpha2.GenericRRset:
+		conditions = o.GetStatus().Conditions
+	}
+	condition := meta.FindStatusCondition(conditions, "Available")
+	return condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == FailureReasonServerUnavailable
+}
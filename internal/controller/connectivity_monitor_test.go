@@ -0,0 +1,44 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsFailedOnUnavailableServer(t *testing.T) {
+	unavailable := []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse, Reason: FailureReasonServerUnavailable}}
+	var testCases = []struct {
+		description string
+		obj         client.Object
+		want        bool
+	}{
+		{"Zone failed on unavailable server", &dnsv1alpha2.Zone{Status: dnsv1alpha2.ZoneStatus{Conditions: unavailable}}, true},
+		{"ClusterRRset failed on unavailable server", &dnsv1alpha2.ClusterRRset{Status: dnsv1alpha2.RRsetStatus{Conditions: unavailable}}, true},
+		{"RRset failed on other reason", &dnsv1alpha2.RRset{Status: dnsv1alpha2.RRsetStatus{Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse, Reason: FailureReasonZoneMissing}}}}, false},
+		{"ClusterZone available", &dnsv1alpha2.ClusterZone{Status: dnsv1alpha2.ZoneStatus{Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, Reason: ZoneReasonSynced}}}}, false},
+		{"RRset never reconciled", &dnsv1alpha2.RRset{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isFailedOnUnavailableServer(tc.obj); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	DomainVerificationReasonGenerationFailed = "GenerationFailed"
+	DomainVerificationReasonGenerated        = "RRsetsGenerated"
+	DomainVerificationReasonExpired          = "Expired"
+	DomainVerificationMessageGenerated       = "Verification RRset generated"
+	DomainVerificationMessageExpired         = "Verification RRset removed on expiry"
+)
+
+// DomainVerificationReconciler reconciles a DomainVerification object
+type DomainVerificationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=domainverifications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=domainverifications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=domainverifications/finalizers,verbs=update
+
+func (r *DomainVerificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile DomainVerification", "DomainVerification.Name", req.Name)
+
+	// DomainVerification
+	dv := &dnsv1alpha2.DomainVerification{}
+	err := r.Get(ctx, req.NamespacedName, dv)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !dv.DeletionTimestamp.IsZero() {
+		// The generated RRsets are controlled by their zone, they are deleted with the DomainVerification
+		if err := deleteGeneratedRRsets(ctx, dv, r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := addGeneratorFinalizer(ctx, dv, r.Client); err != nil {
+		log.Error(err, "Failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	expiresAt := getDomainVerificationExpiry(dv)
+	expired := expiresAt != nil && !time.Now().Before(expiresAt.Time)
+	desired := []dnsv1alpha2.RRset{}
+	if !expired {
+		desired = append(desired, getDomainVerificationRRset(dv))
+	}
+
+	names, err := reconcileGeneratedRRsets(ctx, dv, desired, r.Scheme, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		if err := patchDomainVerificationStatus(ctx, dv, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  DomainVerificationReasonGenerationFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch DomainVerification status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  DomainVerificationReasonGenerated,
+		Message: DomainVerificationMessageGenerated,
+	}
+	if expired {
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, DomainVerificationReasonExpired, DomainVerificationMessageExpired
+	}
+	dv.Status.ExpiresAt = expiresAt
+	if err := patchDomainVerificationStatus(ctx, dv, names, SUCCEEDED_STATUS, r.Client, condition); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch DomainVerification status")
+		return ctrl.Result{}, err
+	}
+
+	if expiresAt != nil && !expired {
+		// Requeue to remove the verification record on expiry
+		return ctrl.Result{RequeueAfter: time.Until(expiresAt.Time)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// getDomainVerificationExpiry return the time the verification record expires at, or nil if it never expires
+func getDomainVerificationExpiry(dv *dnsv1alpha2.DomainVerification) *metav1.Time {
+	if dv.Spec.ExpiresAfter == nil {
+		return nil
+	}
+	return ptr.To(metav1.NewTime(dv.CreationTimestamp.Add(dv.Spec.ExpiresAfter.Duration)))
+}
+
+// getDomainVerificationRRset return the TXT RRset of the verification record. The Patch strategy is used,
+// the verification record is added to the other TXT records of the name (e.g. SPF, other verifications).
+func getDomainVerificationRRset(dv *dnsv1alpha2.DomainVerification) dnsv1alpha2.RRset {
+	return dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(dv.Name, "txt")},
+		Spec: dnsv1alpha2.RRsetSpec{
+			Type:     "TXT",
+			Name:     ptr.Deref(dv.Spec.Name, makeCanonical(dv.Spec.ZoneRef.Name)),
+			TTL:      dv.Spec.TTL,
+			Records:  []string{txtRecordContent(dv.Spec.Value)},
+			Strategy: ptr.To(RRSET_PATCH_STRATEGY),
+			ZoneRef:  dv.Spec.ZoneRef,
+		},
+	}
+}
+
+func patchDomainVerificationStatus(ctx context.Context, dv *dnsv1alpha2.DomainVerification, rrsets []string, status string, cl client.Client, condition metav1.Condition) error {
+	original := dv.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&dv.Status.Conditions, condition)
+	if rrsets != nil {
+		dv.Status.RRsets = rrsets
+	}
+	dv.Status.SyncStatus = ptr.To(status)
+	dv.Status.ObservedGeneration = ptr.To(dv.GetGeneration())
+	return cl.Status().Patch(ctx, dv, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DomainVerificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.DomainVerification{}).
+		Watches(&dnsv1alpha2.DomainVerification{}, enqueueDeletionsFirst()).
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
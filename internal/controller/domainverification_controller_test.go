@@ -0,0 +1,143 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("DomainVerification Controller", func() {
+
+	const (
+		zoneName          = "example13.org"
+		resourceName      = "microsoft-365"
+		resourceNamespace = "example13"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example13.org", "ns2.example13.org"}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	rrsetNamespacedName := types.NamespacedName{
+		Name:      resourceName + "-txt",
+		Namespace: resourceNamespace,
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance DomainVerification")
+		dv := &dnsv1alpha2.DomainVerification{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, dv); err == nil {
+			Expect(k8sClient.Delete(ctx, dv)).To(Succeed())
+		}
+
+		By("Checking the generated RRset is deleted with its generator")
+		rrset := &dnsv1alpha2.RRset{}
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	Context("When creating a DomainVerification with an expiry", func() {
+		It("should generate the verification RRset and remove it on expiry", Label("domainverification-creation", "expiry"), func() {
+			ctx := context.Background()
+			By("Creating the DomainVerification")
+			dv := &dnsv1alpha2.DomainVerification{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.DomainVerificationSpec{
+					ZoneRef:      dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Value:        "MS=ms12345678",
+					ExpiresAfter: &metav1.Duration{Duration: 3 * time.Second},
+				},
+			}
+			Expect(k8sClient.Create(ctx, dv)).To(Succeed())
+
+			By("Getting the generated RRset")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, dv)
+				return err == nil && dv.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS) && len(dv.Status.RRsets) == 1
+			}, timeout, interval).Should(BeTrue())
+			Expect(dv.Status.ExpiresAt).NotTo(BeNil())
+			rrset := &dnsv1alpha2.RRset{}
+			Expect(k8sClient.Get(ctx, rrsetNamespacedName, rrset)).To(Succeed())
+			Expect(rrset.Spec.Type).To(Equal("TXT"))
+			Expect(rrset.Spec.Name).To(Equal(zoneName + "."))
+			Expect(rrset.Spec.Records).To(Equal([]string{`"MS=ms12345678"`}))
+			Expect(rrset.Spec.Strategy).To(Equal(ptr.To(RRSET_PATCH_STRATEGY)))
+
+			By("Waiting for the expiry")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+				return errors.IsNotFound(err) || (err == nil && !rrset.DeletionTimestamp.IsZero())
+			}, 2*timeout, interval).Should(BeTrue())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, dv)
+				return err == nil && meta.IsStatusConditionFalse(dv.Status.Conditions, "Available")
+			}, timeout, interval).Should(BeTrue())
+			Expect(meta.FindStatusCondition(dv.Status.Conditions, "Available").Reason).To(Equal(DomainVerificationReasonExpired))
+		})
+	})
+})
@@ -0,0 +1,421 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	EXTERNAL_DNS_MEDIA_TYPE    = "application/external.dns.webhook+json;version=1"
+	EXTERNAL_DNS_ROOT_PATH     = "/"
+	EXTERNAL_DNS_RECORDS_PATH  = "/records"
+	EXTERNAL_DNS_ADJUST_PATH   = "/adjustendpoints"
+	EXTERNAL_DNS_HEALTH_PATH   = "/healthz"
+	EXTERNAL_DNS_LABEL         = "dns.cav.enablers.ob/external-dns"
+	EXTERNAL_DNS_RESOURCE      = "external-dns-"
+	EXTERNAL_DNS_MAX_BODY_SIZE = 10 << 20
+)
+
+// externalDNSTypes are the record types supported by the webhook
+var externalDNSTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "PTR", "SRV", "TXT"}
+
+// ExternalDNSWebhook serves the external-dns webhook provider API (https://kubernetes-sigs.github.io/external-dns/latest/docs/tutorials/webhook-provider/),
+// external-dns runs as a sidecar and manages its endpoints as RRsets of the Zones of the namespace.
+// The RRsets written by the webhook are labelled, the changes of names and types described by other RRsets are refused.
+type ExternalDNSWebhook struct {
+	Client client.Client
+	// Namespace of the Zones managed by external-dns, where the RRsets are written
+	Namespace string
+	// DefaultTTL is the TTL of the RRsets written without TTL, reported to external-dns
+	DefaultTTL uint32
+
+	// mu serializes the changes, external-dns applies its plan through a single request
+	mu sync.Mutex
+}
+
+type externalDNSProviderProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// externalDNSEndpoint is the endpoint of external-dns, a RRset
+type externalDNSEndpoint struct {
+	DNSName          string                        `json:"dnsName"`
+	Targets          []string                      `json:"targets"`
+	RecordType       string                        `json:"recordType"`
+	SetIdentifier    string                        `json:"setIdentifier,omitempty"`
+	RecordTTL        int64                         `json:"recordTTL,omitempty"`
+	Labels           map[string]string             `json:"labels,omitempty"`
+	ProviderSpecific []externalDNSProviderProperty `json:"providerSpecific,omitempty"`
+}
+
+// externalDNSChanges is the plan applied by external-dns
+type externalDNSChanges struct {
+	Create    []*externalDNSEndpoint `json:"Create"`
+	UpdateOld []*externalDNSEndpoint `json:"UpdateOld"`
+	UpdateNew []*externalDNSEndpoint `json:"UpdateNew"`
+	Delete    []*externalDNSEndpoint `json:"Delete"`
+}
+
+// externalDNSDomainFilter is the list of the domains managed by the webhook, negotiated by external-dns
+type externalDNSDomainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+func (h *ExternalDNSWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == EXTERNAL_DNS_HEALTH_PATH && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == EXTERNAL_DNS_ROOT_PATH && r.Method == http.MethodGet:
+		h.negotiate(w, r)
+	case r.URL.Path == EXTERNAL_DNS_RECORDS_PATH && r.Method == http.MethodGet:
+		h.records(w, r)
+	case r.URL.Path == EXTERNAL_DNS_RECORDS_PATH && r.Method == http.MethodPost:
+		h.applyChanges(w, r)
+	case r.URL.Path == EXTERNAL_DNS_ADJUST_PATH && r.Method == http.MethodPost:
+		h.adjustEndpoints(w, r)
+	case r.URL.Path == EXTERNAL_DNS_HEALTH_PATH || r.URL.Path == EXTERNAL_DNS_ROOT_PATH || r.URL.Path == EXTERNAL_DNS_RECORDS_PATH || r.URL.Path == EXTERNAL_DNS_ADJUST_PATH:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// negotiate return the domain filter: the Zones of the namespace
+func (h *ExternalDNSWebhook) negotiate(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.getZones(r.Context())
+	if err != nil {
+		ctrl.Log.WithName("external-dns").Error(err, "Failed to list Zones")
+		http.Error(w, "failed to list zones", http.StatusInternalServerError)
+		return
+	}
+	filter := externalDNSDomainFilter{}
+	for _, zone := range zones {
+		filter.Include = append(filter.Include, strings.TrimSuffix(zone, "."))
+	}
+	writeExternalDNSResponse(w, http.StatusOK, filter)
+}
+
+// records return the endpoints of the RRsets written by the webhook
+func (h *ExternalDNSWebhook) records(w http.ResponseWriter, r *http.Request) {
+	var rrsetList dnsv1alpha2.RRsetList
+	if err := h.Client.List(r.Context(), &rrsetList, client.InNamespace(h.Namespace), client.MatchingLabels{EXTERNAL_DNS_LABEL: "true"}); err != nil {
+		ctrl.Log.WithName("external-dns").Error(err, "Failed to list RRsets")
+		http.Error(w, "failed to list records", http.StatusInternalServerError)
+		return
+	}
+	endpoints := make([]externalDNSEndpoint, 0, len(rrsetList.Items))
+	for _, rrset := range rrsetList.Items {
+		if !rrset.DeletionTimestamp.IsZero() {
+			continue
+		}
+		endpoints = append(endpoints, externalDNSEndpoint{
+			DNSName:    strings.TrimSuffix(getRRsetName(&rrset), "."),
+			Targets:    externalDNSTargets(rrset.Spec.Type, rrset.Spec.Records),
+			RecordType: rrset.Spec.Type,
+			RecordTTL:  int64(getRRsetTTL(&rrset, h.DefaultTTL)),
+		})
+	}
+	writeExternalDNSResponse(w, http.StatusOK, endpoints)
+}
+
+// adjustEndpoints return the endpoints as managed by the webhook: the provider specific properties are not supported
+func (h *ExternalDNSWebhook) adjustEndpoints(w http.ResponseWriter, r *http.Request) {
+	endpoints := []externalDNSEndpoint{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, EXTERNAL_DNS_MAX_BODY_SIZE)).Decode(&endpoints); err != nil {
+		http.Error(w, "malformed endpoints", http.StatusBadRequest)
+		return
+	}
+	for i := range endpoints {
+		endpoints[i].DNSName = strings.TrimSuffix(strings.ToLower(endpoints[i].DNSName), ".")
+		endpoints[i].ProviderSpecific = nil
+	}
+	writeExternalDNSResponse(w, http.StatusOK, endpoints)
+}
+
+// applyChanges applies the plan of external-dns to the RRsets: all the changes are checked before being applied
+func (h *ExternalDNSWebhook) applyChanges(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("external-dns")
+	changes := externalDNSChanges{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, EXTERNAL_DNS_MAX_BODY_SIZE)).Decode(&changes); err != nil {
+		http.Error(w, "malformed changes", http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.apply(r.Context(), changes); err != nil {
+		log.Info("Changes refused", "reason", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ExternalDNSWebhook) apply(ctx context.Context, changes externalDNSChanges) error {
+	log := ctrl.Log.WithName("external-dns")
+	zones, err := h.getZones(ctx)
+	if err != nil {
+		return err
+	}
+	var rrsetList dnsv1alpha2.RRsetList
+	if err := h.Client.List(ctx, &rrsetList, client.InNamespace(h.Namespace)); err != nil {
+		return err
+	}
+	owned := map[string]*dnsv1alpha2.RRset{}
+	foreign := map[string]bool{}
+	for i := range rrsetList.Items {
+		rrset := &rrsetList.Items[i]
+		key := strings.ToLower(getRRsetName(rrset)) + "/" + rrset.Spec.Type
+		if rrset.Labels[EXTERNAL_DNS_LABEL] == "true" {
+			owned[key] = rrset
+		} else {
+			foreign[key] = true
+		}
+	}
+
+	desired, err := getExternalDNSDesiredEndpoints(changes, zones, foreign)
+	if err != nil {
+		return err
+	}
+	if err := checkExternalDNSRRsetNames(desired, rrsetList.Items); err != nil {
+		return err
+	}
+	for key, endpoint := range desired {
+		rrset := owned[key]
+		if endpoint == nil {
+			if rrset != nil {
+				if err := h.Client.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+					return err
+				}
+				log.Info("Deleted endpoint", "name", rrset.Spec.Name, "type", rrset.Spec.Type)
+			}
+			continue
+		}
+		name := makeCanonical(endpoint.DNSName)
+		rrset = &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getExternalDNSRRsetName(endpoint),
+				Namespace: h.Namespace,
+			},
+		}
+		result, err := controllerutil.CreateOrUpdate(ctx, h.Client, rrset, func() error {
+			if !rrset.CreationTimestamp.IsZero() && rrset.Labels[EXTERNAL_DNS_LABEL] != "true" {
+				return &generatedRRsetConflictError{name: rrset.Name}
+			}
+			if rrset.Labels == nil {
+				rrset.Labels = map[string]string{}
+			}
+			rrset.Labels[EXTERNAL_DNS_LABEL] = "true"
+			rrset.Spec = dnsv1alpha2.RRsetSpec{
+				Type:    endpoint.RecordType,
+				Name:    name,
+				Records: externalDNSRecords(endpoint.RecordType, endpoint.Targets),
+				ZoneRef: dnsv1alpha2.ZoneRef{Name: strings.TrimSuffix(getExternalDNSZone(name, zones), "."), Kind: "Zone"},
+			}
+			if endpoint.RecordTTL > 0 {
+				rrset.Spec.TTL = ptr.To(uint32(endpoint.RecordTTL))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if result != controllerutil.OperationResultNone {
+			log.Info("Applied endpoint", "name", name, "type", endpoint.RecordType, "operation", result)
+		}
+	}
+	return nil
+}
+
+// getExternalDNSRRsetName return the name of the RRset resource of an endpoint (e.g. "external-dns-a-www.example.org")
+func getExternalDNSRRsetName(endpoint *externalDNSEndpoint) string {
+	return getGeneratedRRsetName(EXTERNAL_DNS_RESOURCE+strings.ToLower(endpoint.RecordType), makeCanonical(endpoint.DNSName))
+}
+
+// checkExternalDNSRRsetNames return an error if the RRset resource of an endpoint to apply exists without being created
+// by external-dns, or describes another endpoint: the names of the resources do not keep the underscores of the DNS names
+func checkExternalDNSRRsetNames(desired map[string]*externalDNSEndpoint, rrsets []dnsv1alpha2.RRset) error {
+	existing := map[string]*dnsv1alpha2.RRset{}
+	for i := range rrsets {
+		existing[rrsets[i].Name] = &rrsets[i]
+	}
+	claimed := map[string]string{}
+	for key, endpoint := range desired {
+		if endpoint == nil {
+			continue
+		}
+		name := getExternalDNSRRsetName(endpoint)
+		if other, ok := claimed[name]; ok {
+			return fmt.Errorf("RRsets %s and %s share the resource name %s", other, key, name)
+		}
+		claimed[name] = key
+		rrset, ok := existing[name]
+		if !ok {
+			continue
+		}
+		if rrset.Labels[EXTERNAL_DNS_LABEL] != "true" {
+			return &generatedRRsetConflictError{name: name}
+		}
+		if other := strings.ToLower(getRRsetName(rrset)) + "/" + rrset.Spec.Type; other != key {
+			return fmt.Errorf("RRset %s shares the resource name %s with %s", key, name, other)
+		}
+	}
+	return nil
+}
+
+// getZones return the canonical names of the Zones of the namespace
+func (h *ExternalDNSWebhook) getZones(ctx context.Context) ([]string, error) {
+	var zoneList dnsv1alpha2.ZoneList
+	if err := h.Client.List(ctx, &zoneList, client.InNamespace(h.Namespace)); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(zoneList.Items))
+	for _, zone := range zoneList.Items {
+		zones = append(zones, strings.ToLower(makeCanonical(zone.Name)))
+	}
+	slices.Sort(zones)
+	return zones, nil
+}
+
+// getExternalDNSDesiredEndpoints return, by canonical name and type, the endpoints to apply, or nil for the endpoints to delete.
+// The endpoints must belong to a zone, with a supported type, and must not be described by other resources.
+func getExternalDNSDesiredEndpoints(changes externalDNSChanges, zones []string, foreign map[string]bool) (map[string]*externalDNSEndpoint, error) {
+	desired := map[string]*externalDNSEndpoint{}
+	steps := []struct {
+		endpoints  []*externalDNSEndpoint
+		isDeletion bool
+	}{
+		{changes.Delete, true},
+		{changes.UpdateOld, true},
+		{changes.Create, false},
+		{changes.UpdateNew, false},
+	}
+	for _, step := range steps {
+		for _, endpoint := range step.endpoints {
+			if endpoint == nil {
+				continue
+			}
+			name := strings.ToLower(makeCanonical(endpoint.DNSName))
+			key := name + "/" + endpoint.RecordType
+			if !slices.Contains(externalDNSTypes, endpoint.RecordType) {
+				return nil, fmt.Errorf("unsupported record type %s for %s", endpoint.RecordType, name)
+			}
+			if endpoint.SetIdentifier != "" {
+				return nil, fmt.Errorf("set identifiers are not supported, for %s", name)
+			}
+			if getExternalDNSZone(name, zones) == "" {
+				return nil, fmt.Errorf("no zone for %s", name)
+			}
+			if foreign[key] {
+				return nil, fmt.Errorf("RRset %s is managed by another resource", key)
+			}
+			if step.isDeletion {
+				desired[key] = nil
+				continue
+			}
+			desired[key] = endpoint
+		}
+	}
+	return desired, nil
+}
+
+// getExternalDNSZone return the most specific zone of the canonical name, or an empty string
+func getExternalDNSZone(name string, zones []string) string {
+	zone := ""
+	for _, z := range zones {
+		if isInZone(name, z) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+	return zone
+}
+
+// externalDNSRecords return the records of the targets of an endpoint, in the presentation format:
+// the TXT values are quoted and the names made canonical
+func externalDNSRecords(rrType string, targets []string) []string {
+	records := make([]string, 0, len(targets))
+	for _, target := range targets {
+		switch rrType {
+		case "TXT":
+			target = txtRecordContent(target)
+		case "CNAME", "NS", "PTR":
+			target = makeCanonical(target)
+		case "MX", "SRV":
+			if fields := strings.Fields(target); len(fields) > 1 {
+				fields[len(fields)-1] = makeCanonical(fields[len(fields)-1])
+				target = strings.Join(fields, " ")
+			}
+		}
+		records = append(records, target)
+	}
+	slices.Sort(records)
+	return slices.Compact(records)
+}
+
+// externalDNSTargets return the targets of an endpoint from the records, the reverse of externalDNSRecords
+func externalDNSTargets(rrType string, records []string) []string {
+	targets := make([]string, 0, len(records))
+	for _, record := range records {
+		switch rrType {
+		case "TXT":
+			record = unquoteTXTRecord(record)
+		case "CNAME", "NS", "PTR", "MX", "SRV":
+			record = strings.TrimSuffix(record, ".")
+		}
+		targets = append(targets, record)
+	}
+	return targets
+}
+
+// unquoteTXTRecord return the value of a TXT record: its character strings unquoted and concatenated
+func unquoteTXTRecord(in string) string {
+	var out strings.Builder
+	quoted, escaped := false, false
+	for _, c := range in {
+		switch {
+		case escaped:
+			out.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case quoted || c != ' ':
+			out.WriteRune(c)
+		}
+	}
+	return out.String()
+}
+
+func writeExternalDNSResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", EXTERNAL_DNS_MEDIA_TYPE)
+	w.Header().Set("Vary", "Content-Type")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,224 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestExternalDNSRecords(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrType      string
+		targets     []string
+		want        []string
+	}{
+		{"A targets sorted", "A", []string{"192.0.2.2", "192.0.2.1", "192.0.2.1"}, []string{"192.0.2.1", "192.0.2.2"}},
+		{"CNAME target made canonical", "CNAME", []string{"lb.example.org"}, []string{"lb.example.org."}},
+		{"MX target made canonical", "MX", []string{"10 mail.example.org"}, []string{"10 mail.example.org."}},
+		{"SRV target made canonical", "SRV", []string{"0 5 443 www.example.org."}, []string{"0 5 443 www.example.org."}},
+		{"TXT target quoted", "TXT", []string{`heritage=external-dns,external-dns/owner="default"`}, []string{`"heritage=external-dns,external-dns/owner=\"default\""`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := externalDNSRecords(tc.rrType, tc.targets)
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if tc.rrType != "A" && !cmp.Equal(externalDNSTargets(tc.rrType, got), []string{strings.TrimSuffix(tc.targets[0], ".")}) {
+				t.Errorf("targets %v not restored from %v", tc.targets, got)
+			}
+		})
+	}
+}
+
+func TestUnquoteTXTRecord(t *testing.T) {
+	var testCases = []struct {
+		description string
+		in          string
+		want        string
+	}{
+		{"Single character string", `"v=spf1 -all"`, "v=spf1 -all"},
+		{"Split character strings", `"v=DKIM1; " "p=MII"`, "v=DKIM1; p=MII"},
+		{"Escaped characters", `"say \"hi\" \\o/"`, `say "hi" \o/`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := unquoteTXTRecord(tc.in); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetExternalDNSZone(t *testing.T) {
+	zones := []string{"example.org.", "sub.example.org."}
+	var testCases = []struct {
+		description string
+		name        string
+		want        string
+	}{
+		{"Apex", "example.org.", "example.org."},
+		{"Most specific zone", "www.sub.example.org.", "sub.example.org."},
+		{"Parent zone", "www.example.org.", "example.org."},
+		{"No zone", "www.example.com.", ""},
+		{"Suffix of a zone name", "www.myexample.org.", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := getExternalDNSZone(tc.name, zones); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetExternalDNSDesiredEndpoints(t *testing.T) {
+	zones := []string{"example.org."}
+	foreign := map[string]bool{"mail.example.org./A": true}
+	www := &externalDNSEndpoint{DNSName: "www.example.org", RecordType: "A", Targets: []string{"192.0.2.2"}}
+	var testCases = []struct {
+		description string
+		changes     externalDNSChanges
+		want        map[string]*externalDNSEndpoint
+		wantErr     bool
+	}{
+		{
+			"Create and delete",
+			externalDNSChanges{
+				Create: []*externalDNSEndpoint{www},
+				Delete: []*externalDNSEndpoint{{DNSName: "old.example.org", RecordType: "CNAME"}},
+			},
+			map[string]*externalDNSEndpoint{"www.example.org./A": www, "old.example.org./CNAME": nil},
+			false,
+		},
+		{
+			"Update",
+			externalDNSChanges{
+				UpdateOld: []*externalDNSEndpoint{{DNSName: "www.example.org", RecordType: "A", Targets: []string{"192.0.2.1"}}},
+				UpdateNew: []*externalDNSEndpoint{www},
+			},
+			map[string]*externalDNSEndpoint{"www.example.org./A": www},
+			false,
+		},
+		{
+			"Out of the zones",
+			externalDNSChanges{Create: []*externalDNSEndpoint{{DNSName: "www.example.com", RecordType: "A"}}},
+			nil, true,
+		},
+		{
+			"Unsupported type",
+			externalDNSChanges{Create: []*externalDNSEndpoint{{DNSName: "www.example.org", RecordType: "NAPTR"}}},
+			nil, true,
+		},
+		{
+			"Set identifier",
+			externalDNSChanges{Create: []*externalDNSEndpoint{{DNSName: "www.example.org", RecordType: "A", SetIdentifier: "eu"}}},
+			nil, true,
+		},
+		{
+			"RRset managed by another resource",
+			externalDNSChanges{Delete: []*externalDNSEndpoint{{DNSName: "mail.example.org", RecordType: "A"}}},
+			nil, true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := getExternalDNSDesiredEndpoints(tc.changes, zones, foreign)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected endpoints (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckExternalDNSRRsetNames(t *testing.T) {
+	acme := &externalDNSEndpoint{DNSName: "_acme-challenge.example.org", RecordType: "TXT", Targets: []string{"token"}}
+	rrset := func(name string, labels map[string]string, dnsName string) dnsv1alpha2.RRset {
+		return dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       dnsv1alpha2.RRsetSpec{Type: "TXT", Name: dnsName, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		}
+	}
+	owned := map[string]string{EXTERNAL_DNS_LABEL: "true"}
+	var testCases = []struct {
+		description string
+		desired     map[string]*externalDNSEndpoint
+		rrsets      []dnsv1alpha2.RRset
+		wantErr     bool
+	}{
+		{"New RRset", map[string]*externalDNSEndpoint{"_acme-challenge.example.org./TXT": acme}, nil, false},
+		{"RRset of the endpoint", map[string]*externalDNSEndpoint{"_acme-challenge.example.org./TXT": acme}, []dnsv1alpha2.RRset{rrset("external-dns-txt-acme-challenge.example.org", owned, "_acme-challenge")}, false},
+		{"RRset not created by external-dns", map[string]*externalDNSEndpoint{"_acme-challenge.example.org./TXT": acme}, []dnsv1alpha2.RRset{rrset("external-dns-txt-acme-challenge.example.org", nil, "other")}, true},
+		{"RRset of another endpoint", map[string]*externalDNSEndpoint{"_acme-challenge.example.org./TXT": acme}, []dnsv1alpha2.RRset{rrset("external-dns-txt-acme-challenge.example.org", owned, "acme-challenge")}, true},
+		{"Endpoints sharing a resource name", map[string]*externalDNSEndpoint{
+			"_acme-challenge.example.org./TXT": acme,
+			"acme-challenge.example.org./TXT":  {DNSName: "acme-challenge.example.org", RecordType: "TXT"},
+		}, nil, true},
+		{"Deletion", map[string]*externalDNSEndpoint{"_acme-challenge.example.org./TXT": nil}, []dnsv1alpha2.RRset{rrset("external-dns-txt-acme-challenge.example.org", nil, "other")}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := checkExternalDNSRRsetNames(tc.desired, tc.rrsets)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExternalDNSWebhookRouting(t *testing.T) {
+	webhook := &ExternalDNSWebhook{Namespace: "external-dns"}
+	var testCases = []struct {
+		description string
+		method      string
+		path        string
+		body        string
+		want        int
+	}{
+		{"Health", http.MethodGet, EXTERNAL_DNS_HEALTH_PATH, "", http.StatusOK},
+		{"Unknown path", http.MethodGet, "/unknown", "", http.StatusNotFound},
+		{"Wrong method", http.MethodPut, EXTERNAL_DNS_RECORDS_PATH, "", http.StatusMethodNotAllowed},
+		{"Malformed changes", http.MethodPost, EXTERNAL_DNS_RECORDS_PATH, "{", http.StatusBadRequest},
+		{"Adjust endpoints", http.MethodPost, EXTERNAL_DNS_ADJUST_PATH, `[{"dnsName":"WWW.example.org.","recordType":"A","targets":["192.0.2.1"]}]`, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			webhook.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Errorf("got status %d, want %d", rec.Code, tc.want)
+			}
+			if tc.path == EXTERNAL_DNS_ADJUST_PATH && !strings.Contains(rec.Body.String(), `"dnsName":"www.example.org"`) {
+				t.Errorf("endpoint not adjusted: %s", rec.Body.String())
+			}
+		})
+	}
+}
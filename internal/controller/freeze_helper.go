@@ -0,0 +1,35 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// FREEZE_ANNOTATION freezes a zone, as spec.frozen: the records of the zone are not modified in PowerDNS
+	FREEZE_ANNOTATION       = "dns.cav.enablers.ob/frozen"
+	FREEZE_ANNOTATION_VALUE = "true"
+
+	RrsetReasonZoneFrozen  = "ZoneFrozen"
+	RrsetMessageZoneFrozen = "Frozen zone, pending changes are not applied to PowerDNS until unfrozen"
+
+	ZoneReasonFrozen  = "ZoneFrozen"
+	ZoneMessageFrozen = "Frozen zone, pending changes of the zone are not applied to PowerDNS until unfrozen"
+)
+
+// isFrozenZone return True if the changes of the records of the zone are suspended, through specification or annotation
+func isFrozenZone(zone dnsv1alpha2.GenericZone) bool {
+	return ptr.Deref(zone.GetSpec().Frozen, false) || zone.GetAnnotations()[FREEZE_ANNOTATION] == FREEZE_ANNOTATION_VALUE
+}
@@ -0,0 +1,43 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsFrozenZone(t *testing.T) {
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		want        bool
+	}{
+		{"Zone not frozen", &dnsv1alpha2.Zone{}, false},
+		{"Frozen through specification", &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{Frozen: ptr.To(true)}}, true},
+		{"Unfrozen through specification", &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{Frozen: ptr.To(false)}}, false},
+		{"Frozen through annotation", &dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{FREEZE_ANNOTATION: "true"}}}, true},
+		{"Other annotation value", &dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{FREEZE_ANNOTATION: "false"}}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isFrozenZone(tc.zone); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
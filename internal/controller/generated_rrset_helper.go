@@ -0,0 +1,172 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// reconcileGeneratedRRsets creates or updates the RRsets generated by a resource, in its namespace, and deletes
+// the RRsets it generated previously and not desired anymore. It returns the sorted names of the generated RRsets.
+// The generated RRsets are owned by the resource, without being controlled by it: the RRsets are controlled by their zone,
+// so they are not garbage collected with the resource, see deleteGeneratedRRsets.
+// An existing RRset not generated by the resource is not taken over.
+func reconcileGeneratedRRsets(ctx context.Context, owner client.Object, desired []dnsv1alpha2.RRset, scheme *runtime.Scheme, cl client.Client) ([]string, error) {
+	names := make([]string, 0, len(desired))
+	for _, d := range desired {
+		rrset := &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      d.Name,
+				Namespace: owner.GetNamespace(),
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, cl, rrset, func() error {
+			if !rrset.CreationTimestamp.IsZero() && !isGeneratedBy(rrset, owner) {
+				return &generatedRRsetConflictError{name: rrset.Name}
+			}
+			rrset.Spec = d.Spec
+			return controllerutil.SetOwnerReference(owner, rrset, scheme)
+		}); err != nil {
+			return nil, err
+		}
+		names = append(names, d.Name)
+	}
+
+	if err := pruneGeneratedRRsets(ctx, owner, names, cl); err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// pruneGeneratedRRsets deletes the RRsets generated by a resource, except the kept ones
+func pruneGeneratedRRsets(ctx context.Context, owner client.Object, kept []string, cl client.Client) error {
+	var existingRRsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &existingRRsets, client.InNamespace(owner.GetNamespace())); err != nil {
+		return err
+	}
+	for i := range existingRRsets.Items {
+		rrset := &existingRRsets.Items[i]
+		if !isGeneratedBy(rrset, owner) || slices.Contains(kept, rrset.Name) {
+			continue
+		}
+		if err := cl.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addGeneratorFinalizer registers the finalizer of a resource generating RRsets, deleting them with the resource
+func addGeneratorFinalizer(ctx context.Context, owner client.Object, cl client.Client) error {
+	if controllerutil.ContainsFinalizer(owner, RESOURCES_FINALIZER_NAME) {
+		return nil
+	}
+	controllerutil.AddFinalizer(owner, RESOURCES_FINALIZER_NAME)
+	return cl.Update(ctx, owner)
+}
+
+// deleteGeneratedRRsets deletes the RRsets generated by a resource being deleted, then removes its finalizer
+func deleteGeneratedRRsets(ctx context.Context, owner client.Object, cl client.Client) error {
+	if !controllerutil.ContainsFinalizer(owner, RESOURCES_FINALIZER_NAME) {
+		return nil
+	}
+	if err := pruneGeneratedRRsets(ctx, owner, nil, cl); err != nil {
+		return err
+	}
+	controllerutil.RemoveFinalizer(owner, RESOURCES_FINALIZER_NAME)
+	return cl.Update(ctx, owner)
+}
+
+// deleteSourceRRsets deletes the RRsets generated by a deleted source object, or by a previous object of the same name,
+// matched by the kind and the name of their owner: the sources (e.g. Services) are not owned by the operator, so no
+// finalizer is added to them. The RRsets owned by the object of the UID, if any, are kept.
+func deleteSourceRRsets(ctx context.Context, key client.ObjectKey, gvk schema.GroupVersionKind, uid types.UID, cl client.Client) error {
+	var existingRRsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &existingRRsets, client.InNamespace(key.Namespace)); err != nil {
+		return err
+	}
+	apiVersion := gvk.GroupVersion().String()
+	for i := range existingRRsets.Items {
+		rrset := &existingRRsets.Items[i]
+		if !slices.ContainsFunc(rrset.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.APIVersion == apiVersion && ref.Kind == gvk.Kind && ref.Name == key.Name && ref.UID != uid
+		}) {
+			continue
+		}
+		if err := cl.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isGeneratedBy return True if the RRset is owned by the resource
+func isGeneratedBy(rrset *dnsv1alpha2.RRset, owner client.Object) bool {
+	return slices.ContainsFunc(rrset.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.GetUID()
+	})
+}
+
+// getGeneratedRRsetName return the name of the RRset resource generated by a resource for a DNS name (e.g. "hosts-web1")
+func getGeneratedRRsetName(ownerName, name string) string {
+	name = strings.ReplaceAll(strings.TrimSuffix(name, "."), "*", "wildcard")
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "")
+	return strings.Trim(ownerName+"-"+name, ".-")
+}
+
+// enqueueForSourceObject return an event handler enqueuing the resources of a list, in the namespace of an object,
+// whose index field is the "<kind>/<name>" key of the object, e.g. the resources generating RRsets from a Secret
+func enqueueForSourceObject(cl client.Client, list client.ObjectList, field, kind string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		l := list.DeepCopyObject().(client.ObjectList)
+		if err := cl.List(ctx, l, client.InNamespace(obj.GetNamespace()), client.MatchingFields{field: kind + "/" + obj.GetName()}); err != nil {
+			return nil
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			if o, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+			}
+		}
+		return requests
+	})
+}
+
+// generatedRRsetConflictError is returned when a RRset exists with the name of a generated RRset,
+// without being generated by the resource
+type generatedRRsetConflictError struct {
+	name string
+}
+
+func (e *generatedRRsetConflictError) Error() string {
+	return fmt.Sprintf("RRset %s already exists, not generated by the resource", e.name)
+}
@@ -0,0 +1,41 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetGeneratedRRsetName(t *testing.T) {
+	var testCases = []struct {
+		description string
+		ownerName   string
+		name        string
+		want        string
+	}{
+		{"Relative name", "hosts", "web1", "hosts-web1"},
+		{"Canonical name", "hosts", "Web1.example.org.", "hosts-web1.example.org"},
+		{"Wildcard name", "hosts", "*.test", "hosts-wildcard.test"},
+		{"Service name", "mail", "_25._tcp.mx", "mail-25.tcp.mx"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			name := getGeneratedRRsetName(tc.ownerName, tc.name)
+			if !cmp.Equal(name, tc.want) {
+				t.Errorf("got %v, want %v", name, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	INVENTORY_STATE_IN_SYNC = "in_sync"
+	INVENTORY_STATE_DRIFTED = "drifted"
+	INVENTORY_STATE_MISSING = "missing"
+
+	InventoryReasonDrifted  = "Drifted"
+	InventoryMessageDrifted = "Differs from PowerDNS on startup, annotate with " + RECONCILE_ANNOTATION + " to synchronize:"
+	InventoryReasonMissing  = "Missing"
+	InventoryMessageMissing = "Missing from PowerDNS on startup, annotate with " + RECONCILE_ANNOTATION + " to synchronize"
+)
+
+var inventoryMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "startup_inventory_resources",
+		Help: "Number of resources by kind and state (in_sync, drifted, missing) compared with PowerDNS on startup",
+	},
+	[]string{"kind", "state"},
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(inventoryMetric)
+}
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// InventoryValidator compares once, on startup, the managed zones and RRsets with PowerDNS, without modifying them:
+// the summary is logged and exported, and the drifted or missing resources are reported through events
+type InventoryValidator struct {
+	Client client.Client
+	// Reader reads the Services of the primaries without cache, only their metadata is watched
+	Reader     client.Reader
+	PDNSClient PdnsClienter
+	Recorder   events.EventRecorder
+	DefaultTTL uint32
+	ClusterID  string
+}
+
+// inventory counts the resources of each kind and state
+type inventory map[string]map[string]int
+
+func (i inventory) add(kind string, state string) {
+	if i[kind] == nil {
+		i[kind] = map[string]int{INVENTORY_STATE_IN_SYNC: 0, INVENTORY_STATE_DRIFTED: 0, INVENTORY_STATE_MISSING: 0}
+	}
+	i[kind][state]++
+}
+
+// Start validates the inventory, the caches are synchronized before the runnables are started
+func (v *InventoryValidator) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("inventory-validator")
+	zones, rrsets, err := v.list(ctx)
+	if err != nil {
+		// The validation is informative, the operator is not stopped
+		log.Error(err, "unable to list the managed resources, startup inventory not validated")
+		return nil
+	}
+
+	counts := inventory{}
+	for _, zone := range zones {
+		externalZone, err := v.PDNSClient.Zones.Get(ctx, zone.GetObjectMeta().Name)
+		if err != nil && err.Error() != ZONE_NOT_FOUND_MSG {
+			log.Error(err, "unable to get zone, not validated", "zone", zone.GetName())
+			continue
+		}
+		if err != nil {
+			externalZone = nil
+		}
+		// The primaries of the Services are compared, as applied by the zone reconciliation
+		if len(zone.GetSpec().MasterServices) != 0 && isSecondaryZone(zone) {
+			masters, err := resolveMasterServices(ctx, zone, v.Reader)
+			if err != nil {
+				log.Error(err, "unable to resolve the Services of the primaries, not validated", "zone", zone.GetName())
+				continue
+			}
+			zone.GetSpec().Masters = masters
+		}
+		v.report(counts, zone, getZoneInventoryState(zone, externalZone), nil, log)
+
+		var externalRRsets []powerdns.RRset
+		if externalZone != nil {
+			externalRRsets = externalZone.RRsets
+		}
+		zoneRRsets := getZoneInventoryRRsets(zone, rrsets)
+		for _, rrset := range zoneRRsets {
+			applied := rrset
+			if isMergeStrategy(rrset) {
+				contributors := slices.DeleteFunc(slices.Clone(zoneRRsets), func(other dnsv1alpha2.GenericRRset) bool { return !isContending(rrset, other) })
+				applied = getMergedRRset(rrset, contributors, v.DefaultTTL, time.Now())
+			}
+			if rrset.GetSpec().TargetRef != nil {
+				records, err := resolveTargetRef(ctx, rrset, v.Client)
+				if err != nil {
+					log.Error(err, "unable to resolve the target, not validated", "rrset", rrset.GetName())
+					continue
+				}
+				applied = rrset.Copy()
+				applied.GetSpec().Records = records
+			}
+			state, changes := getRRsetInventoryState(applied, externalZone != nil, externalRRsets, v.DefaultTTL, v.ClusterID)
+			v.report(counts, rrset, state, changes, log)
+		}
+	}
+
+	for kind, states := range counts {
+		log.Info("Startup inventory validated", "kind", kind, "inSync", states[INVENTORY_STATE_IN_SYNC],
+			"drifted", states[INVENTORY_STATE_DRIFTED], "missing", states[INVENTORY_STATE_MISSING])
+		for state, count := range states {
+			inventoryMetric.WithLabelValues(kind, state).Set(float64(count))
+		}
+	}
+	return nil
+}
+
+// list return the zones, and the RRsets successfully applied to PowerDNS, not being deleted and published:
+// the other RRsets are expected to differ from PowerDNS
+func (v *InventoryValidator) list(ctx context.Context) ([]dnsv1alpha2.GenericZone, []dnsv1alpha2.GenericRRset, error) {
+	var zoneList dnsv1alpha2.ZoneList
+	if err := v.Client.List(ctx, &zoneList); err != nil {
+		return nil, nil, err
+	}
+	var clusterZoneList dnsv1alpha2.ClusterZoneList
+	if err := v.Client.List(ctx, &clusterZoneList); err != nil {
+		return nil, nil, err
+	}
+	var rrsetList dnsv1alpha2.RRsetList
+	if err := v.Client.List(ctx, &rrsetList); err != nil {
+		return nil, nil, err
+	}
+	var clusterRRsetList dnsv1alpha2.ClusterRRsetList
+	if err := v.Client.List(ctx, &clusterRRsetList); err != nil {
+		return nil, nil, err
+	}
+
+	zones := []dnsv1alpha2.GenericZone{}
+	for i := range zoneList.Items {
+		zones = append(zones, &zoneList.Items[i])
+	}
+	for i := range clusterZoneList.Items {
+		zones = append(zones, &clusterZoneList.Items[i])
+	}
+	all := []dnsv1alpha2.GenericRRset{}
+	for i := range rrsetList.Items {
+		all = append(all, &rrsetList.Items[i])
+	}
+	for i := range clusterRRsetList.Items {
+		all = append(all, &clusterRRsetList.Items[i])
+	}
+	rrsets := []dnsv1alpha2.GenericRRset{}
+	for _, rrset := range all {
+		if rrset.GetDeletionTimestamp().IsZero() && ptr.Deref(rrset.GetStatus().SyncStatus, "") == SUCCEEDED_STATUS &&
+			isActiveRRset(rrset, time.Now()) {
+			rrsets = append(rrsets, rrset)
+		}
+	}
+	return zones, rrsets, nil
+}
+
+// report count the state of the resource, and emit an event if drifted or missing
+func (v *InventoryValidator) report(counts inventory, obj client.Object, state string, changes []string, log logr.Logger) {
+	kind := "Zone"
+	switch obj.(type) {
+	case *dnsv1alpha2.ClusterZone:
+		kind = "ClusterZone"
+	case *dnsv1alpha2.RRset:
+		kind = "RRset"
+	case *dnsv1alpha2.ClusterRRset:
+		kind = "ClusterRRset"
+	}
+	counts.add(kind, state)
+	switch state {
+	case INVENTORY_STATE_DRIFTED:
+		log.Info("Resource drifted from PowerDNS", "kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName(), "changes", changes)
+		v.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, InventoryReasonDrifted, "Validate", "%s %s", InventoryMessageDrifted, strings.Join(changes, ", "))
+	case INVENTORY_STATE_MISSING:
+		log.Info("Resource missing from PowerDNS", "kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+		v.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, InventoryReasonMissing, "Validate", InventoryMessageMissing)
+	}
+}
+
+// getZoneInventoryRRsets return the RRsets referencing the zone, in the namespace of a Zone
+func getZoneInventoryRRsets(zone dnsv1alpha2.GenericZone, rrsets []dnsv1alpha2.GenericRRset) []dnsv1alpha2.GenericRRset {
+	kind := "ClusterZone"
+	if _, ok := zone.(*dnsv1alpha2.Zone); ok {
+		kind = "Zone"
+	}
+	referencing := []dnsv1alpha2.GenericRRset{}
+	for _, rrset := range rrsets {
+		if rrset.GetSpec().ZoneRef.Name == zone.GetName() && rrset.GetSpec().ZoneRef.Kind == kind &&
+			(kind == "ClusterZone" || rrset.GetNamespace() == zone.GetNamespace()) {
+			referencing = append(referencing, rrset)
+		}
+	}
+	return referencing
+}
+
+// getZoneInventoryState return the state of the zone, compared with the zone in PowerDNS (nil if missing)
+func getZoneInventoryState(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone) string {
+	if externalZone == nil || externalZone.Name == nil {
+		return INVENTORY_STATE_MISSING
+	}
+	var nameservers []string
+	for _, rrset := range externalZone.RRsets {
+		if ptr.Deref(rrset.Name, "") == makeCanonical(zone.GetObjectMeta().Name) && ptr.Deref(rrset.Type, "") == powerdns.RRTypeNS {
+			for _, record := range rrset.Records {
+				nameservers = append(nameservers, strings.TrimSuffix(ptr.Deref(record.Content, ""), "."))
+			}
+		}
+	}
+	zoneIdentical, nsIdentical := zoneIsIdenticalToExternalZone(zone, externalZone, nameservers)
+	if !zoneIdentical || (!nsIdentical && !isSecondaryZone(zone)) {
+		return INVENTORY_STATE_DRIFTED
+	}
+	return INVENTORY_STATE_IN_SYNC
+}
+
+// getRRsetInventoryState return the state of the RRset, compared with the RRsets of its zone in PowerDNS,
+// and the changes applied by a synchronization if drifted
+func getRRsetInventoryState(rrset dnsv1alpha2.GenericRRset, zoneFound bool, externalRRsets []powerdns.RRset, defaultTTL uint32, clusterID string) (string, []string) {
+	var externalRecord *powerdns.RRset
+	for i, external := range externalRRsets {
+		// The names are lowercase in PowerDNS
+		if strings.EqualFold(ptr.Deref(external.Name, ""), getRRsetName(rrset)) && string(ptr.Deref(external.Type, "")) == rrset.GetSpec().Type {
+			externalRecord = &externalRRsets[i]
+		}
+	}
+	if !zoneFound || (externalRecord == nil && !isAbsentRRset(rrset)) {
+		return INVENTORY_STATE_MISSING, nil
+	}
+	if changes := rrsetPendingChanges(rrset, externalRecord, defaultTTL, clusterID); len(changes) != 0 {
+		return INVENTORY_STATE_DRIFTED, changes
+	}
+	return INVENTORY_STATE_IN_SYNC, nil
+}
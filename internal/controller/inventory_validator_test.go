@@ -0,0 +1,121 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetZoneInventoryState(t *testing.T) {
+	zone := &dnsv1alpha2.Zone{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.org"},
+		Spec:       dnsv1alpha2.ZoneSpec{Kind: "Native", Nameservers: []string{"ns1.example.org"}},
+	}
+	nsRRset := powerdns.RRset{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS), Records: toPdnsRecords([]string{"ns1.example.org."})}
+
+	var testCases = []struct {
+		description  string
+		externalZone *powerdns.Zone
+		want         string
+	}{
+		{"Missing zone", nil, INVENTORY_STATE_MISSING},
+		{"Identical zone", &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.NativeZoneKind), RRsets: []powerdns.RRset{nsRRset}}, INVENTORY_STATE_IN_SYNC},
+		{"Other kind", &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.MasterZoneKind), RRsets: []powerdns.RRset{nsRRset}}, INVENTORY_STATE_DRIFTED},
+		{"Other nameservers", &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.NativeZoneKind)}, INVENTORY_STATE_DRIFTED},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := getZoneInventoryState(zone, tc.externalZone); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRRsetInventoryState(t *testing.T) {
+	rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", TTL: ptr.To(uint32(300)), Records: []string{"1.1.1.1"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	mixedCase := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "Test", Type: "A", TTL: ptr.To(uint32(300)), Records: []string{"1.1.1.1"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	absent := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", Ensure: ptr.To(RRSET_ABSENT_ENSURE), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	identical := powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1"})}
+	different := powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"2.2.2.2"})}
+	otherType := powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeAAAA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"::1"})}
+
+	var testCases = []struct {
+		description    string
+		rrset          dnsv1alpha2.GenericRRset
+		zoneFound      bool
+		externalRRsets []powerdns.RRset
+		want           string
+		wantChanges    []string
+	}{
+		{"Missing zone", rrset, false, nil, INVENTORY_STATE_MISSING, nil},
+		{"Missing RRset", rrset, true, []powerdns.RRset{otherType}, INVENTORY_STATE_MISSING, nil},
+		{"Identical RRset", rrset, true, []powerdns.RRset{otherType, identical}, INVENTORY_STATE_IN_SYNC, nil},
+		{"Mixed-case RRset name", mixedCase, true, []powerdns.RRset{identical}, INVENTORY_STATE_IN_SYNC, nil},
+		{"Different records", rrset, true, []powerdns.RRset{different}, INVENTORY_STATE_DRIFTED, []string{"- test.example.org. 300 IN A 2.2.2.2", "+ test.example.org. 300 IN A 1.1.1.1"}},
+		{"Absent RRset removed", absent, true, []powerdns.RRset{otherType}, INVENTORY_STATE_IN_SYNC, nil},
+		{"Absent RRset present", absent, true, []powerdns.RRset{different}, INVENTORY_STATE_DRIFTED, []string{"- test.example.org. 300 IN A 2.2.2.2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, changes := getRRsetInventoryState(tc.rrset, tc.zoneFound, tc.externalRRsets, DEFAULT_TTL, "")
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if diff := cmp.Diff(tc.wantChanges, changes); diff != "" {
+				t.Errorf("unexpected changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetZoneInventoryRRsets(t *testing.T) {
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "team-a"}}
+	clusterZone := &dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}}
+	rrsets := []dnsv1alpha2.GenericRRset{
+		&dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "same-namespace", Namespace: "team-a"}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}},
+		&dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "team-b"}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}},
+		&dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "cluster-zone", Namespace: "team-b"}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}}},
+		&dnsv1alpha2.ClusterRRset{ObjectMeta: metav1.ObjectMeta{Name: "cluster-rrset"}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}}},
+		&dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "other-zone", Namespace: "team-a"}, Spec: dnsv1alpha2.RRsetSpec{ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.com", Kind: "Zone"}}},
+	}
+
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		want        []string
+	}{
+		{"Zone", zone, []string{"same-namespace"}},
+		{"ClusterZone", clusterZone, []string{"cluster-zone", "cluster-rrset"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := []string{}
+			for _, rrset := range getZoneInventoryRRsets(tc.zone, rrsets) {
+				got = append(got, rrset.GetName())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected RRsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
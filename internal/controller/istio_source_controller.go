@@ -0,0 +1,270 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ISTIO_GATEWAY_KIND         = "Gateway"
+	ISTIO_VIRTUAL_SERVICE_KIND = "VirtualService"
+	// ISTIO_MESH_GATEWAY is the reserved gateway name of the sidecars of the mesh
+	ISTIO_MESH_GATEWAY = "mesh"
+)
+
+// istioGroupVersion is the API of the Istio networking resources, read as unstructured objects
+var istioGroupVersion = schema.GroupVersion{Group: "networking.istio.io", Version: "v1"}
+
+// IstioSourceReconciler publishes records for the Istio Gateways or VirtualServices annotated with a zone:
+// the hosts of the Gateway servers, or of the VirtualService, point to the load balancer addresses of the
+// ingress gateway Services selected by the Gateways. The targets can be set with an annotation instead.
+type IstioSourceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Kind is the reconciled Istio kind, Gateway or VirtualService
+	Kind string
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=networking.istio.io,resources=gateways;virtualservices,verbs=get;list;watch
+
+func (r *IstioSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile Istio "+r.Kind, r.Kind+".Name", req.Name)
+
+	obj := newIstioObject(r.Kind)
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The generated RRsets are controlled by their zone, they are deleted with the object
+		if err := deleteSourceRRsets(ctx, req.NamespacedName, istioGroupVersion.WithKind(r.Kind), "", r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	// The RRsets of a previous object of the same name are not taken over
+	if err := deleteSourceRRsets(ctx, req.NamespacedName, istioGroupVersion.WithKind(r.Kind), obj.GetUID(), r.Client); err != nil {
+		log.Error(err, "Failed to delete generated RRsets")
+		return ctrl.Result{}, err
+	}
+
+	// The RRsets of an object being deleted or not annotated anymore are deleted
+	desired := []dnsv1alpha2.RRset{}
+	if obj.GetDeletionTimestamp().IsZero() && hasAnnotation(obj, SOURCE_ZONE_ANNOTATION) {
+		settings, err := getSourceSettings(obj.GetAnnotations(), getIstioHosts(obj)...)
+		if err != nil {
+			// A new reconciliation is triggered on the modification of the object
+			log.Error(err, "Invalid annotations, the records are not published")
+			return ctrl.Result{}, nil
+		}
+		targets, err := r.getTargets(ctx, obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		desired = getTargetRRsets(obj.GetName(), settings, targets)
+	}
+
+	if _, err := reconcileGeneratedRRsets(ctx, obj, desired, r.Scheme, r.Client); err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// getTargets return the targets of the annotation, or the load balancer addresses of the ingress gateways of the object
+func (r *IstioSourceReconciler) getTargets(ctx context.Context, obj *unstructured.Unstructured) ([]string, error) {
+	if targets, ok := obj.GetAnnotations()[SOURCE_TARGET_ANNOTATION]; ok {
+		return splitSourceTargets(targets), nil
+	}
+	gateways := []*unstructured.Unstructured{obj}
+	if r.Kind == ISTIO_VIRTUAL_SERVICE_KIND {
+		gateways = nil
+		for _, key := range getIstioGatewayKeys(obj) {
+			gateway := newIstioObject(ISTIO_GATEWAY_KIND)
+			if err := r.Get(ctx, key, gateway); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					return nil, err
+				}
+				continue
+			}
+			gateways = append(gateways, gateway)
+		}
+	}
+
+	targets := []string{}
+	for _, gateway := range gateways {
+		selector, _, _ := unstructured.NestedStringMap(gateway.Object, "spec", "selector")
+		if len(selector) == 0 {
+			continue
+		}
+		var services corev1.ServiceList
+		if err := r.List(ctx, &services, client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(selector)}); err != nil {
+			return nil, err
+		}
+		for _, svc := range services.Items {
+			targets = append(targets, getServiceLoadBalancerTargets(&svc)...)
+		}
+	}
+	slices.Sort(targets)
+	return slices.Compact(targets), nil
+}
+
+// newIstioObject return an empty unstructured object of an Istio networking kind
+func newIstioObject(kind string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(istioGroupVersion.WithKind(kind))
+	return obj
+}
+
+// getIstioHosts return the hosts of a VirtualService, or of the servers of a Gateway, without their namespace
+// prefix ("namespace/host") and without the catch-all "*" host
+func getIstioHosts(obj *unstructured.Unstructured) []string {
+	var hosts []string
+	if obj.GetKind() == ISTIO_GATEWAY_KIND {
+		servers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "servers")
+		for _, server := range servers {
+			if s, ok := server.(map[string]any); ok {
+				serverHosts, _, _ := unstructured.NestedStringSlice(s, "hosts")
+				hosts = append(hosts, serverHosts...)
+			}
+		}
+	} else {
+		hosts, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "hosts")
+	}
+	result := []string{}
+	for _, host := range hosts {
+		if i := strings.LastIndex(host, "/"); i >= 0 {
+			host = host[i+1:]
+		}
+		if host != "" && host != "*" && !slices.Contains(result, host) {
+			result = append(result, host)
+		}
+	}
+	return result
+}
+
+// getIstioGatewayKeys return the keys of the Gateways of a VirtualService ("namespace/name", or "name" in the
+// namespace of the VirtualService), except the mesh
+func getIstioGatewayKeys(vs *unstructured.Unstructured) []client.ObjectKey {
+	gateways, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "gateways")
+	keys := []client.ObjectKey{}
+	for _, gateway := range gateways {
+		if gateway == ISTIO_MESH_GATEWAY || gateway == "" {
+			continue
+		}
+		key := client.ObjectKey{Namespace: vs.GetNamespace(), Name: gateway}
+		if namespace, name, ok := strings.Cut(gateway, "/"); ok {
+			key = client.ObjectKey{Namespace: namespace, Name: name}
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// getServiceLoadBalancerTargets return the load balancer IP addresses or hostnames, and the external IPs, of a Service
+func getServiceLoadBalancerTargets(svc *corev1.Service) []string {
+	targets := slices.Clone(svc.Spec.ExternalIPs)
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			targets = append(targets, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			targets = append(targets, ingress.Hostname)
+		}
+	}
+	return targets
+}
+
+// enqueueIstioSources return an event handler enqueuing the annotated objects of the reconciled kind
+// whose targets depend on a Service or a Gateway
+func (r *IstioSourceReconciler) enqueueIstioSources() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(istioGroupVersion.WithKind(r.Kind + "List"))
+		if err := r.List(ctx, list); err != nil {
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for i := range list.Items {
+			item := &list.Items[i]
+			if !hasAnnotation(item, SOURCE_ZONE_ANNOTATION) || hasAnnotation(item, SOURCE_TARGET_ANNOTATION) {
+				continue
+			}
+			if r.dependsOn(ctx, item, obj) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item)})
+			}
+		}
+		return requests
+	})
+}
+
+// dependsOn return True if the targets of the object depend on the Service or the Gateway
+func (r *IstioSourceReconciler) dependsOn(ctx context.Context, item *unstructured.Unstructured, obj client.Object) bool {
+	if gateway, ok := obj.(*unstructured.Unstructured); ok {
+		return slices.Contains(getIstioGatewayKeys(item), client.ObjectKeyFromObject(gateway))
+	}
+	gateways := []*unstructured.Unstructured{item}
+	if r.Kind == ISTIO_VIRTUAL_SERVICE_KIND {
+		gateways = nil
+		for _, key := range getIstioGatewayKeys(item) {
+			gateway := newIstioObject(ISTIO_GATEWAY_KIND)
+			if err := r.Get(ctx, key, gateway); err == nil {
+				gateways = append(gateways, gateway)
+			}
+		}
+	}
+	for _, gateway := range gateways {
+		selector, _, _ := unstructured.NestedStringMap(gateway.Object, "spec", "selector")
+		if len(selector) > 0 && labels.SelectorFromSet(selector).Matches(labels.Set(obj.GetLabels())) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IstioSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("istio-"+strings.ToLower(r.Kind)+"-source").
+		For(newIstioObject(r.Kind), builder.WithPredicates(annotatedSourcePredicate(SOURCE_ZONE_ANNOTATION))).
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner).
+		Watches(&corev1.Service{}, r.enqueueIstioSources())
+	if r.Kind == ISTIO_VIRTUAL_SERVICE_KIND {
+		b = b.Watches(newIstioObject(ISTIO_GATEWAY_KIND), r.enqueueIstioSources())
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
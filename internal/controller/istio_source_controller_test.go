@@ -0,0 +1,103 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetIstioHosts(t *testing.T) {
+	gateway := newIstioObject(ISTIO_GATEWAY_KIND)
+	gateway.Object["spec"] = map[string]any{
+		"servers": []any{
+			map[string]any{"hosts": []any{"web/www.example.org", "*"}},
+			map[string]any{"hosts": []any{"./api.example.org", "www.example.org"}},
+		},
+	}
+	vs := newIstioObject(ISTIO_VIRTUAL_SERVICE_KIND)
+	vs.Object["spec"] = map[string]any{"hosts": []any{"shop.example.org", "reviews"}}
+
+	var testCases = []struct {
+		description string
+		obj         *unstructured.Unstructured
+		want        []string
+	}{
+		{"Gateway servers", gateway, []string{"www.example.org", "api.example.org"}},
+		{"VirtualService", vs, []string{"shop.example.org", "reviews"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := getIstioHosts(tc.obj); !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetIstioGatewayKeys(t *testing.T) {
+	vs := newIstioObject(ISTIO_VIRTUAL_SERVICE_KIND)
+	vs.SetNamespace("shop")
+	vs.Object["spec"] = map[string]any{"gateways": []any{"mesh", "public", "istio-system/ingress", "public"}}
+	want := []client.ObjectKey{{Namespace: "shop", Name: "public"}, {Namespace: "istio-system", Name: "ingress"}}
+	if got := getIstioGatewayKeys(vs); !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGetServiceLoadBalancerTargets(t *testing.T) {
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{ExternalIPs: []string{"198.51.100.1"}},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+			{IP: "192.0.2.1"},
+			{Hostname: "lb.example.com"},
+		}}},
+	}
+	want := []string{"198.51.100.1", "192.0.2.1", "lb.example.com"}
+	if got := getServiceLoadBalancerTargets(svc); !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGetTargetRRsets(t *testing.T) {
+	var testCases = []struct {
+		description string
+		targets     []string
+		ipFamily    string
+		want        map[string][]string
+	}{
+		{"IPv4 and IPv6 addresses", []string{"192.0.2.2", "2001:db8::1", "192.0.2.1"}, "", map[string][]string{"gw-a-www.example.org": {"192.0.2.1", "192.0.2.2"}, "gw-aaaa-www.example.org": {"2001:db8::1"}}},
+		{"IPv4 only", []string{"192.0.2.2", "2001:db8::1", "::ffff:192.0.2.1"}, IP_FAMILY_IPV4, map[string][]string{"gw-a-www.example.org": {"192.0.2.1", "192.0.2.2"}}},
+		{"IPv6 only", []string{"192.0.2.2", "2001:db8::1"}, IP_FAMILY_IPV6, map[string][]string{"gw-aaaa-www.example.org": {"2001:db8::1"}}},
+		{"Hostnames ignored with addresses", []string{"lb.example.com", "192.0.2.1"}, "", map[string][]string{"gw-a-www.example.org": {"192.0.2.1"}}},
+		{"Single CNAME", []string{"lb2.example.com", "lb1.example.com"}, "", map[string][]string{"gw-cname-www.example.org": {"lb1.example.com."}}},
+		{"No target", nil, "", map[string][]string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			settings := sourceSettings{Hostnames: []string{"www.example.org."}, IPFamily: tc.ipFamily}
+			got := map[string][]string{}
+			for _, rrset := range getTargetRRsets("gw", settings, tc.targets) {
+				got[rrset.Name] = rrset.Spec.Records
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected RRsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
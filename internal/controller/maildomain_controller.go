@@ -0,0 +1,177 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	MailDomainReasonInvalidPolicy    = "InvalidPolicy"
+	MailDomainReasonGenerationFailed = "GenerationFailed"
+	MailDomainReasonGenerated        = "RRsetsGenerated"
+	MailDomainMessageGenerated       = "Mail RRsets generated from the mail domain"
+)
+
+// MailDomainReconciler reconciles a MailDomain object
+type MailDomainReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=maildomains,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=maildomains/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=maildomains/finalizers,verbs=update
+
+func (r *MailDomainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile MailDomain", "MailDomain.Name", req.Name)
+
+	// MailDomain
+	md := &dnsv1alpha2.MailDomain{}
+	err := r.Get(ctx, req.NamespacedName, md)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !md.DeletionTimestamp.IsZero() {
+		// The generated RRsets are controlled by their zone, they are deleted with the MailDomain
+		if err := deleteGeneratedRRsets(ctx, md, r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := addGeneratorFinalizer(ctx, md, r.Client); err != nil {
+		log.Error(err, "Failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	desired, err := getMailDomainRRsets(md)
+	if err != nil {
+		if err := patchMailDomainStatus(ctx, md, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  MailDomainReasonInvalidPolicy,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch MailDomain status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	names, err := reconcileGeneratedRRsets(ctx, md, desired, r.Scheme, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		if err := patchMailDomainStatus(ctx, md, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  MailDomainReasonGenerationFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch MailDomain status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := patchMailDomainStatus(ctx, md, names, SUCCEEDED_STATUS, r.Client, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  MailDomainReasonGenerated,
+		Message: MailDomainMessageGenerated,
+	}); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch MailDomain status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getMailDomainRRsets return the MX, SPF, DKIM and DMARC RRsets described by the MailDomain
+func getMailDomainRRsets(md *dnsv1alpha2.MailDomain) ([]dnsv1alpha2.RRset, error) {
+	domain := ptr.Deref(md.Spec.Name, makeCanonical(md.Spec.ZoneRef.Name))
+	newRRset := func(suffix, rrType, name string, records []string) dnsv1alpha2.RRset {
+		return dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(md.Name, suffix)},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Type:    rrType,
+				Name:    name,
+				TTL:     md.Spec.TTL,
+				Records: records,
+				ZoneRef: md.Spec.ZoneRef,
+			},
+		}
+	}
+
+	desired := []dnsv1alpha2.RRset{}
+	if len(md.Spec.MX) > 0 {
+		desired = append(desired, newRRset("mx", "MX", domain, mxRecords(md.Spec.MX)))
+	}
+	if md.Spec.SPF != nil {
+		record, err := spfRecord(*md.Spec.SPF)
+		if err != nil {
+			return nil, err
+		}
+		desired = append(desired, newRRset("spf", "TXT", domain, []string{record}))
+	}
+	for _, dkim := range md.Spec.DKIM {
+		desired = append(desired, newRRset("dkim-"+dkim.Selector, "TXT", getMailDomainSubName(domain, dkim.Selector+"._domainkey"), []string{dkimRecord(dkim)}))
+	}
+	if md.Spec.DMARC != nil {
+		desired = append(desired, newRRset("dmarc", "TXT", getMailDomainSubName(domain, "_dmarc"), []string{dmarcRecord(*md.Spec.DMARC)}))
+	}
+	return desired, nil
+}
+
+func patchMailDomainStatus(ctx context.Context, md *dnsv1alpha2.MailDomain, rrsets []string, status string, cl client.Client, condition metav1.Condition) error {
+	original := md.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&md.Status.Conditions, condition)
+	if rrsets != nil {
+		md.Status.RRsets = rrsets
+	}
+	md.Status.SyncStatus = ptr.To(status)
+	md.Status.ObservedGeneration = ptr.To(md.GetGeneration())
+	return cl.Status().Patch(ctx, md, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MailDomainReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.MailDomain{}).
+		Watches(&dnsv1alpha2.MailDomain{}, enqueueDeletionsFirst()).
+		// Generated RRsets modified by a third party are restored
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
@@ -0,0 +1,150 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("MailDomain Controller", func() {
+
+	const (
+		zoneName          = "example12.org"
+		resourceName      = "corp"
+		resourceNamespace = "example12"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example12.org", "ns2.example12.org"}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	mxNamespacedName := types.NamespacedName{Name: resourceName + "-mx", Namespace: resourceNamespace}
+	dmarcNamespacedName := types.NamespacedName{Name: resourceName + "-dmarc", Namespace: resourceNamespace}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance MailDomain")
+		md := &dnsv1alpha2.MailDomain{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, md); err == nil {
+			Expect(k8sClient.Delete(ctx, md)).To(Succeed())
+		}
+
+		By("Checking the generated RRsets are deleted with their generator")
+		for _, n := range []types.NamespacedName{mxNamespacedName, dmarcNamespacedName} {
+			rrset := &dnsv1alpha2.RRset{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, n, rrset)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		}
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	Context("When creating a MailDomain", func() {
+		It("should generate the mail RRsets and keep them consistent", Label("maildomain-creation"), func() {
+			ctx := context.Background()
+			By("Creating the MailDomain")
+			md := &dnsv1alpha2.MailDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.MailDomainSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					MX:      []dnsv1alpha2.MailServer{{Host: "mx1.example12.org.", Preference: 10}},
+					DMARC:   &dnsv1alpha2.DMARCPolicy{Policy: "reject"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, md)).To(Succeed())
+
+			By("Getting the generated RRsets")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, md)
+				return err == nil && md.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(md.Status.RRsets).To(Equal([]string{dmarcNamespacedName.Name, mxNamespacedName.Name}))
+			mx := &dnsv1alpha2.RRset{}
+			Expect(k8sClient.Get(ctx, mxNamespacedName, mx)).To(Succeed())
+			Expect(mx.Spec.Type).To(Equal("MX"))
+			Expect(mx.Spec.Name).To(Equal(zoneName + "."))
+			Expect(mx.Spec.Records).To(Equal([]string{"10 mx1.example12.org."}))
+			dmarc := &dnsv1alpha2.RRset{}
+			Expect(k8sClient.Get(ctx, dmarcNamespacedName, dmarc)).To(Succeed())
+			Expect(dmarc.Spec.Name).To(Equal("_dmarc." + zoneName + "."))
+			Expect(dmarc.Spec.Records).To(Equal([]string{`"v=DMARC1; p=reject"`}))
+
+			By("Modifying a generated RRset")
+			mx.Spec.Records = []string{"10 mx.elsewhere.org."}
+			Expect(k8sClient.Update(ctx, mx)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, mxNamespacedName, mx)
+				return err == nil && len(mx.Spec.Records) == 1 && mx.Spec.Records[0] == "10 mx1.example12.org."
+			}, timeout, interval).Should(BeTrue())
+
+			By("Removing the DMARC policy")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, md)).To(Succeed())
+			md.Spec.DMARC = nil
+			Expect(k8sClient.Update(ctx, md)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, dmarcNamespacedName, dmarc)
+				return errors.IsNotFound(err) || (err == nil && !dmarc.DeletionTimestamp.IsZero())
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,120 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// TXT_CHARACTER_STRING_LENGTH is the maximum length of a character string of a TXT record
+	TXT_CHARACTER_STRING_LENGTH = 255
+	DEFAULT_SPF_ALL             = "SoftFail"
+	DEFAULT_DKIM_KEY_TYPE       = "rsa"
+)
+
+var spfAllQualifiers = map[string]string{
+	"Fail":     "-all",
+	"SoftFail": "~all",
+	"Neutral":  "?all",
+}
+
+// txtRecordContent return the content of a TXT record, split in character strings of at most 255 characters
+func txtRecordContent(in string) string {
+	chunks := []string{}
+	for len(in) > TXT_CHARACTER_STRING_LENGTH {
+		chunks = append(chunks, quoteCharacterString(in[:TXT_CHARACTER_STRING_LENGTH]))
+		in = in[TXT_CHARACTER_STRING_LENGTH:]
+	}
+	chunks = append(chunks, quoteCharacterString(in))
+	return strings.Join(chunks, " ")
+}
+
+// mxRecords return the MX records of the mail servers, sorted
+func mxRecords(servers []dnsv1alpha2.MailServer) []string {
+	records := make([]string, 0, len(servers))
+	for _, s := range servers {
+		records = append(records, fmt.Sprintf("%d %s", s.Preference, s.Host))
+	}
+	slices.Sort(records)
+	return slices.Compact(records)
+}
+
+// spfRecord return the SPF record (RFC 7208) of the policy
+func spfRecord(spf dnsv1alpha2.SPFPolicy) (string, error) {
+	terms := []string{"v=spf1"}
+	if ptr.Deref(spf.MX, true) {
+		terms = append(terms, "mx")
+	}
+	for _, ip := range spf.IPs {
+		prefix, err := netip.ParsePrefix(ip)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(ip)
+			if addrErr != nil {
+				return "", fmt.Errorf("invalid IP address or network %s", ip)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		mechanism := "ip4"
+		if prefix.Addr().Is6() {
+			mechanism = "ip6"
+		}
+		value := prefix.Masked().String()
+		if prefix.IsSingleIP() {
+			value = prefix.Addr().String()
+		}
+		terms = append(terms, mechanism+":"+value)
+	}
+	for _, include := range spf.Includes {
+		terms = append(terms, "include:"+strings.TrimSuffix(include, "."))
+	}
+	terms = append(terms, spfAllQualifiers[ptr.Deref(spf.All, DEFAULT_SPF_ALL)])
+	return txtRecordContent(strings.Join(terms, " ")), nil
+}
+
+// dkimRecord return the DKIM key record (RFC 6376) of the selector
+func dkimRecord(dkim dnsv1alpha2.DKIMSelector) string {
+	return txtRecordContent(fmt.Sprintf("v=DKIM1; k=%s; p=%s", ptr.Deref(dkim.KeyType, DEFAULT_DKIM_KEY_TYPE), dkim.PublicKey))
+}
+
+// dmarcRecord return the DMARC record (RFC 7489) of the policy
+func dmarcRecord(dmarc dnsv1alpha2.DMARCPolicy) string {
+	tags := []string{"v=DMARC1", "p=" + dmarc.Policy}
+	if dmarc.SubdomainPolicy != nil {
+		tags = append(tags, "sp="+*dmarc.SubdomainPolicy)
+	}
+	if dmarc.Percentage != nil {
+		tags = append(tags, fmt.Sprintf("pct=%d", *dmarc.Percentage))
+	}
+	if len(dmarc.AggregateReports) > 0 {
+		tags = append(tags, "rua="+strings.Join(dmarc.AggregateReports, ","))
+	}
+	if len(dmarc.FailureReports) > 0 {
+		tags = append(tags, "ruf="+strings.Join(dmarc.FailureReports, ","))
+	}
+	return txtRecordContent(strings.Join(tags, "; "))
+}
+
+// getMailDomainSubName return the name of a record under the mail domain (e.g. "_dmarc.example.org." for the apex)
+func getMailDomainSubName(domain, label string) string {
+	if strings.HasSuffix(domain, ".") {
+		return makeCanonical(label + "." + domain)
+	}
+	return label + "." + domain
+}
@@ -0,0 +1,116 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestTxtRecordContent(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	var testCases = []struct {
+		description string
+		in          string
+		want        string
+	}{
+		{"Short value", "v=spf1 -all", `"v=spf1 -all"`},
+		{"Quotes", `say "hello"`, `"say \"hello\""`},
+		{"Long value", long, `"` + long[:255] + `" "` + long[255:] + `"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := txtRecordContent(tc.in); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSpfRecord(t *testing.T) {
+	var testCases = []struct {
+		description string
+		spf         dnsv1alpha2.SPFPolicy
+		want        string
+		wantErr     bool
+	}{
+		{"Default policy", dnsv1alpha2.SPFPolicy{}, `"v=spf1 mx ~all"`, false},
+		{"Addresses and includes", dnsv1alpha2.SPFPolicy{
+			MX:       ptr.To(false),
+			IPs:      []string{"192.0.2.1", "198.51.100.7/24", "2001:db8::/32"},
+			Includes: []string{"_spf.google.com."},
+			All:      ptr.To("Fail"),
+		}, `"v=spf1 ip4:192.0.2.1 ip4:198.51.100.0/24 ip6:2001:db8::/32 include:_spf.google.com -all"`, false},
+		{"Invalid address", dnsv1alpha2.SPFPolicy{IPs: []string{"mx.example.org"}}, "", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := spfRecord(tc.spf)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetMailDomainRRsets(t *testing.T) {
+	md := &dnsv1alpha2.MailDomain{}
+	md.Name = "corp"
+	md.Spec = dnsv1alpha2.MailDomainSpec{
+		ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"},
+		MX: []dnsv1alpha2.MailServer{
+			{Host: "mx2.example.org.", Preference: 20},
+			{Host: "mx1.example.org.", Preference: 10},
+		},
+		DKIM: []dnsv1alpha2.DKIMSelector{{Selector: "mail", KeyType: ptr.To("ed25519"), PublicKey: "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}},
+		DMARC: &dnsv1alpha2.DMARCPolicy{
+			Policy:           "reject",
+			SubdomainPolicy:  ptr.To("none"),
+			Percentage:       ptr.To(int32(50)),
+			AggregateReports: []string{"mailto:dmarc@example.org", "mailto:dmarc@example.com"},
+		},
+	}
+
+	rrsets, err := getMailDomainRRsets(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	for _, rrset := range rrsets {
+		got[rrset.Name+" "+rrset.Spec.Type+" "+rrset.Spec.Name] = rrset.Spec.Records
+	}
+	want := map[string][]string{
+		"corp-mx MX example.org.":                         {"10 mx1.example.org.", "20 mx2.example.org."},
+		"corp-dkim-mail TXT mail._domainkey.example.org.": {`"v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="`},
+		"corp-dmarc TXT _dmarc.example.org.":              {`"v=DMARC1; p=reject; sp=none; pct=50; rua=mailto:dmarc@example.org,mailto:dmarc@example.com"`},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	md.Spec.Name = ptr.To("lists")
+	rrsets, err = getMailDomainRRsets(md)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rrsets[len(rrsets)-1].Spec.Name != "_dmarc.lists" {
+		t.Errorf("got %s, want _dmarc.lists", rrsets[len(rrsets)-1].Spec.Name)
+	}
+}
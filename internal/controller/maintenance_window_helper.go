@@ -0,0 +1,59 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	RrsetReasonPendingWindow            = "PendingWindow"
+	RrsetMessagePendingWindow           = "Changes queued until the next maintenance window, opening at"
+	RrsetReasonInvalidMaintenanceWindow = "InvalidMaintenanceWindow"
+)
+
+// getMaintenanceWindows return the maintenance windows of the zone, or else the maintenance windows of the operator
+func getMaintenanceWindows(zone dnsv1alpha2.GenericZone, windows []dnsv1alpha2.MaintenanceWindow) []dnsv1alpha2.MaintenanceWindow {
+	if len(zone.GetSpec().MaintenanceWindows) != 0 {
+		return zone.GetSpec().MaintenanceWindows
+	}
+	return windows
+}
+
+// getMaintenanceWindowState return True if one of the maintenance windows is open at the time, or if there is
+// no window, and otherwise the time the next window opens
+func getMaintenanceWindowState(windows []dnsv1alpha2.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	var next time.Time
+	for _, window := range windows {
+		schedule, err := cron.ParseStandard(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid maintenance window schedule %q: %w", window.Schedule, err)
+		}
+		// The first start after the beginning of a window ending now
+		start := schedule.Next(now.Add(-window.Duration.Duration))
+		if !start.After(now) {
+			return true, time.Time{}, nil
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetMaintenanceWindowState(t *testing.T) {
+	// Saturday 2025-01-04 and Sunday 2025-01-05
+	saturday := dnsv1alpha2.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	sunday := dnsv1alpha2.MaintenanceWindow{Schedule: "0 22 * * 0", Duration: metav1.Duration{Duration: time.Hour}}
+	var testCases = []struct {
+		description string
+		windows     []dnsv1alpha2.MaintenanceWindow
+		now         time.Time
+		wantOpen    bool
+		wantNext    time.Time
+		wantErr     bool
+	}{
+		{"No window", nil, time.Date(2025, 1, 3, 12, 0, 0, 0, time.UTC), true, time.Time{}, false},
+		{"Window start", []dnsv1alpha2.MaintenanceWindow{saturday}, time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC), true, time.Time{}, false},
+		{"Inside window", []dnsv1alpha2.MaintenanceWindow{saturday}, time.Date(2025, 1, 4, 3, 59, 0, 0, time.UTC), true, time.Time{}, false},
+		{"Window end", []dnsv1alpha2.MaintenanceWindow{saturday}, time.Date(2025, 1, 4, 4, 0, 0, 0, time.UTC), false, time.Date(2025, 1, 11, 2, 0, 0, 0, time.UTC), false},
+		{"Earliest next window", []dnsv1alpha2.MaintenanceWindow{saturday, sunday}, time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC), false, time.Date(2025, 1, 5, 22, 0, 0, 0, time.UTC), false},
+		{"Second window open", []dnsv1alpha2.MaintenanceWindow{saturday, sunday}, time.Date(2025, 1, 5, 22, 30, 0, 0, time.UTC), true, time.Time{}, false},
+		{"Invalid schedule", []dnsv1alpha2.MaintenanceWindow{{Schedule: "every saturday"}}, time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC), false, time.Time{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			open, next, err := getMaintenanceWindowState(tc.windows, tc.now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v", err)
+			}
+			if open != tc.wantOpen || !next.Equal(tc.wantNext) {
+				t.Errorf("got %v %v, want %v %v", open, next, tc.wantOpen, tc.wantNext)
+			}
+		})
+	}
+}
+
+func TestGetMaintenanceWindows(t *testing.T) {
+	operator := []dnsv1alpha2.MaintenanceWindow{{Schedule: "0 2 * * *"}}
+	zone := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{MaintenanceWindows: []dnsv1alpha2.MaintenanceWindow{{Schedule: "0 3 * * 6"}}}}
+	if got := getMaintenanceWindows(zone, operator); got[0].Schedule != "0 3 * * 6" {
+		t.Errorf("got %v, want the windows of the zone", got)
+	}
+	if got := getMaintenanceWindows(&dnsv1alpha2.Zone{}, operator); got[0].Schedule != "0 2 * * *" {
+		t.Errorf("got %v, want the windows of the operator", got)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZoneReasonMasterServicesUnavailable = "MasterServicesUnavailable"
+)
+
+// getMasterServiceNamespace return the namespace of the Service of a primary of the zone
+func getMasterServiceNamespace(zone dnsv1alpha2.GenericZone, ref dnsv1alpha2.ServiceReference) string {
+	if zone.GetNamespace() != "" {
+		return zone.GetNamespace()
+	}
+	return ptr.Deref(ref.Namespace, "")
+}
+
+// getMasterServicesIndexKeys return the keys the zones are indexed with, one per Service of their primaries
+func getMasterServicesIndexKeys(zone dnsv1alpha2.GenericZone) []string {
+	keys := []string{}
+	for _, ref := range zone.GetSpec().MasterServices {
+		keys = append(keys, getMasterServiceNamespace(zone, ref)+"/"+ref.Name)
+	}
+	return keys
+}
+
+// getServiceAddresses return the IP addresses of a Service: its LoadBalancer ingress IPs, or its ClusterIPs
+func getServiceAddresses(svc *corev1.Service) []string {
+	addresses := []string{}
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			}
+		}
+		if len(addresses) != 0 {
+			return addresses
+		}
+	}
+	for _, ip := range svc.Spec.ClusterIPs {
+		if ip != "" && ip != corev1.ClusterIPNone {
+			addresses = append(addresses, ip)
+		}
+	}
+	return addresses
+}
+
+// getMasterAddress return the address of a primary, with its port if any (e.g. "192.0.2.1:5300", "[2001:db8::1]:5300")
+func getMasterAddress(ip string, port *int32) string {
+	if port == nil {
+		return ip
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(int(*port)))
+}
+
+// resolveMasterServices return the masters of the zone, with the addresses of the Services of its primaries
+func resolveMasterServices(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Reader) ([]string, error) {
+	masters := slices.Clone(zone.GetSpec().Masters)
+	for _, ref := range zone.GetSpec().MasterServices {
+		namespace := getMasterServiceNamespace(zone, ref)
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace of the Service %s is required", ref.Name)
+		}
+		svc := &corev1.Service{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, svc); err != nil {
+			return nil, err
+		}
+		addresses := getServiceAddresses(svc)
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("no IP address for the Service %s/%s", namespace, ref.Name)
+		}
+		for _, ip := range addresses {
+			if address := getMasterAddress(ip, ref.Port); !slices.Contains(masters, address) {
+				masters = append(masters, address)
+			}
+		}
+	}
+	return masters, nil
+}
+
+// enqueueForReferencingResources return an event handler enqueuing the resources of a list referencing an object,
+// indexed on the field by its namespace/name (the Service of a primary or of a target, the ConfigMap of a zone file)
+func enqueueForReferencingResources(cl client.Client, list client.ObjectList, field string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		l := list.DeepCopyObject().(client.ObjectList)
+		if err := cl.List(ctx, l, client.MatchingFields{field: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+			return nil
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			if o, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+			}
+		}
+		return requests
+	})
+}
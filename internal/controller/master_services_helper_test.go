@@ -0,0 +1,65 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetServiceAddresses(t *testing.T) {
+	var testCases = []struct {
+		description string
+		svc         *corev1.Service
+		want        []string
+	}{
+		{"ClusterIP", &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIPs: []string{"10.0.0.53", "fd00::53"}}}, []string{"10.0.0.53", "fd00::53"}},
+		{"Headless", &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIPs: []string{corev1.ClusterIPNone}}}, []string{}},
+		{"LoadBalancer", &corev1.Service{
+			Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIPs: []string{"10.0.0.53"}},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "192.0.2.53"}, {Hostname: "lb.example.org"}}}},
+		}, []string{"192.0.2.53"}},
+		{"Pending LoadBalancer", &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIPs: []string{"10.0.0.53"}}}, []string{"10.0.0.53"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, getServiceAddresses(tc.svc)); diff != "" {
+				t.Errorf("unexpected addresses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetMasterAddress(t *testing.T) {
+	var testCases = []struct {
+		description string
+		ip          string
+		port        *int32
+		want        string
+	}{
+		{"Default port", "10.0.0.53", nil, "10.0.0.53"},
+		{"IPv4 with port", "10.0.0.53", ptr.To(int32(5300)), "10.0.0.53:5300"},
+		{"IPv6 with port", "fd00::53", ptr.To(int32(5300)), "[fd00::53]:5300"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := getMasterAddress(tc.ip, tc.port); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
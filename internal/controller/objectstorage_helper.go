@@ -0,0 +1,117 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	S3_ACCESS_KEY_ID_KEY     = "accessKeyID"
+	S3_SECRET_ACCESS_KEY_KEY = "secretAccessKey"
+)
+
+type objectStorageClienter interface {
+	Put(ctx context.Context, bucket, key string, content []byte) error
+	Get(ctx context.Context, bucket, key string) ([]byte, error)
+	List(ctx context.Context, bucket, prefix string) ([]string, error)
+	Remove(ctx context.Context, bucket, key string) error
+}
+
+// ObjectStorageClientFactory create a client for the S3-compatible object storage of a backup target
+type ObjectStorageClientFactory func(target dnsv1alpha2.S3BackupTarget, accessKeyID, secretAccessKey string) (objectStorageClienter, error)
+
+type minioObjectStorageClient struct {
+	client *minio.Client
+}
+
+// NewMinioObjectStorageClient is the default ObjectStorageClientFactory
+func NewMinioObjectStorageClient(target dnsv1alpha2.S3BackupTarget, accessKeyID, secretAccessKey string) (objectStorageClienter, error) {
+	client, err := minio.New(target.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: !ptr.Deref(target.Insecure, false),
+		Region: ptr.Deref(target.Region, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &minioObjectStorageClient{client: client}, nil
+}
+
+func (c *minioObjectStorageClient) Put(ctx context.Context, bucket, key string, content []byte) error {
+	_, err := c.client.PutObject(ctx, bucket, key, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{ContentType: "text/dns"})
+	return err
+}
+
+func (c *minioObjectStorageClient) Get(ctx context.Context, bucket, key string) ([]byte, error) {
+	object, err := c.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = object.Close() }()
+	return io.ReadAll(object)
+}
+
+func (c *minioObjectStorageClient) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	keys := []string{}
+	for object := range c.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+func (c *minioObjectStorageClient) Remove(ctx context.Context, bucket, key string) error {
+	return c.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+// newObjectStorageClientForTarget create a client for a S3 target, with the credentials of its Secret
+func newObjectStorageClientForTarget(ctx context.Context, cl client.Client, namespace string, target dnsv1alpha2.S3BackupTarget, factory ObjectStorageClientFactory) (objectStorageClienter, error) {
+	credentials := &corev1.Secret{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: target.CredentialsSecretRef.Name}, credentials); err != nil {
+		return nil, err
+	}
+	if factory == nil {
+		factory = NewMinioObjectStorageClient
+	}
+	return factory(target, string(credentials.Data[S3_ACCESS_KEY_ID_KEY]), string(credentials.Data[S3_SECRET_ACCESS_KEY_KEY]))
+}
+
+// getZoneBackupObjectPrefix return the prefix of the object keys of the backups of a zone
+func getZoneBackupObjectPrefix(target dnsv1alpha2.S3BackupTarget, zoneName string) string {
+	return ptr.Deref(target.Prefix, "") + strings.TrimSuffix(makeCanonical(zoneName), ".") + "/"
+}
+
+// expiredZoneBackupObjects return the object keys exceeding the retention, the oldest first.
+// Keys are timestamped, so their lexical order is their chronological order.
+func expiredZoneBackupObjects(keys []string, retention *int32) []string {
+	if retention == nil || len(keys) <= int(*retention) {
+		return nil
+	}
+	sorted := append([]string{}, keys...)
+	slices.Sort(sorted)
+	return sorted[:len(sorted)-int(*retention)]
+}
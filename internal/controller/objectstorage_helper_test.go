@@ -0,0 +1,69 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetZoneBackupObjectPrefix(t *testing.T) {
+	var testCases = []struct {
+		description string
+		target      dnsv1alpha2.S3BackupTarget
+		zoneName    string
+		want        string
+	}{
+		{"Without prefix", dnsv1alpha2.S3BackupTarget{}, "example.org", "example.org/"},
+		{"With prefix", dnsv1alpha2.S3BackupTarget{Prefix: ptr.To("backups/")}, "example.org.", "backups/example.org/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			prefix := getZoneBackupObjectPrefix(tc.target, tc.zoneName)
+			if !cmp.Equal(prefix, tc.want) {
+				t.Errorf("got %v, want %v", prefix, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpiredZoneBackupObjects(t *testing.T) {
+	var (
+		oldest = "example.org/20250101T030000Z.zone"
+		middle = "example.org/20250102T030000Z.zone"
+		newest = "example.org/20250103T030000Z.zone"
+	)
+
+	var testCases = []struct {
+		description string
+		keys        []string
+		retention   *int32
+		want        []string
+	}{
+		{"No retention", []string{oldest, middle, newest}, nil, nil},
+		{"Retention not exceeded", []string{oldest, middle}, ptr.To(int32(2)), nil},
+		{"Retention exceeded", []string{newest, oldest, middle}, ptr.To(int32(1)), []string{oldest, middle}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			expired := expiredZoneBackupObjects(tc.keys, tc.retention)
+			if !cmp.Equal(expired, tc.want) {
+				t.Errorf("got %v, want %v", expired, tc.want)
+			}
+		})
+	}
+}
@@ -13,12 +13,21 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joeig/go-powerdns/v3"
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -28,10 +37,21 @@ const (
 	SUCCEEDED_STATUS = "Succeeded"
 )
 
+// Categories of the synchronization failures, reported as the reason of the Available condition:
+// AuthError and InvalidRecord are permanent, ZoneMissing, ServerUnavailable and Conflict are transient
+const (
+	FailureReasonAuthError         = "AuthError"
+	FailureReasonZoneMissing       = "ZoneMissing"
+	FailureReasonInvalidRecord     = "InvalidRecord"
+	FailureReasonServerUnavailable = "ServerUnavailable"
+	FailureReasonConflict          = "Conflict"
+)
+
 type pdnsRecordsClienter interface {
 	Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error
 	Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...func(*powerdns.RRset)) error
 	Get(ctx context.Context, domain, name string, recordType *powerdns.RRType) ([]powerdns.RRset, error)
+	Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error
 }
 
 type pdnsZonesClienter interface {
@@ -39,44 +59,436 @@ type pdnsZonesClienter interface {
 	Delete(ctx context.Context, domain string) error
 	Change(ctx context.Context, domain string, zone *powerdns.Zone) error
 	Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error)
+	AxfrRetrieve(ctx context.Context, domain string) (*powerdns.AxfrRetrieveResult, error)
+	Export(ctx context.Context, domain string) (powerdns.Export, error)
+}
+
+type pdnsCryptokeysClienter interface {
+	List(ctx context.Context, domain string) ([]powerdns.Cryptokey, error)
+}
+
+type pdnsMetadataClienter interface {
+	Get(ctx context.Context, domain string, kind powerdns.MetadataKind) (*powerdns.Metadata, error)
+	Set(ctx context.Context, domain string, kind powerdns.MetadataKind, values []string) (*powerdns.Metadata, error)
+	Delete(ctx context.Context, domain string, kind powerdns.MetadataKind) error
 }
 
 type PdnsClienter struct {
-	Records pdnsRecordsClienter
-	Zones   pdnsZonesClienter
+	Records    pdnsRecordsClienter
+	Zones      pdnsZonesClienter
+	Cryptokeys pdnsCryptokeysClienter
+	Metadata   pdnsMetadataClienter
+	// Peers are the PowerDNS servers serving the same data, the zones are checked for consistency against them
+	Peers []PdnsPeer
+	// SOASerials queries the serials served by the primaries of the secondary zones, over DNS by default
+	SOASerials soaSerialQuerier
+}
+
+// PdnsPeer is a PowerDNS server serving the same data as the PowerDNS server managed by the operator
+type PdnsPeer struct {
+	URL   string
+	Zones pdnsZonesClienter
 }
 
-// zoneIsIdenticalToExternalZone return True, True if respectively kind, soa_edit_api and catalog are identical
-// and nameservers are identical between Zone and External Resource
+// zoneIsIdenticalToExternalZone return True, True if respectively kind, soa_edit_api, catalog, presigned and,
+// for secondary zones declaring their primaries, masters are identical and nameservers are identical between Zone and External Resource
 func zoneIsIdenticalToExternalZone(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone, ns []string) (bool, bool) {
 	zoneCatalog := makeCanonical(ptr.Deref(zone.GetSpec().Catalog, ""))
 	externalZoneCatalog := ptr.Deref(externalZone.Catalog, "")
 	zoneSOAEditAPI := ptr.Deref(zone.GetSpec().SOAEditAPI, "")
 	externalZoneSOAEditAPI := ptr.Deref(externalZone.SOAEditAPI, "")
-	return zone.GetSpec().Kind == string(*externalZone.Kind) && zoneCatalog == externalZoneCatalog && zoneSOAEditAPI == externalZoneSOAEditAPI, reflect.DeepEqual(zone.GetSpec().Nameservers, ns)
+	zonePresigned := ptr.Deref(zone.GetSpec().Presigned, false)
+	externalZonePresigned := ptr.Deref(externalZone.Presigned, false)
+	// PowerDNS keeps the masters of a zone turned into a primary, they are only compared for secondary zones
+	// declaring their primaries, through the masters or the Services of the primaries
+	mastersDeclared := len(zone.GetSpec().Masters) > 0 || len(zone.GetSpec().MasterServices) > 0
+	mastersIdentical := !isSecondaryZone(zone) || !mastersDeclared || slices.Equal(slices.Sorted(slices.Values(zone.GetSpec().Masters)), slices.Sorted(slices.Values(externalZone.Masters)))
+	return zone.GetSpec().Kind == string(*externalZone.Kind) && zoneCatalog == externalZoneCatalog && zoneSOAEditAPI == externalZoneSOAEditAPI && zonePresigned == externalZonePresigned && mastersIdentical, reflect.DeepEqual(zone.GetSpec().Nameservers, ns)
+}
+
+// getPDNSErrorStatusCode return the HTTP status code of a PowerDNS API error, 0 for other errors
+func getPDNSErrorStatusCode(err error) int {
+	var pdnsErr *powerdns.Error
+	if errors.As(err, &pdnsErr) {
+		return pdnsErr.StatusCode
+	}
+	var pdnsErrValue powerdns.Error
+	if errors.As(err, &pdnsErrValue) {
+		return pdnsErrValue.StatusCode
+	}
+	return 0
+}
+
+// getFailureReason return the category of a synchronization failure, or the fallback reason for an uncategorized error
+func getFailureReason(err error, fallback string) string {
+	switch code := getPDNSErrorStatusCode(err); {
+	case code == 401 || code == 403:
+		return FailureReasonAuthError
+	case code == 404:
+		return FailureReasonZoneMissing
+	case code == 400 || code == 422:
+		return FailureReasonInvalidRecord
+	case code == 409:
+		return FailureReasonConflict
+	case code == 429 || code >= 500:
+		return FailureReasonServerUnavailable
+	}
+	// The PowerDNS API is not reachable
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return FailureReasonServerUnavailable
+	}
+	return fallback
+}
+
+// isTransientFailureReason return True if the failure is expected to be resolved without any change of the resource:
+// the synchronization is retried with an exponential backoff
+func isTransientFailureReason(reason string) bool {
+	return reason == FailureReasonZoneMissing || reason == FailureReasonServerUnavailable || reason == FailureReasonConflict ||
+		reason == ZoneReasonMasterServicesUnavailable || reason == ZoneReasonZoneFileUnavailable ||
+		reason == RrsetReasonTargetUnavailable || reason == RrsetReasonUnmanagedRecords
+}
+
+// isPermanentFailureReason return True if the failure is only resolved by a change of the resource, or of the
+// operator configuration: the resource is parked until then
+func isPermanentFailureReason(reason string) bool {
+	return reason == FailureReasonAuthError || reason == FailureReasonInvalidRecord || reason == ZoneReasonZoneFileInvalid ||
+		reason == RrsetReasonNamespaceNotAllowed || reason == RrsetReasonInvalidMaintenanceWindow
 }
 
-// rrsetIsIdenticalToExternalRRset return True if Comments, Name, Type, TTL and Records are identical between RRSet and External Resource
-func rrsetIsIdenticalToExternalRRset(rrset dnsv1alpha2.GenericRRset, externalRecord powerdns.RRset) bool {
-	commentsIdentical := true
-	if len(externalRecord.Comments) != 0 {
-		if rrset.GetSpec().Comment != nil {
-			commentsIdentical = *rrset.GetSpec().Comment == *(externalRecord.Comments[0].Content)
-		} else {
-			commentsIdentical = false
+// isTransientFailure return True if the last synchronization of the resource failed with a transient error
+func isTransientFailure(conditions []metav1.Condition) bool {
+	condition := meta.FindStatusCondition(conditions, "Available")
+	return condition != nil && condition.Status == metav1.ConditionFalse && isTransientFailureReason(condition.Reason)
+}
+
+// setStalledCondition set the Stalled condition when the Available condition reports a permanent failure, and remove it otherwise
+func setStalledCondition(conditions *[]metav1.Condition, available metav1.Condition) {
+	if available.Status != metav1.ConditionFalse || !isPermanentFailureReason(available.Reason) {
+		meta.RemoveStatusCondition(conditions, "Stalled")
+		return
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               "Stalled",
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: available.LastTransitionTime,
+		Reason:             available.Reason,
+		Message:            "Not retried until the resource is modified: " + available.Message,
+	})
+}
+
+// getAppliedHash return the hash of the desired state of the RRset in PowerDNS: its zone (a recreated zone
+// has another UID), its specification and the operator settings shaping its records
+func getAppliedHash(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, defaultTTL uint32, clusterID string) string {
+	data, _ := json.Marshal(struct {
+		Zone       string
+		ZoneUID    string
+		Spec       dnsv1alpha2.RRsetSpec
+		DefaultTTL uint32
+		ClusterID  string
+	}{zone.GetName(), string(zone.GetUID()), *rrset.GetSpec(), defaultTTL, clusterID})
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// getZoneSerial return the serial of the SOA record of the zone in PowerDNS
+func getZoneSerial(ctx context.Context, zoneName string, PDNSClient PdnsClienter) (*uint32, error) {
+	rrsets, err := PDNSClient.Records.Get(ctx, zoneName, makeCanonical(zoneName), ptr.To(powerdns.RRTypeSOA))
+	if err != nil {
+		return nil, err
+	}
+	for _, rrset := range rrsets {
+		if ptr.Deref(rrset.Type, "") != powerdns.RRTypeSOA || len(rrset.Records) == 0 {
+			continue
 		}
-	} else {
-		if rrset.GetSpec().Comment != nil {
-			commentsIdentical = false
+		fields := strings.Fields(ptr.Deref(rrset.Records[0].Content, ""))
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid SOA record of the zone %s", zoneName)
 		}
+		serial, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid serial of the zone %s: %w", zoneName, err)
+		}
+		return ptr.To(uint32(serial)), nil
+	}
+	return nil, fmt.Errorf("no SOA record in the zone %s", zoneName)
+}
+
+// isAlreadyApplied return True if the desired state of the RRset was successfully applied, and the zone was not
+// modified since: PowerDNS does not need to be queried
+func isAlreadyApplied(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone, appliedHash string) bool {
+	status := rrset.GetStatus()
+	zoneSerial := zone.GetStatus().Serial
+	return ptr.Deref(status.SyncStatus, "") == SUCCEEDED_STATUS &&
+		ptr.Deref(status.AppliedHash, "") == appliedHash &&
+		zoneSerial != nil && ptr.Deref(status.AppliedZoneSerial, 0) == *zoneSerial
+}
+
+// descriptionIsIdenticalToExternalMetadata return True if the description of the Zone is the value of the external metadata,
+// a Zone without description matching no value
+func descriptionIsIdenticalToExternalMetadata(zone dnsv1alpha2.GenericZone, externalMetadata *powerdns.Metadata) bool {
+	if zone.GetSpec().Description == nil {
+		return len(externalMetadata.Metadata) == 0
 	}
+	return slices.Equal(externalMetadata.Metadata, []string{*zone.GetSpec().Description})
+}
 
+// rrsetIsIdenticalToExternalRRset return True if Comments, Name, Type, TTL and Records are identical between RRSet and External Resource,
+// and if none of the external records is disabled
+func rrsetIsIdenticalToExternalRRset(rrset dnsv1alpha2.GenericRRset, externalRecord powerdns.RRset, defaultTTL uint32, clusterID string) bool {
 	externalRecordsSlice := make([]string, 0, len(externalRecord.Records))
+	recordsEnabled := true
 	for _, r := range externalRecord.Records {
 		externalRecordsSlice = append(externalRecordsSlice, *r.Content)
+		recordsEnabled = recordsEnabled && !ptr.Deref(r.Disabled, false)
 	}
+	slices.Sort(externalRecordsSlice)
 	name := getRRsetName(rrset)
-	return name == *externalRecord.Name && rrset.GetSpec().Type == string(*externalRecord.Type) && rrset.GetSpec().TTL == *(externalRecord.TTL) && commentsIdentical && reflect.DeepEqual(rrset.GetSpec().Records, externalRecordsSlice)
+	// The names are lowercase in PowerDNS
+	return strings.EqualFold(name, ptr.Deref(externalRecord.Name, "")) && rrset.GetSpec().Type == string(ptr.Deref(externalRecord.Type, "")) &&
+		getRRsetTTL(rrset, defaultTTL) == ptr.Deref(externalRecord.TTL, 0) && commentsAreIdentical(rrset, externalRecord, clusterID) &&
+		recordsEnabled && reflect.DeepEqual(getRRsetDesiredRecords(rrset, &externalRecord), externalRecordsSlice)
+}
+
+// commentsAreIdentical return True if the external RRset has the single comment of the RRset, set by the operator account,
+// or no comment if the RRset has none. With the Patch strategy, the comments are left as is if the RRset has none.
+func commentsAreIdentical(rrset dnsv1alpha2.GenericRRset, externalRecord powerdns.RRset, clusterID string) bool {
+	comment := getRRsetComment(rrset, clusterID)
+	if comment == nil {
+		return len(externalRecord.Comments) == 0 || isPatchStrategy(rrset)
+	}
+	return len(externalRecord.Comments) == 1 &&
+		ptr.Deref(externalRecord.Comments[0].Content, "") == *comment.Content &&
+		ptr.Deref(externalRecord.Comments[0].Account, "") == *comment.Account
+}
+
+// getRRsetComment return the comment written by the operator on the records of the RRset, nil if there is none.
+// With a cluster id, a comment is always written: its account records the operator instance owning the records.
+func getRRsetComment(rrset dnsv1alpha2.GenericRRset, clusterID string) *powerdns.Comment {
+	if rrset.GetSpec().Comment == nil && clusterID == "" {
+		return nil
+	}
+	return &powerdns.Comment{
+		Content: ptr.To(ptr.Deref(rrset.GetSpec().Comment, OWNER_COMMENT)),
+		Account: ptr.To(getOwnerAccount(clusterID)),
+	}
+}
+
+// getOwnerAccount return the comment account of the operator instance of the cluster id
+func getOwnerAccount(clusterID string) string {
+	if clusterID == "" {
+		return PDNS_COMMENT_ACCOUNT
+	}
+	return PDNS_COMMENT_ACCOUNT + "/" + clusterID
+}
+
+// getExternalRRsetOwner return the cluster id recorded in the operator comment of the external RRset,
+// and False if the external RRset has no operator comment
+func getExternalRRsetOwner(externalRecord powerdns.RRset) (string, bool) {
+	for _, c := range externalRecord.Comments {
+		account := ptr.Deref(c.Account, "")
+		if account == PDNS_COMMENT_ACCOUNT {
+			return "", true
+		}
+		if clusterID, ok := strings.CutPrefix(account, PDNS_COMMENT_ACCOUNT+"/"); ok && clusterID != "" {
+			return clusterID, true
+		}
+	}
+	return "", false
+}
+
+// getOwnershipConflict return the cluster id of another operator instance owning the external RRset, or an empty string.
+// The records without cluster id, created manually or by an instance without cluster id, are adopted.
+func getOwnershipConflict(externalRecord *powerdns.RRset, clusterID string) string {
+	if externalRecord == nil {
+		return ""
+	}
+	if owner, _ := getExternalRRsetOwner(*externalRecord); owner != "" && owner != clusterID {
+		return owner
+	}
+	return ""
+}
+
+// isDryRun return True if the writes to PowerDNS are disabled, globally or through annotation on the resource
+func isDryRun(obj metav1.Object, dryRun bool) bool {
+	return dryRun || obj.GetAnnotations()[DRY_RUN_ANNOTATION] == DRY_RUN_ANNOTATION_VALUE
+}
+
+// isProtectedApexRRset return True if the RRset would overwrite the SOA record or the NS records of the zone apex,
+// managed by the zone, without the override annotation
+func isProtectedApexRRset(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone) bool {
+	rrType := strings.ToUpper(rrset.GetSpec().Type)
+	if rrType != string(powerdns.RRTypeSOA) && rrType != string(powerdns.RRTypeNS) {
+		return false
+	}
+	return strings.EqualFold(getRRsetName(rrset), makeCanonical(zone.GetName())) &&
+		rrset.GetAnnotations()[APEX_OVERRIDE_ANNOTATION] != APEX_OVERRIDE_ANNOTATION_VALUE
+}
+
+// rrsetPendingChanges return the records to remove ("-") and to add ("+") in PowerDNS to synchronize the external RRset with the RRset
+func rrsetPendingChanges(rrset dnsv1alpha2.GenericRRset, externalRecord *powerdns.RRset, defaultTTL uint32, clusterID string) []string {
+	if isAbsentRRset(rrset) {
+		if externalRecord == nil {
+			return nil
+		}
+		return rrsetDiffLines("-", *externalRecord)
+	}
+	if externalRecord != nil && rrsetIsIdenticalToExternalRRset(rrset, *externalRecord, defaultTTL, clusterID) {
+		return nil
+	}
+	desired := powerdns.RRset{
+		Name:    ptr.To(getRRsetName(rrset)),
+		Type:    ptr.To(powerdns.RRType(rrset.GetSpec().Type)),
+		TTL:     ptr.To(getRRsetTTL(rrset, defaultTTL)),
+		Records: toPdnsRecords(getRRsetDesiredRecords(rrset, externalRecord)),
+	}
+	changes := []string{}
+	externalComment := ""
+	if externalRecord != nil {
+		changes = append(changes, rrsetDiffLines("-", *externalRecord)...)
+		if len(externalRecord.Comments) != 0 {
+			externalComment = ptr.Deref(externalRecord.Comments[0].Content, "")
+		}
+	}
+	changes = append(changes, rrsetDiffLines("+", desired)...)
+	if comment := ptr.Deref(rrset.GetSpec().Comment, ""); comment != externalComment && !(isPatchStrategy(rrset) && rrset.GetSpec().Comment == nil) {
+		changes = append(changes, fmt.Sprintf("~ comment %q -> %q", externalComment, comment))
+	}
+	return changes
+}
+
+// isSecondaryZone return True if the zone content is retrieved from primaries through zone transfers
+func isSecondaryZone(zone dnsv1alpha2.GenericZone) bool {
+	return isSecondaryKind(powerdns.ZoneKind(zone.GetSpec().Kind))
+}
+
+// isSecondaryKind return True if the zone kind retrieves its content from primaries
+func isSecondaryKind(kind powerdns.ZoneKind) bool {
+	return kind == powerdns.SlaveZoneKind || kind == powerdns.ConsumerZoneKind
+}
+
+// isBecomingSecondaryZone return True if the existing external zone is turned into a secondary zone with primaries,
+// its content must be retrieved from them
+func isBecomingSecondaryZone(zone dnsv1alpha2.GenericZone, externalZone *powerdns.Zone) bool {
+	return externalZone.Name != nil && isSecondaryZone(zone) && len(zone.GetSpec().Masters) > 0 &&
+		!isSecondaryKind(ptr.Deref(externalZone.Kind, ""))
+}
+
+// dnssecKeyRolloverRemainingDays return the number of days before the oldest active key exceeds the rollover policy,
+// and False if there is no active key
+func dnssecKeyRolloverRemainingDays(keys []dnsv1alpha2.DNSSECKey, rolloverDays uint32, now time.Time) (float64, bool) {
+	var oldest *time.Time
+	for _, k := range keys {
+		if k.Active && (oldest == nil || k.FirstSeen.Time.Before(*oldest)) {
+			oldest = &k.FirstSeen.Time
+		}
+	}
+	if oldest == nil {
+		return 0, false
+	}
+	deadline := oldest.Add(time.Duration(rolloverDays) * 24 * time.Hour)
+	return deadline.Sub(now).Hours() / 24, true
+}
+
+// dsRecordsFromKeys return the sorted SHA-256 DS records of the active key signing keys
+func dsRecordsFromKeys(keys []dnsv1alpha2.DNSSECKey) []string {
+	ds := []string{}
+	for _, k := range keys {
+		if !k.Active || (k.KeyType != "ksk" && k.KeyType != "csk") {
+			continue
+		}
+		for _, d := range k.DS {
+			// DS format: <key tag> <algorithm> <digest type> <digest>
+			if fields := strings.Fields(d); len(fields) == 4 && fields[2] == DS_SHA256_DIGEST_TYPE {
+				ds = append(ds, d)
+			}
+		}
+	}
+	slices.Sort(ds)
+	return slices.Compact(ds)
+}
+
+// isOperatorManagedRRset return True if the external RRset has been created by the operator
+func isOperatorManagedRRset(externalRecord powerdns.RRset) bool {
+	_, ok := getExternalRRsetOwner(externalRecord)
+	return ok
+}
+
+// isClusterManagedRRset return True if the external RRset has been created by the operator instance of the cluster id,
+// or by an instance without cluster id, adopted as by getOwnershipConflict
+func isClusterManagedRRset(externalRecord powerdns.RRset, clusterID string) bool {
+	owner, ok := getExternalRRsetOwner(externalRecord)
+	return ok && (owner == "" || owner == clusterID)
+}
+
+// isWrittenByZone return True if the external RRset has been written by a zone of the operator instance of the cluster id,
+// marked with the comment of the zone (e.g. the delegation comment), and not by a RRset
+func isWrittenByZone(externalRecord powerdns.RRset, comment string, clusterID string) bool {
+	return isClusterManagedRRset(externalRecord, clusterID) && slices.ContainsFunc(externalRecord.Comments, func(c powerdns.Comment) bool {
+		return ptr.Deref(c.Content, "") == comment
+	})
+}
+
+// getPurgedRRsets return the deletion of all the RRsets of the zone, except the SOA and NS ones
+// and the ones owned by the operator instance of another cluster
+func getPurgedRRsets(externalZone *powerdns.Zone, clusterID string) *powerdns.RRsets {
+	purged := &powerdns.RRsets{}
+	for _, rrset := range externalZone.RRsets {
+		rrType := ptr.Deref(rrset.Type, "")
+		if rrType == powerdns.RRTypeSOA || rrType == powerdns.RRTypeNS || getOwnershipConflict(&rrset, clusterID) != "" {
+			continue
+		}
+		purged.Sets = append(purged.Sets, powerdns.RRset{Name: rrset.Name, Type: rrset.Type, ChangeType: ptr.To(powerdns.ChangeTypeDelete)})
+	}
+	return purged
+}
+
+// getClonedRRsets return the RRsets of the source zone renamed in the target zone, except the ones managed
+// through the zone itself (SOA and apex NS) and the ones published on behalf of child zones
+func getClonedRRsets(sourceZone *powerdns.Zone, source, target string) []powerdns.RRset {
+	cloned := []powerdns.RRset{}
+	for _, rrset := range sourceZone.RRsets {
+		if isZoneManagedRRset(source, rrset) || isPublishedForChildZone(rrset) {
+			continue
+		}
+		name := strings.TrimSuffix(ptr.Deref(rrset.Name, ""), makeCanonical(source)) + makeCanonical(target)
+		cloned = append(cloned, powerdns.RRset{
+			Name:       ptr.To(name),
+			Type:       rrset.Type,
+			TTL:        rrset.TTL,
+			ChangeType: ptr.To(powerdns.ChangeTypeReplace),
+			Records:    rrset.Records,
+		})
+	}
+	return cloned
+}
+
+// isInZone return True if the canonical name is the zone apex or belongs to the zone
+func isInZone(name, zone string) bool {
+	return name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// toPdnsRecords convert record contents to PowerDNS records
+func toPdnsRecords(contents []string) []powerdns.Record {
+	records := make([]powerdns.Record, 0, len(contents))
+	for _, c := range contents {
+		records = append(records, powerdns.Record{Content: ptr.To(c), Disabled: ptr.To(false)})
+	}
+	return records
+}
+
+// recordsContent return the sorted contents of the records of a PowerDNS RRset
+func recordsContent(rrset powerdns.RRset) []string {
+	contents := make([]string, 0, len(rrset.Records))
+	for _, r := range rrset.Records {
+		contents = append(contents, ptr.Deref(r.Content, ""))
+	}
+	slices.Sort(contents)
+	return contents
+}
+
+// getZoneFileKey return the key a zone file is stored under in a ConfigMap or a Secret
+func getZoneFileKey(zoneName string) string {
+	return strings.TrimSuffix(makeCanonical(zoneName), ".") + ZONE_FILE_EXTENSION
 }
 
 func makeCanonical(in string) string {
@@ -87,6 +499,183 @@ func makeCanonical(in string) string {
 	return result
 }
 
+// rrsetConflictingTypes are, for a type, the types of the RRsets which cannot exist with the same name
+var rrsetConflictingTypes = map[string][]string{
+	string(powerdns.RRTypeALIAS): {string(powerdns.RRTypeA), string(powerdns.RRTypeAAAA)},
+	string(powerdns.RRTypeA):     {string(powerdns.RRTypeALIAS)},
+	string(powerdns.RRTypeAAAA):  {string(powerdns.RRTypeALIAS)},
+	string(powerdns.RRTypeDNAME): {string(powerdns.RRTypeCNAME)},
+	string(powerdns.RRTypeCNAME): {string(powerdns.RRTypeDNAME)},
+}
+
+// getDNAMEIndexKeys return the keys the DNAME RRsets are indexed with: their owner name
+func getDNAMEIndexKeys(rrset dnsv1alpha2.GenericRRset) []string {
+	if rrset.GetSpec().Type != string(powerdns.RRTypeDNAME) {
+		return nil
+	}
+	return []string{getRRsetName(rrset)}
+}
+
+// getAncestorNames return the names above a name, from its parent to the top-level domain
+func getAncestorNames(name string) []string {
+	var ancestors []string
+	for _, parent, found := strings.Cut(name, "."); found && parent != ""; _, parent, found = strings.Cut(parent, ".") {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// getDNAMEConflictingRRset return the name of a RRset conflicting with a DNAME redirection, or an empty string:
+// a DNAME RRset cannot have RRsets below its owner name, and a RRset cannot be below the owner name of a DNAME RRset.
+// Only the RRsets in Succeeded status or not yet reconciled are considered, as the RRsets indexed by DNS entry.
+func getDNAMEConflictingRRset(rrset dnsv1alpha2.GenericRRset, rrsets []dnsv1alpha2.GenericRRset) string {
+	name := getRRsetName(rrset)
+	isDNAME := rrset.GetSpec().Type == string(powerdns.RRTypeDNAME)
+	for _, other := range rrsets {
+		syncStatus := other.GetStatus().SyncStatus
+		if (syncStatus != nil && *syncStatus != SUCCEEDED_STATUS) || !other.GetDeletionTimestamp().IsZero() || isAbsentRRset(other) {
+			continue
+		}
+		otherName := getRRsetName(other)
+		if isDNAME && otherName != name && isInZone(otherName, name) {
+			return otherName
+		}
+		if other.GetSpec().Type == string(powerdns.RRTypeDNAME) && otherName != name && isInZone(name, otherName) {
+			return otherName
+		}
+	}
+	return ""
+}
+
+// getRRsetRecords return the records of the RRset in their canonical order: sorted and without duplicates
+func getRRsetRecords(rrset dnsv1alpha2.GenericRRset) []string {
+	records := slices.Clone(rrset.GetSpec().Records)
+	for _, r := range rrset.GetSpec().NAPTR {
+		records = append(records, naptrRecordContent(r))
+	}
+	slices.Sort(records)
+	return slices.Compact(records)
+}
+
+// isPatchStrategy return True if the records of the RRset are added to the external RRset instead of replacing its records
+func isPatchStrategy(rrset dnsv1alpha2.GenericRRset) bool {
+	return ptr.Deref(rrset.GetSpec().Strategy, "") == RRSET_PATCH_STRATEGY
+}
+
+// isMergeStrategy return True if the records of the RRset are merged with the records of the other RRsets
+// using the Merge strategy for the same name and type
+func isMergeStrategy(rrset dnsv1alpha2.GenericRRset) bool {
+	return ptr.Deref(rrset.GetSpec().Strategy, "") == RRSET_MERGE_STRATEGY
+}
+
+// areSharedRRsets return True if all the RRsets use the Patch strategy, or all the Merge strategy:
+// each of them manages its own records of the same name and type
+func areSharedRRsets(rrsets []dnsv1alpha2.GenericRRset) bool {
+	return !slices.ContainsFunc(rrsets, func(rrset dnsv1alpha2.GenericRRset) bool { return !isPatchStrategy(rrset) }) ||
+		!slices.ContainsFunc(rrsets, func(rrset dnsv1alpha2.GenericRRset) bool { return !isMergeStrategy(rrset) })
+}
+
+// isMergeContributor return True if the records of the contributor are merged with the records of the RRset:
+// it uses the Merge strategy in the same zone, and is published, neither absent nor being deleted
+func isMergeContributor(rrset dnsv1alpha2.GenericRRset, contributor dnsv1alpha2.GenericRRset, now time.Time) bool {
+	sameZone := rrset.GetSpec().ZoneRef == contributor.GetSpec().ZoneRef &&
+		(rrset.GetSpec().ZoneRef.Kind == "ClusterZone" || rrset.GetNamespace() == contributor.GetNamespace())
+	return isMergeStrategy(contributor) && sameZone && isActiveRRset(contributor, now) && !isAbsentRRset(contributor) &&
+		contributor.GetDeletionTimestamp().IsZero()
+}
+
+// getMergedRRset return a copy of the RRset holding the union of the records of the contributing RRsets
+// using the Merge strategy, with their lowest TTL. It is applied to PowerDNS as a whole.
+func getMergedRRset(rrset dnsv1alpha2.GenericRRset, contributors []dnsv1alpha2.GenericRRset, defaultTTL uint32, now time.Time) dnsv1alpha2.GenericRRset {
+	merged := rrset.Copy()
+	records := []string{}
+	var ttl *uint32
+	for _, contributor := range contributors {
+		if !isMergeContributor(rrset, contributor, now) {
+			continue
+		}
+		records = append(records, getRRsetRecords(contributor)...)
+		if contributorTTL := getRRsetTTL(contributor, defaultTTL); ttl == nil || contributorTTL < *ttl {
+			ttl = ptr.To(contributorTTL)
+		}
+	}
+	slices.Sort(records)
+	merged.GetSpec().Records = slices.Compact(records)
+	merged.GetSpec().NAPTR = nil
+	merged.GetSpec().TTL = ttl
+	return merged
+}
+
+// isDuplicatedRRset return True if the RRset is Failed because another RRset owns its name and type
+func isDuplicatedRRset(rrset dnsv1alpha2.GenericRRset) bool {
+	status := rrset.GetStatus()
+	condition := meta.FindStatusCondition(status.Conditions, "Available")
+	return ptr.Deref(status.SyncStatus, "") == FAILED_STATUS && condition != nil && condition.Reason == RrsetReasonDuplicated
+}
+
+// hasBeenApplied return True if the RRset was already successfully applied to PowerDNS: the records it finds are its own
+func hasBeenApplied(rrset dnsv1alpha2.GenericRRset) bool {
+	return rrset.GetStatus().AppliedHash != nil || ptr.Deref(rrset.GetStatus().SyncStatus, "") == SUCCEEDED_STATUS
+}
+
+// isCheckingUnmanagedRecords return True if the records not written by the operator must be looked for before the
+// RRset is applied: on its first apply only, when it replaces the records, and unless it wins the conflict
+// with the priority policy (unmanaged records have no priority)
+func isCheckingUnmanagedRecords(rrset dnsv1alpha2.GenericRRset, conflictPolicy string) bool {
+	if hasBeenApplied(rrset) || isAbsentRRset(rrset) || isPatchStrategy(rrset) || isMergeStrategy(rrset) {
+		return false
+	}
+	return conflictPolicy != CONFLICT_POLICY_PRIORITY || ptr.Deref(rrset.GetSpec().Priority, 0) <= 0
+}
+
+// hasUnmanagedRecords return True if the external RRset holds records, other than the records of the RRset,
+// not written by the operator
+func hasUnmanagedRecords(rrset dnsv1alpha2.GenericRRset, externalRecord *powerdns.RRset) bool {
+	if externalRecord == nil || len(externalRecord.Records) == 0 || isOperatorManagedRRset(*externalRecord) {
+		return false
+	}
+	return !slices.Equal(recordsContent(*externalRecord), getRRsetRecords(rrset))
+}
+
+// isAbsentRRset return True if the RRset must not exist in the zone
+func isAbsentRRset(rrset dnsv1alpha2.GenericRRset) bool {
+	return ptr.Deref(rrset.GetSpec().Ensure, "") == RRSET_ABSENT_ENSURE
+}
+
+// getRRsetDesiredRecords return the records the external RRset must contain, in their canonical order.
+// With the Patch strategy, the records of the external RRset not managed by the RRset are kept, except the removed records.
+func getRRsetDesiredRecords(rrset dnsv1alpha2.GenericRRset, externalRecord *powerdns.RRset) []string {
+	if !isPatchStrategy(rrset) || externalRecord == nil {
+		return getRRsetRecords(rrset)
+	}
+	records := append(recordsContent(*externalRecord), getRRsetRecords(rrset)...)
+	records = slices.DeleteFunc(records, func(r string) bool {
+		return slices.Contains(rrset.GetSpec().RemovedRecords, r)
+	})
+	slices.Sort(records)
+	return slices.Compact(records)
+}
+
+// naptrRecordContent return the content of a NAPTR record, in the presentation format:
+// order preference "flags" "service" "regexp" replacement
+func naptrRecordContent(r dnsv1alpha2.NAPTRRecord) string {
+	replacement := makeCanonical(r.Replacement)
+	if replacement == "" {
+		replacement = "."
+	}
+	return fmt.Sprintf("%d %d %s %s %s %s", r.Order, r.Preference, quoteCharacterString(r.Flags), quoteCharacterString(r.Service), quoteCharacterString(r.Regexp), replacement)
+}
+
+// quoteCharacterString return the character string quoted, with the backslashes and quotes escaped
+func quoteCharacterString(in string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(in) + `"`
+}
+
+// getRRsetTTL return the TTL of the RRset, or the default TTL if not specified
+func getRRsetTTL(rrset dnsv1alpha2.GenericRRset, defaultTTL uint32) uint32 {
+	return ptr.Deref(rrset.GetSpec().TTL, defaultTTL)
+}
+
 func getRRsetName(rrset dnsv1alpha2.GenericRRset) string {
 	if !strings.HasSuffix(rrset.GetSpec().Name, ".") {
 		return makeCanonical(rrset.GetSpec().Name + "." + rrset.GetSpec().ZoneRef.Name)
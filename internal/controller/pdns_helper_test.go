@@ -12,11 +12,16 @@
 package controller
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/joeig/go-powerdns/v3"
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -168,6 +173,32 @@ func TestZoneIsIdenticalToExternalZone(t *testing.T) {
 			false,
 			true,
 		},
+		{
+			"Different Zones on Presigned",
+			&dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: dnsv1alpha2.ZoneSpec{
+					Kind:        MASTER_KIND_ZONE,
+					Nameservers: nameservers,
+					Catalog:     &catalog,
+					SOAEditAPI:  &soaEditApi,
+					Presigned:   ptr.To(true),
+				},
+			},
+			&powerdns.Zone{
+				ID:         &name,
+				Name:       &name,
+				Kind:       &kind,
+				Catalog:    &catalog,
+				SOAEditAPI: &soaEditApi,
+			},
+			nameservers,
+			false,
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -219,7 +250,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 					Comment: &recordComment1,
 					Name:    recordName,
 					Type:    recordType1,
-					TTL:     recordTtl1,
+					TTL:     ptr.To(recordTtl1),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -246,6 +277,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 				Comments: []powerdns.Comment{
 					{
 						Content: &recordComment1,
+						Account: ptr.To(PDNS_COMMENT_ACCOUNT),
 					},
 				},
 			},
@@ -262,7 +294,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 					Comment: &recordComment1,
 					Name:    recordName,
 					Type:    recordType1,
-					TTL:     recordTtl1,
+					TTL:     ptr.To(recordTtl1),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -289,6 +321,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 				Comments: []powerdns.Comment{
 					{
 						Content: &recordComment2,
+						Account: ptr.To(PDNS_COMMENT_ACCOUNT),
 					},
 				},
 			},
@@ -305,7 +338,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 					Comment: &recordComment1,
 					Name:    recordName,
 					Type:    recordType1,
-					TTL:     recordTtl1,
+					TTL:     ptr.To(recordTtl1),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -327,6 +360,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 				Comments: []powerdns.Comment{
 					{
 						Content: &recordComment1,
+						Account: ptr.To(PDNS_COMMENT_ACCOUNT),
 					},
 				},
 			},
@@ -343,7 +377,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 					Comment: &recordComment1,
 					Name:    recordName,
 					Type:    recordType1,
-					TTL:     recordTtl1,
+					TTL:     ptr.To(recordTtl1),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -370,6 +404,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 				Comments: []powerdns.Comment{
 					{
 						Content: &recordComment1,
+						Account: ptr.To(PDNS_COMMENT_ACCOUNT),
 					},
 				},
 			},
@@ -386,7 +421,7 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 					Comment: &recordComment1,
 					Name:    recordName,
 					Type:    recordType1,
-					TTL:     recordTtl1,
+					TTL:     ptr.To(recordTtl1),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -413,16 +448,26 @@ func TestRrsetIsIdenticalToExternalRRset(t *testing.T) {
 				Comments: []powerdns.Comment{
 					{
 						Content: &recordComment1,
+						Account: ptr.To(PDNS_COMMENT_ACCOUNT),
 					},
 				},
 			},
 			false,
 		},
+		{"Different RRsets on comment account", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: &recordComment1, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: records, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment1, Account: ptr.To("admin")}}}, false},
+		{"Different RRsets on additional comment", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: &recordComment1, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: records, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment1, Account: ptr.To(PDNS_COMMENT_ACCOUNT)}, {Content: &recordComment2, Account: ptr.To("admin")}}}, false},
+		{"Different RRsets on comment removal", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: nil, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: records, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment1, Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, false},
+		{"Identical RRsets without comment", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: nil, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: records, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: nil}, true},
+		{"Identical RRsets with unordered records", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: &recordComment1, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: []string{recordContent2, recordContent1}, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment1, Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, true},
+		{"Different RRsets on disabled record", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: &recordComment1, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: records, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(true)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment1, Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, false},
+		{"Identical RRsets with Patch strategy and unmanaged record", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: nil, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: []string{recordContent1}, Strategy: ptr.To(RRSET_PATCH_STRATEGY), ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}, Comments: []powerdns.Comment{{Content: &recordComment2, Account: ptr.To("admin")}}}, true},
+		{"Different RRsets with Patch strategy and removed record", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: nil, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: []string{recordContent1}, Strategy: ptr.To(RRSET_PATCH_STRATEGY), RemovedRecords: []string{recordContent2}, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}}, false},
+		{"Different RRsets with Replace strategy and unmanaged record", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: dnsv1alpha2.RRsetSpec{Comment: nil, Name: recordName, Type: recordType1, TTL: ptr.To(recordTtl1), Records: []string{recordContent1}, ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}}}, &powerdns.RRset{Name: &fqdnName, Type: (*powerdns.RRType)(&recordType1), TTL: &recordTtl1, Records: []powerdns.Record{{Content: &recordContent1, Disabled: ptr.To(false)}, {Content: &recordContent2, Disabled: ptr.To(false)}}}, false},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			ns := rrsetIsIdenticalToExternalRRset(tc.rrset, *tc.externalRrset)
+			ns := rrsetIsIdenticalToExternalRRset(tc.rrset, *tc.externalRrset, DEFAULT_TTL, "")
 			if !cmp.Equal(ns, tc.rrsetsIdentical) {
 				t.Errorf("got %v, want %v", ns, tc.rrsetsIdentical)
 			}
@@ -488,7 +533,7 @@ func TestGetRRsetName(t *testing.T) {
 					Comment: &recordComment,
 					Name:    recordName,
 					Type:    recordType,
-					TTL:     recordTtl,
+					TTL:     ptr.To(recordTtl),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -509,7 +554,7 @@ func TestGetRRsetName(t *testing.T) {
 					Comment: &recordComment,
 					Name:    recordFQDName,
 					Type:    recordType,
-					TTL:     recordTtl,
+					TTL:     ptr.To(recordTtl),
 					Records: records,
 					ZoneRef: dnsv1alpha2.ZoneRef{
 						Name: zoneName,
@@ -530,3 +575,797 @@ func TestGetRRsetName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRRsetRecords(t *testing.T) {
+	var testCases = []struct {
+		description string
+		records     []string
+		want        []string
+	}{
+		{"Sorted records", []string{"1.1.1.1", "2.2.2.2"}, []string{"1.1.1.1", "2.2.2.2"}},
+		{"Unordered records", []string{"2.2.2.2", "1.1.1.1"}, []string{"1.1.1.1", "2.2.2.2"}},
+		{"Duplicated records", []string{"2.2.2.2", "1.1.1.1", "2.2.2.2"}, []string{"1.1.1.1", "2.2.2.2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", Records: tc.records}}
+			records := getRRsetRecords(rrset)
+			if !cmp.Equal(records, tc.want) {
+				t.Errorf("got %v, want %v", records, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRRsetDesiredRecords(t *testing.T) {
+	external := &powerdns.RRset{Records: toPdnsRecords([]string{"3.3.3.3", "1.1.1.1"})}
+	var testCases = []struct {
+		description    string
+		strategy       *string
+		removedRecords []string
+		external       *powerdns.RRset
+		want           []string
+	}{
+		{"Replace strategy", nil, nil, external, []string{"1.1.1.1", "2.2.2.2"}},
+		{"Patch strategy", ptr.To(RRSET_PATCH_STRATEGY), nil, external, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}},
+		{"Patch strategy with removed records", ptr.To(RRSET_PATCH_STRATEGY), []string{"3.3.3.3"}, external, []string{"1.1.1.1", "2.2.2.2"}},
+		{"Patch strategy without external RRset", ptr.To(RRSET_PATCH_STRATEGY), nil, nil, []string{"1.1.1.1", "2.2.2.2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", Records: []string{"2.2.2.2", "1.1.1.1"}, Strategy: tc.strategy, RemovedRecords: tc.removedRecords}}
+			records := getRRsetDesiredRecords(rrset, tc.external)
+			if !cmp.Equal(records, tc.want) {
+				t.Errorf("got %v, want %v", records, tc.want)
+			}
+		})
+	}
+}
+
+func TestAreSharedRRsets(t *testing.T) {
+	patch := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Strategy: ptr.To(RRSET_PATCH_STRATEGY)}}
+	merge := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Strategy: ptr.To(RRSET_MERGE_STRATEGY)}}
+	replace := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Strategy: ptr.To("Replace")}}
+	var testCases = []struct {
+		description string
+		rrsets      []dnsv1alpha2.GenericRRset
+		want        bool
+	}{
+		{"All Patch", []dnsv1alpha2.GenericRRset{patch, patch, &dnsv1alpha2.ClusterRRset{Spec: patch.Spec}}, true},
+		{"All Merge", []dnsv1alpha2.GenericRRset{merge, &dnsv1alpha2.ClusterRRset{Spec: merge.Spec}}, true},
+		{"Patch and Merge", []dnsv1alpha2.GenericRRset{patch, merge}, false},
+		{"One Replace", []dnsv1alpha2.GenericRRset{patch, replace}, false},
+		{"Default strategy", []dnsv1alpha2.GenericRRset{merge, &dnsv1alpha2.RRset{}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := areSharedRRsets(tc.rrsets); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetMergedRRset(t *testing.T) {
+	merge := func(ttl *uint32, records ...string) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "www", Type: "A", TTL: ttl, Records: records, Strategy: ptr.To(RRSET_MERGE_STRATEGY)}}
+	}
+	rrset := merge(nil, "192.0.2.1")
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	otherZone := merge(nil, "192.0.2.4")
+	otherZone.Spec.ZoneRef = dnsv1alpha2.ZoneRef{Name: "org", Kind: "Zone"}
+	otherNamespace := merge(nil, "192.0.2.4")
+	otherNamespace.Namespace = "other"
+	inactive := merge(nil, "192.0.2.4")
+	inactive.Spec.ActiveUntil = &metav1.Time{Time: now.Add(-time.Hour)}
+	deleting := merge(nil, "192.0.2.4")
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	var testCases = []struct {
+		description  string
+		contributors []dnsv1alpha2.GenericRRset
+		wantRecords  []string
+		wantTTL      *uint32
+	}{
+		{"Single contributor", []dnsv1alpha2.GenericRRset{rrset}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"Union with the lowest TTL", []dnsv1alpha2.GenericRRset{rrset, merge(ptr.To(uint32(60)), "192.0.2.2", "192.0.2.1")}, []string{"192.0.2.1", "192.0.2.2"}, ptr.To(uint32(60))},
+		{"Other strategies ignored", []dnsv1alpha2.GenericRRset{rrset, &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Records: []string{"192.0.2.3"}}}}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"Other zone ignored", []dnsv1alpha2.GenericRRset{rrset, otherZone}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"Other namespace ignored", []dnsv1alpha2.GenericRRset{rrset, otherNamespace}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"Inactive contributor ignored", []dnsv1alpha2.GenericRRset{rrset, inactive}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"Deleting contributor ignored", []dnsv1alpha2.GenericRRset{rrset, deleting}, []string{"192.0.2.1"}, ptr.To(DEFAULT_TTL)},
+		{"No contributor", nil, []string{}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			merged := getMergedRRset(rrset, tc.contributors, DEFAULT_TTL, now)
+			if !cmp.Equal(merged.GetSpec().Records, tc.wantRecords) {
+				t.Errorf("got %v, want %v", merged.GetSpec().Records, tc.wantRecords)
+			}
+			if !cmp.Equal(merged.GetSpec().TTL, tc.wantTTL) {
+				t.Errorf("got %v, want %v", merged.GetSpec().TTL, tc.wantTTL)
+			}
+		})
+	}
+	if !cmp.Equal(rrset.Spec.Records, []string{"192.0.2.1"}) {
+		t.Errorf("the RRset has been modified: %v", rrset.Spec.Records)
+	}
+}
+
+func TestGetDNAMEConflictingRRset(t *testing.T) {
+	newRRset := func(name, rrType string, syncStatus *string) dnsv1alpha2.GenericRRset {
+		return &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: name, Type: rrType, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}, Status: dnsv1alpha2.RRsetStatus{SyncStatus: syncStatus}}
+	}
+	var testCases = []struct {
+		description string
+		rrset       dnsv1alpha2.GenericRRset
+		rrsets      []dnsv1alpha2.GenericRRset
+		want        string
+	}{
+		{"DNAME without RRset below", newRRset("legacy", "DNAME", nil), []dnsv1alpha2.GenericRRset{newRRset("legacy", "DNAME", nil), newRRset("legacy", "TXT", ptr.To(SUCCEEDED_STATUS)), newRRset("other", "A", nil)}, ""},
+		{"DNAME with RRset below", newRRset("legacy", "DNAME", nil), []dnsv1alpha2.GenericRRset{newRRset("www.legacy", "A", ptr.To(SUCCEEDED_STATUS))}, "www.legacy.example.org."},
+		{"DNAME with failed RRset below", newRRset("legacy", "DNAME", nil), []dnsv1alpha2.GenericRRset{newRRset("www.legacy", "A", ptr.To(FAILED_STATUS))}, ""},
+		{"RRset below DNAME", newRRset("www.legacy", "A", nil), []dnsv1alpha2.GenericRRset{newRRset("legacy", "DNAME", ptr.To(SUCCEEDED_STATUS))}, "legacy.example.org."},
+		{"RRset beside DNAME", newRRset("wwwlegacy", "A", nil), []dnsv1alpha2.GenericRRset{newRRset("legacy", "DNAME", ptr.To(SUCCEEDED_STATUS))}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			name := getDNAMEConflictingRRset(tc.rrset, tc.rrsets)
+			if name != tc.want {
+				t.Errorf("got %q, want %q", name, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetAncestorNames(t *testing.T) {
+	var testCases = []struct {
+		name string
+		want []string
+	}{
+		{"www.legacy.example.org.", []string{"legacy.example.org.", "example.org.", "org."}},
+		{"org.", nil},
+		{".", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := getAncestorNames(tc.name); !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNaptrRecordContent(t *testing.T) {
+	var testCases = []struct {
+		description string
+		record      dnsv1alpha2.NAPTRRecord
+		want        string
+	}{
+		{"ENUM record", dnsv1alpha2.NAPTRRecord{Order: 100, Preference: 10, Flags: "U", Service: "E2U+sip", Regexp: "!^.*$!sip:info@example.com!"}, `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{"Replacement", dnsv1alpha2.NAPTRRecord{Order: 10, Preference: 0, Flags: "S", Service: "SIP+D2U", Replacement: "_sip._udp.example.com"}, `10 0 "S" "SIP+D2U" "" _sip._udp.example.com.`},
+		{"Escaped regexp", dnsv1alpha2.NAPTRRecord{Order: 100, Preference: 10, Flags: "U", Service: "E2U+sip", Regexp: `!^\+33(.*)$!sip:\1@example.com!`}, `100 10 "U" "E2U+sip" "!^\\+33(.*)$!sip:\\1@example.com!" .`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			content := naptrRecordContent(tc.record)
+			if content != tc.want {
+				t.Errorf("got %s, want %s", content, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRRsetTTL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		ttl         *uint32
+		defaultTTL  uint32
+		want        uint32
+	}{
+		{"Specified TTL", ptr.To(uint32(300)), 3600, 300},
+		{"Specified zero TTL", ptr.To(uint32(0)), 3600, 0},
+		{"Default TTL", nil, 3600, 3600},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", TTL: tc.ttl}}
+			ttl := getRRsetTTL(rrset, tc.defaultTTL)
+			if ttl != tc.want {
+				t.Errorf("got %v, want %v", ttl, tc.want)
+			}
+		})
+	}
+}
+
+func TestDnssecKeyRolloverRemainingDays(t *testing.T) {
+	var (
+		now       = time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+		oldest    = metav1.NewTime(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+		newest    = metav1.NewTime(time.Date(2025, time.January, 21, 0, 0, 0, 0, time.UTC))
+		oldestKey = dnsv1alpha2.DNSSECKey{ID: 1, KeyType: "ksk", Active: true, FirstSeen: oldest}
+		newestKey = dnsv1alpha2.DNSSECKey{ID: 2, KeyType: "zsk", Active: true, FirstSeen: newest}
+		inactive  = dnsv1alpha2.DNSSECKey{ID: 3, KeyType: "zsk", Active: false, FirstSeen: oldest}
+	)
+
+	var testCases = []struct {
+		description  string
+		keys         []dnsv1alpha2.DNSSECKey
+		rolloverDays uint32
+		want         float64
+		found        bool
+	}{
+		{"No key", nil, 90, 0, false},
+		{"Only inactive keys", []dnsv1alpha2.DNSSECKey{inactive}, 90, 0, false},
+		{"Oldest active key", []dnsv1alpha2.DNSSECKey{newestKey, oldestKey}, 90, 60, true},
+		{"Inactive keys ignored", []dnsv1alpha2.DNSSECKey{inactive, newestKey}, 90, 80, true},
+		{"Exceeded policy", []dnsv1alpha2.DNSSECKey{oldestKey}, 10, -20, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			days, found := dnssecKeyRolloverRemainingDays(tc.keys, tc.rolloverDays, now)
+			if !cmp.Equal(days, tc.want) {
+				t.Errorf("got %v, want %v", days, tc.want)
+			}
+			if !cmp.Equal(found, tc.found) {
+				t.Errorf("got %v, want %v", found, tc.found)
+			}
+		})
+	}
+}
+
+func TestDsRecordsFromKeys(t *testing.T) {
+	var (
+		sha256 = "12345 13 2 A5A3F49D0E7C7F8B"
+		sha384 = "12345 13 4 0F1E2D3C4B5A6978"
+		other  = "54321 13 2 0A1B2C3D4E5F6071"
+	)
+
+	var testCases = []struct {
+		description string
+		keys        []dnsv1alpha2.DNSSECKey
+		want        []string
+	}{
+		{"No key", nil, []string{}},
+		{"SHA-256 digest only", []dnsv1alpha2.DNSSECKey{{ID: 1, KeyType: "ksk", Active: true, DS: []string{sha256, sha384}}}, []string{sha256}},
+		{"ZSK ignored", []dnsv1alpha2.DNSSECKey{{ID: 2, KeyType: "zsk", Active: true, DS: []string{other}}}, []string{}},
+		{"Inactive key ignored", []dnsv1alpha2.DNSSECKey{{ID: 1, KeyType: "ksk", Active: false, DS: []string{sha256}}}, []string{}},
+		{"Sorted and deduplicated", []dnsv1alpha2.DNSSECKey{{ID: 3, KeyType: "csk", Active: true, DS: []string{sha256}}, {ID: 1, KeyType: "ksk", Active: true, DS: []string{other, sha256}}}, []string{sha256, other}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ds := dsRecordsFromKeys(tc.keys)
+			if !cmp.Equal(ds, tc.want) {
+				t.Errorf("got %v, want %v", ds, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsBecomingSecondaryZone(t *testing.T) {
+	masters := []string{"192.0.2.1", "192.0.2.2:5300"}
+	var testCases = []struct {
+		description string
+		kind        string
+		masters     []string
+		external    *powerdns.Zone
+		want        bool
+	}{
+		{"Primary turned into secondary", SLAVE_KIND_ZONE, masters, &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.NativeZoneKind)}, true},
+		{"Secondary without primaries", SLAVE_KIND_ZONE, nil, &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.NativeZoneKind)}, false},
+		{"Already secondary", SLAVE_KIND_ZONE, masters, &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.ConsumerZoneKind)}, false},
+		{"Zone creation", SLAVE_KIND_ZONE, masters, &powerdns.Zone{}, false},
+		{"Secondary turned into primary", MASTER_KIND_ZONE, nil, &powerdns.Zone{Name: ptr.To("example.org."), Kind: ptr.To(powerdns.SlaveZoneKind)}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{Kind: tc.kind, Masters: tc.masters}}
+			if got := isBecomingSecondaryZone(zone, tc.external); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// The masters are only compared for secondary zones
+	secondary := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{Kind: SLAVE_KIND_ZONE, Masters: []string{"192.0.2.2:5300", "192.0.2.1"}}}
+	external := &powerdns.Zone{Kind: ptr.To(powerdns.SlaveZoneKind), Masters: masters}
+	if identical, _ := zoneIsIdenticalToExternalZone(secondary, external, nil); !identical {
+		t.Errorf("secondary zone with the same masters in another order should be identical")
+	}
+	secondary.Spec.Masters = masters[:1]
+	if identical, _ := zoneIsIdenticalToExternalZone(secondary, external, nil); identical {
+		t.Errorf("secondary zone with other masters should not be identical")
+	}
+	secondary.Spec.Masters = nil
+	if identical, _ := zoneIsIdenticalToExternalZone(secondary, external, nil); !identical {
+		t.Errorf("secondary zone without declared primaries should keep the masters of PowerDNS")
+	}
+}
+
+func TestGetFailureReason(t *testing.T) {
+	var testCases = []struct {
+		description string
+		err         error
+		want        string
+	}{
+		{"Unauthorized", &powerdns.Error{StatusCode: 401, Message: "Unauthorized"}, FailureReasonAuthError},
+		{"Missing zone", powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Message: ZONE_NOT_FOUND_MSG}, FailureReasonZoneMissing},
+		{"Invalid record", &powerdns.Error{StatusCode: 422, Message: "Record is not in zone"}, FailureReasonInvalidRecord},
+		{"Conflict", &powerdns.Error{StatusCode: ZONE_CONFLICT_CODE, Message: ZONE_CONFLICT_MSG}, FailureReasonConflict},
+		{"Server error", fmt.Errorf("patch failed: %w", &powerdns.Error{StatusCode: 503}), FailureReasonServerUnavailable},
+		{"Unreachable server", &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, FailureReasonServerUnavailable},
+		{"Timeout", context.DeadlineExceeded, FailureReasonServerUnavailable},
+		{"Other error", fmt.Errorf("unexpected"), RrsetReasonSynchronizationFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := getFailureReason(tc.err, RrsetReasonSynchronizationFailed); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetStalledCondition(t *testing.T) {
+	var testCases = []struct {
+		description string
+		status      metav1.ConditionStatus
+		reason      string
+		wantStalled bool
+		wantRetry   bool
+	}{
+		{"Synced", metav1.ConditionTrue, RrsetReasonSynced, false, false},
+		{"Permanent failure", metav1.ConditionFalse, FailureReasonInvalidRecord, true, false},
+		{"Transient failure", metav1.ConditionFalse, FailureReasonServerUnavailable, false, true},
+		{"Unmanaged records", metav1.ConditionFalse, RrsetReasonUnmanagedRecords, false, true},
+		{"Uncategorized failure", metav1.ConditionFalse, RrsetReasonSynchronizationFailed, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// A previous permanent failure is cleared once the resource synchronizes again
+			conditions := []metav1.Condition{{Type: "Stalled", Status: metav1.ConditionTrue, Reason: FailureReasonAuthError}}
+			available := metav1.Condition{Type: "Available", Status: tc.status, Reason: tc.reason}
+			meta.SetStatusCondition(&conditions, available)
+			setStalledCondition(&conditions, available)
+			if got := meta.IsStatusConditionTrue(conditions, "Stalled"); got != tc.wantStalled {
+				t.Errorf("got stalled %v, want %v", got, tc.wantStalled)
+			}
+			if got := isTransientFailure(conditions); got != tc.wantRetry {
+				t.Errorf("got retry %v, want %v", got, tc.wantRetry)
+			}
+		})
+	}
+}
+
+func TestIsCheckingUnmanagedRecords(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrset       *dnsv1alpha2.RRset
+		policy      string
+		want        bool
+	}{
+		{"First apply", &dnsv1alpha2.RRset{}, CONFLICT_POLICY_FAIL, true},
+		{"Already applied", &dnsv1alpha2.RRset{Status: dnsv1alpha2.RRsetStatus{AppliedHash: ptr.To("abc"), SyncStatus: ptr.To(FAILED_STATUS)}}, CONFLICT_POLICY_FAIL, false},
+		{"Patch strategy", &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Strategy: ptr.To(RRSET_PATCH_STRATEGY)}}, CONFLICT_POLICY_FAIL, false},
+		{"Priority not set", &dnsv1alpha2.RRset{}, CONFLICT_POLICY_PRIORITY, true},
+		{"Positive priority", &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Priority: ptr.To(int32(10))}}, CONFLICT_POLICY_PRIORITY, false},
+		{"Positive priority, oldest wins", &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Priority: ptr.To(int32(10))}}, CONFLICT_POLICY_OLDEST_WINS, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isCheckingUnmanagedRecords(tc.rrset, tc.policy); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHasUnmanagedRecords(t *testing.T) {
+	rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"192.0.2.1"}}}
+	var testCases = []struct {
+		description string
+		external    *powerdns.RRset
+		want        bool
+	}{
+		{"No records", nil, false},
+		{"Unmanaged records", &powerdns.RRset{Records: []powerdns.Record{{Content: ptr.To("192.0.2.2")}}}, true},
+		{"Same records", &powerdns.RRset{Records: []powerdns.Record{{Content: ptr.To("192.0.2.1")}}}, false},
+		{"Operator records", &powerdns.RRset{Records: []powerdns.Record{{Content: ptr.To("192.0.2.2")}}, Comments: []powerdns.Comment{{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := hasUnmanagedRecords(rrset, tc.external); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetZoneSerial(t *testing.T) {
+	ctx := context.Background()
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	var testCases = []struct {
+		description string
+		soa         *powerdns.RRset
+		want        *uint32
+		e           bool
+	}{
+		{"SOA record", &powerdns.RRset{Name: ptr.To("serial.org."), Type: ptr.To(powerdns.RRTypeSOA), Records: toPdnsRecords([]string{"ns1.serial.org. hostmaster.serial.org. 2025010102 10800 3600 604800 3600"})}, ptr.To(uint32(2025010102)), false},
+		{"Invalid serial", &powerdns.RRset{Name: ptr.To("serial.org."), Type: ptr.To(powerdns.RRTypeSOA), Records: toPdnsRecords([]string{"ns1.serial.org. hostmaster.serial.org. latest 10800 3600 604800 3600"})}, nil, true},
+		{"No SOA record", nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			deleteFromRecordsMap("serial.org.")
+			if tc.soa != nil {
+				writeToRecordsMap("serial.org.", tc.soa)
+			}
+			got, err := getZoneSerial(ctx, "serial.org", PDNSClient)
+			if (err != nil) != tc.e {
+				t.Errorf("got error %v, want error %v", err, tc.e)
+			}
+			if !ptr.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", ptr.Deref(got, 0), ptr.Deref(tc.want, 0))
+			}
+		})
+	}
+	deleteFromRecordsMap("serial.org.")
+}
+
+func TestIsAlreadyApplied(t *testing.T) {
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Status: dnsv1alpha2.ZoneStatus{Serial: ptr.To(uint32(2025010101))}}
+	rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"192.0.2.1"}}}
+	appliedHash := getAppliedHash(rrset, zone, DEFAULT_TTL, "")
+	var testCases = []struct {
+		description string
+		status      dnsv1alpha2.RRsetStatus
+		hash        string
+		want        bool
+	}{
+		{"Unchanged", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: &appliedHash, AppliedZoneSerial: ptr.To(uint32(2025010101))}, appliedHash, true},
+		{"Never applied", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS)}, appliedHash, false},
+		{"Failed", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(FAILED_STATUS), AppliedHash: &appliedHash, AppliedZoneSerial: ptr.To(uint32(2025010101))}, appliedHash, false},
+		{"Zone modified", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: &appliedHash, AppliedZoneSerial: ptr.To(uint32(2025010100))}, appliedHash, false},
+		{"Cluster id changed", dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS), AppliedHash: &appliedHash, AppliedZoneSerial: ptr.To(uint32(2025010101))}, getAppliedHash(rrset, zone, DEFAULT_TTL, "eu-west"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset.Status = tc.status
+			if got := isAlreadyApplied(rrset, zone, tc.hash); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescriptionIsIdenticalToExternalMetadata(t *testing.T) {
+	var testCases = []struct {
+		description string
+		zone        *string
+		external    []string
+		want        bool
+	}{
+		{"No description", nil, []string{}, true},
+		{"Same description", ptr.To("owned by platform team"), []string{"owned by platform team"}, true},
+		{"Description added", ptr.To("owned by platform team"), []string{}, false},
+		{"Description modified", ptr.To("owned by platform team"), []string{"owned by network team"}, false},
+		{"Description removed", nil, []string{"owned by platform team"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{Spec: dnsv1alpha2.ZoneSpec{Description: tc.zone}}
+			external := &powerdns.Metadata{Kind: powerdns.MetadataKindPtr(ZONE_DESCRIPTION_METADATA), Metadata: tc.external}
+			if got := descriptionIsIdenticalToExternalMetadata(zone, external); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsProtectedApexRRset(t *testing.T) {
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}}
+	override := map[string]string{APEX_OVERRIDE_ANNOTATION: APEX_OVERRIDE_ANNOTATION_VALUE}
+	var testCases = []struct {
+		description string
+		name        string
+		rrType      string
+		annotations map[string]string
+		want        bool
+	}{
+		{"Apex SOA", "example.org.", "SOA", nil, true},
+		{"Apex NS", "EXAMPLE.org.", "NS", nil, true},
+		{"Apex NS with override", "example.org.", "NS", override, false},
+		{"Apex MX", "example.org.", "MX", nil, false},
+		{"Delegation NS", "sub", "NS", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       dnsv1alpha2.RRsetSpec{Type: tc.rrType, Name: tc.name, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			}
+			if got := isProtectedApexRRset(rrset, zone); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsOperatorManagedRRset(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrset       powerdns.RRset
+		want        bool
+	}{
+		{"No comment", powerdns.RRset{}, false},
+		{"Foreign comment", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To("manual"), Account: ptr.To("admin")}}}, false},
+		{"Operator comment", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(DS_RECORDS_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, true},
+		{"Operator comment of a cluster", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/east")}}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			managed := isOperatorManagedRRset(tc.rrset)
+			if !cmp.Equal(managed, tc.want) {
+				t.Errorf("got %v, want %v", managed, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsClusterManagedRRset(t *testing.T) {
+	owned := func(account string) powerdns.RRset {
+		return powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(DELEGATION_COMMENT), Account: ptr.To(account)}}}
+	}
+	var testCases = []struct {
+		description string
+		rrset       powerdns.RRset
+		clusterID   string
+		want        bool
+	}{
+		{"No comment", powerdns.RRset{}, "east", false},
+		{"Operator comment without cluster id", owned(PDNS_COMMENT_ACCOUNT), "east", true},
+		{"Operator comment of the cluster", owned(PDNS_COMMENT_ACCOUNT + "/east"), "east", true},
+		{"Operator comment of another cluster", owned(PDNS_COMMENT_ACCOUNT + "/west"), "east", false},
+		{"Operator comment of a cluster, instance without cluster id", owned(PDNS_COMMENT_ACCOUNT + "/west"), "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isClusterManagedRRset(tc.rrset, tc.clusterID); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsWrittenByZone(t *testing.T) {
+	commented := func(content string, account string) powerdns.RRset {
+		return powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(content), Account: ptr.To(account)}}}
+	}
+	var testCases = []struct {
+		description string
+		rrset       powerdns.RRset
+		want        bool
+	}{
+		{"No comment", powerdns.RRset{}, false},
+		{"Delegation of the cluster", commented(DELEGATION_COMMENT, PDNS_COMMENT_ACCOUNT+"/east"), true},
+		{"Delegation without cluster id", commented(DELEGATION_COMMENT, PDNS_COMMENT_ACCOUNT), true},
+		{"Delegation of another cluster", commented(DELEGATION_COMMENT, PDNS_COMMENT_ACCOUNT+"/west"), false},
+		{"Glue record of a RRset", commented(OWNER_COMMENT, PDNS_COMMENT_ACCOUNT+"/east"), false},
+		{"Manual record with the delegation comment", commented(DELEGATION_COMMENT, "admin"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isWrittenByZone(tc.rrset, DELEGATION_COMMENT, "east"); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetOwnershipConflict(t *testing.T) {
+	owned := func(account string) *powerdns.RRset {
+		return &powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(account)}}}
+	}
+	var testCases = []struct {
+		description string
+		rrset       *powerdns.RRset
+		clusterID   string
+		want        string
+	}{
+		{"Inexisting RRset", nil, "east", ""},
+		{"Manual records adopted", &powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To("manual"), Account: ptr.To("admin")}}}, "east", ""},
+		{"Records without cluster id adopted", owned(PDNS_COMMENT_ACCOUNT), "east", ""},
+		{"Own records", owned(PDNS_COMMENT_ACCOUNT + "/east"), "east", ""},
+		{"Records of another cluster", owned(PDNS_COMMENT_ACCOUNT + "/west"), "east", "west"},
+		{"Records of a cluster without cluster id", owned(PDNS_COMMENT_ACCOUNT + "/west"), "", "west"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			owner := getOwnershipConflict(tc.rrset, tc.clusterID)
+			if owner != tc.want {
+				t.Errorf("got %v, want %v", owner, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRRsetComment(t *testing.T) {
+	var testCases = []struct {
+		description string
+		comment     *string
+		clusterID   string
+		want        *powerdns.Comment
+	}{
+		{"No comment", nil, "", nil},
+		{"Comment", ptr.To("web"), "", &powerdns.Comment{Content: ptr.To("web"), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}},
+		{"Owner comment", nil, "east", &powerdns.Comment{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/east")}},
+		{"Comment with cluster id", ptr.To("web"), "east", &powerdns.Comment{Content: ptr.To("web"), Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/east")}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Comment: tc.comment}}
+			comment := getRRsetComment(rrset, tc.clusterID)
+			if !cmp.Equal(comment, tc.want) {
+				t.Errorf("got %v, want %v", comment, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsInZone(t *testing.T) {
+	var testCases = []struct {
+		description string
+		name        string
+		zone        string
+		want        bool
+	}{
+		{"Zone apex", "example.org.", "example.org.", true},
+		{"Name in zone", "ns1.sub.example.org.", "sub.example.org.", true},
+		{"Name out of zone", "ns1.example.org.", "sub.example.org.", false},
+		{"Suffix without label boundary", "ns1.myexample.org.", "example.org.", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			inZone := isInZone(tc.name, tc.zone)
+			if !cmp.Equal(inZone, tc.want) {
+				t.Errorf("got %v, want %v", inZone, tc.want)
+			}
+		})
+	}
+}
+
+func TestRrsetPendingChanges(t *testing.T) {
+	var (
+		rrset = &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", TTL: ptr.To(uint32(300)), Records: []string{"1.1.1.1"}, Comment: ptr.To("comment"), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	)
+
+	var testCases = []struct {
+		description    string
+		externalRecord *powerdns.RRset
+		want           []string
+	}{
+		{"Non-existing external RRset", nil, []string{"+ test.example.org. 300 IN A 1.1.1.1", `~ comment "" -> "comment"`}},
+		{"Identical external RRset", &powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1"}), Comments: []powerdns.Comment{{Content: ptr.To("comment"), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, nil},
+		{"Different records", &powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"2.2.2.2"}), Comments: []powerdns.Comment{{Content: ptr.To("comment")}}}, []string{"- test.example.org. 300 IN A 2.2.2.2", "+ test.example.org. 300 IN A 1.1.1.1"}},
+		{"Different TTL and comment", &powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(3600)), Records: toPdnsRecords([]string{"1.1.1.1"}), Comments: []powerdns.Comment{{Content: ptr.To("old")}}}, []string{"- test.example.org. 3600 IN A 1.1.1.1", "+ test.example.org. 300 IN A 1.1.1.1", `~ comment "old" -> "comment"`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			changes := rrsetPendingChanges(rrset, tc.externalRecord, DEFAULT_TTL, "")
+			if !cmp.Equal(changes, tc.want) {
+				t.Errorf("got %v, want %v", changes, tc.want)
+			}
+		})
+	}
+}
+
+func TestAbsentRrsetPendingChanges(t *testing.T) {
+	var (
+		rrset = &dnsv1alpha2.RRset{Spec: dnsv1alpha2.RRsetSpec{Name: "test", Type: "A", Ensure: ptr.To(RRSET_ABSENT_ENSURE), ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}}}
+	)
+
+	var testCases = []struct {
+		description    string
+		externalRecord *powerdns.RRset
+		want           []string
+	}{
+		{"Non-existing external RRset", nil, nil},
+		{"Existing external RRset", &powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1", "2.2.2.2"})}, []string{"- test.example.org. 300 IN A 1.1.1.1", "- test.example.org. 300 IN A 2.2.2.2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			changes := rrsetPendingChanges(rrset, tc.externalRecord, DEFAULT_TTL, "")
+			if !cmp.Equal(changes, tc.want) {
+				t.Errorf("got %v, want %v", changes, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsDryRun(t *testing.T) {
+	var testCases = []struct {
+		description string
+		annotations map[string]string
+		dryRun      bool
+		want        bool
+	}{
+		{"No dry-run", nil, false, false},
+		{"Global dry-run", nil, true, true},
+		{"Dry-run annotation", map[string]string{DRY_RUN_ANNOTATION: DRY_RUN_ANNOTATION_VALUE}, false, true},
+		{"Disabled dry-run annotation", map[string]string{DRY_RUN_ANNOTATION: "false"}, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := isDryRun(rrset, tc.dryRun); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetPurgedRRsets(t *testing.T) {
+	externalZone := &powerdns.Zone{RRsets: []powerdns.RRset{
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeSOA)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS)},
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeMX)},
+		{Name: ptr.To("west.example.org."), Type: ptr.To(powerdns.RRTypeA), Comments: []powerdns.Comment{{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/west")}}},
+	}}
+	want := &powerdns.RRsets{Sets: []powerdns.RRset{
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), ChangeType: ptr.To(powerdns.ChangeTypeDelete)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeMX), ChangeType: ptr.To(powerdns.ChangeTypeDelete)},
+	}}
+	if diff := cmp.Diff(want, getPurgedRRsets(externalZone, "east")); diff != "" {
+		t.Errorf("unexpected purge (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetClonedRRsets(t *testing.T) {
+	records := []powerdns.Record{{Content: ptr.To("192.0.2.1"), Disabled: ptr.To(false)}}
+	sourceZone := &powerdns.Zone{RRsets: []powerdns.RRset{
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeSOA)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: records},
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: records},
+		{Name: ptr.To("child.example.org."), Type: ptr.To(powerdns.RRTypeDS), Comments: []powerdns.Comment{{Account: ptr.To(PDNS_COMMENT_ACCOUNT), Content: ptr.To(DS_RECORDS_COMMENT)}}},
+	}}
+	want := []powerdns.RRset{
+		{Name: ptr.To("staging.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), ChangeType: ptr.To(powerdns.ChangeTypeReplace), Records: records},
+		{Name: ptr.To("www.staging.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), ChangeType: ptr.To(powerdns.ChangeTypeReplace), Records: records},
+	}
+	if diff := cmp.Diff(want, getClonedRRsets(sourceZone, "example.org", "staging.example.org")); diff != "" {
+		t.Errorf("unexpected clone (-want +got):\n%s", diff)
+	}
+}
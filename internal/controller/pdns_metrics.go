@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"time"
+
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/ptr"
 )
 
 var (
@@ -35,6 +38,76 @@ var (
 		},
 		[]string{"status", "name"},
 	)
+	zonesDNSSECStatusMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zones_dnssec_status",
+			Help: "DNSSEC signing status of Zones processed (1 if signed, 0 otherwise)",
+		},
+		[]string{"name", "namespace"},
+	)
+	clusterZonesDNSSECStatusMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterzones_dnssec_status",
+			Help: "DNSSEC signing status of ClusterZones processed (1 if signed, 0 otherwise)",
+		},
+		[]string{"name"},
+	)
+	zonesDNSSECKeyRolloverMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zones_dnssec_key_rollover_remaining_days",
+			Help: "Days until the oldest active DNSSEC key of Zones exceeds the rollover policy",
+		},
+		[]string{"name", "namespace"},
+	)
+	clusterZonesDNSSECKeyRolloverMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterzones_dnssec_key_rollover_remaining_days",
+			Help: "Days until the oldest active DNSSEC key of ClusterZones exceeds the rollover policy",
+		},
+		[]string{"name"},
+	)
+	zonesPeerDivergenceMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zones_peer_divergence",
+			Help: "Divergence of Zones on the PowerDNS peers (1 if diverging, 0 otherwise)",
+		},
+		[]string{"name", "namespace", "peer"},
+	)
+	clusterZonesPeerDivergenceMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterzones_peer_divergence",
+			Help: "Divergence of ClusterZones on the PowerDNS peers (1 if diverging, 0 otherwise)",
+		},
+		[]string{"name", "peer"},
+	)
+	rrsetsLastSyncMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rrsets_last_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last successful synchronization of RRsets",
+		},
+		[]string{"name", "namespace"},
+	)
+	clusterRrsetsLastSyncMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterrrsets_last_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last successful synchronization of ClusterRRsets",
+		},
+		[]string{"name"},
+	)
+	zonesLastSyncMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zones_last_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last successful synchronization of Zones",
+		},
+		[]string{"name", "namespace"},
+	)
+	clusterZonesLastSyncMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "clusterzones_last_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last successful synchronization of ClusterZones",
+		},
+		[]string{"name"},
+	)
 )
 
 func updateRrsetsMetrics(fqdn string, gr dnsv1alpha2.GenericRRset) {
@@ -58,6 +131,19 @@ func updateRrsetsMetrics(fqdn string, gr dnsv1alpha2.GenericRRset) {
 	}
 
 }
+func updateRrsetsLastSyncMetrics(gr dnsv1alpha2.GenericRRset) {
+	switch gr.(type) {
+	case *dnsv1alpha2.RRset:
+		rrsetsLastSyncMetric.With(map[string]string{
+			"name":      gr.GetName(),
+			"namespace": gr.GetNamespace(),
+		}).SetToCurrentTime()
+	case *dnsv1alpha2.ClusterRRset:
+		clusterRrsetsLastSyncMetric.With(map[string]string{
+			"name": gr.GetName(),
+		}).SetToCurrentTime()
+	}
+}
 func removeRrsetMetrics(gr dnsv1alpha2.GenericRRset) {
 	switch gr.(type) {
 	case *dnsv1alpha2.RRset:
@@ -72,6 +158,18 @@ func removeRrsetMetrics(gr dnsv1alpha2.GenericRRset) {
 				"name": gr.GetName(),
 			},
 		)
+		rrsetsLastSyncMetric.DeletePartialMatch(
+			map[string]string{
+				"namespace": gr.GetNamespace(),
+				"name":      gr.GetName(),
+			},
+		)
+	case *dnsv1alpha2.ClusterRRset:
+		clusterRrsetsLastSyncMetric.DeletePartialMatch(
+			map[string]string{
+				"name": gr.GetName(),
+			},
+		)
 	}
 }
 
@@ -90,21 +188,92 @@ func updateZonesMetrics(gz dnsv1alpha2.GenericZone) {
 		}).Set(1)
 	}
 }
-func removeZonesMetrics(gz dnsv1alpha2.GenericZone) {
+func updateZonesLastSyncMetrics(gz dnsv1alpha2.GenericZone) {
 	switch gz.(type) {
 	case *dnsv1alpha2.Zone:
-		zonesStatusesMetric.DeletePartialMatch(
-			map[string]string{
-				"namespace": gz.GetNamespace(),
-				"name":      gz.GetName(),
-			},
-		)
+		zonesLastSyncMetric.With(map[string]string{
+			"name":      gz.GetName(),
+			"namespace": gz.GetNamespace(),
+		}).SetToCurrentTime()
 	case *dnsv1alpha2.ClusterZone:
-		clusterZonesStatusesMetric.DeletePartialMatch(
-			map[string]string{
+		clusterZonesLastSyncMetric.With(map[string]string{
+			"name": gz.GetName(),
+		}).SetToCurrentTime()
+	}
+}
+func updateZonesDNSSECMetrics(gz dnsv1alpha2.GenericZone, rolloverDays uint32) {
+	signed := 0.0
+	if ptr.Deref(gz.GetStatus().DNSsec, false) {
+		signed = 1.0
+	}
+	remainingDays, hasActiveKey := dnssecKeyRolloverRemainingDays(gz.GetStatus().DNSSECKeys, rolloverDays, time.Now().UTC())
+
+	switch gz.(type) {
+	case *dnsv1alpha2.Zone:
+		labels := map[string]string{
+			"name":      gz.GetName(),
+			"namespace": gz.GetNamespace(),
+		}
+		zonesDNSSECStatusMetric.With(labels).Set(signed)
+		if hasActiveKey {
+			zonesDNSSECKeyRolloverMetric.With(labels).Set(remainingDays)
+		} else {
+			zonesDNSSECKeyRolloverMetric.Delete(labels)
+		}
+	case *dnsv1alpha2.ClusterZone:
+		labels := map[string]string{
+			"name": gz.GetName(),
+		}
+		clusterZonesDNSSECStatusMetric.With(labels).Set(signed)
+		if hasActiveKey {
+			clusterZonesDNSSECKeyRolloverMetric.With(labels).Set(remainingDays)
+		} else {
+			clusterZonesDNSSECKeyRolloverMetric.Delete(labels)
+		}
+	}
+}
+func updateZonesPeerMetrics(gz dnsv1alpha2.GenericZone, peers []PdnsPeer, divergent map[string]string) {
+	for _, peer := range peers {
+		diverging := 0.0
+		if _, ok := divergent[peer.URL]; ok {
+			diverging = 1.0
+		}
+		switch gz.(type) {
+		case *dnsv1alpha2.Zone:
+			zonesPeerDivergenceMetric.With(map[string]string{
+				"name":      gz.GetName(),
+				"namespace": gz.GetNamespace(),
+				"peer":      peer.URL,
+			}).Set(diverging)
+		case *dnsv1alpha2.ClusterZone:
+			clusterZonesPeerDivergenceMetric.With(map[string]string{
 				"name": gz.GetName(),
-			},
-		)
+				"peer": peer.URL,
+			}).Set(diverging)
+		}
+	}
+}
+func removeZonesMetrics(gz dnsv1alpha2.GenericZone) {
+	switch gz.(type) {
+	case *dnsv1alpha2.Zone:
+		labels := map[string]string{
+			"namespace": gz.GetNamespace(),
+			"name":      gz.GetName(),
+		}
+		zonesStatusesMetric.DeletePartialMatch(labels)
+		zonesDNSSECStatusMetric.DeletePartialMatch(labels)
+		zonesDNSSECKeyRolloverMetric.DeletePartialMatch(labels)
+		zonesPeerDivergenceMetric.DeletePartialMatch(labels)
+		zonesLastSyncMetric.DeletePartialMatch(labels)
+	case *dnsv1alpha2.ClusterZone:
+		labels := map[string]string{
+			"name": gz.GetName(),
+		}
+		clusterZonesStatusesMetric.DeletePartialMatch(labels)
+		clusterZonesDNSSECStatusMetric.DeletePartialMatch(labels)
+		clusterZonesDNSSECKeyRolloverMetric.DeletePartialMatch(labels)
+		clusterZonesPeerDivergenceMetric.DeletePartialMatch(labels)
+		clusterZonesLastSyncMetric.DeletePartialMatch(labels)
 	}
 }
 
@@ -146,6 +315,14 @@ func getClusterZoneMetricWithLabels(zoneStatus, zoneName string) float64 {
 	}))
 }
 
+//nolint:unparam
+func getRrsetLastSyncMetricWithLabels(rrsetName, rrsetNamespace string) float64 {
+	return testutil.ToFloat64(rrsetsLastSyncMetric.With(prometheus.Labels{
+		"name":      rrsetName,
+		"namespace": rrsetNamespace,
+	}))
+}
+
 func countRrsetsMetrics() int {
 	return testutil.CollectAndCount(rrsetsStatusesMetric)
 }
@@ -0,0 +1,275 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// Functions used by the pdnsctl command line, sharing the PowerDNS client code of the operator
+
+// ImportZone return the Zone (or ClusterZone) and the RRsets (or ClusterRRsets) describing a zone existing in PowerDNS.
+// SOA, apex NS and records published on behalf of child zones (DS, delegation) are not imported as RRsets.
+func ImportZone(ctx context.Context, zoneRef dnsv1alpha2.ZoneRef, namespace string, PDNSClient PdnsClienter) ([]client.Object, error) {
+	zoneRes, err := PDNSClient.Zones.Get(ctx, zoneRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneSpec := dnsv1alpha2.ZoneSpec{
+		Kind:        string(ptr.Deref(zoneRes.Kind, powerdns.NativeZoneKind)),
+		Nameservers: []string{},
+		SOAEditAPI:  zoneRes.SOAEditAPI,
+	}
+	if ptr.Deref(zoneRes.Catalog, "") != "" {
+		zoneSpec.Catalog = zoneRes.Catalog
+	}
+	if ptr.Deref(zoneRes.Presigned, false) {
+		zoneSpec.Presigned = zoneRes.Presigned
+	}
+	objects := []client.Object{}
+	//nolint:goconst
+	switch zoneRef.Kind {
+	case "Zone":
+		objects = append(objects, &dnsv1alpha2.Zone{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dnsv1alpha2.GroupVersion.String(), Kind: "Zone"},
+			ObjectMeta: metav1.ObjectMeta{Name: zoneRef.Name, Namespace: namespace},
+		})
+	case "ClusterZone":
+		objects = append(objects, &dnsv1alpha2.ClusterZone{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dnsv1alpha2.GroupVersion.String(), Kind: "ClusterZone"},
+			ObjectMeta: metav1.ObjectMeta{Name: zoneRef.Name},
+		})
+	default:
+		return nil, fmt.Errorf("unknown zone kind %q", zoneRef.Kind)
+	}
+
+	rrsets := slices.Clone(zoneRes.RRsets)
+	slices.SortFunc(rrsets, func(a, b powerdns.RRset) int {
+		return strings.Compare(ptr.Deref(a.Name, "")+"/"+string(ptr.Deref(a.Type, "")), ptr.Deref(b.Name, "")+"/"+string(ptr.Deref(b.Type, "")))
+	})
+	for _, r := range rrsets {
+		if ptr.Deref(r.Type, "") == powerdns.RRTypeNS && ptr.Deref(r.Name, "") == makeCanonical(zoneRef.Name) {
+			zoneSpec.Nameservers = recordsContent(r)
+		}
+		if isZoneManagedRRset(zoneRef.Name, r) || isPublishedForChildZone(r) {
+			continue
+		}
+		spec := rrsetSpecFromExternal(r, zoneRef)
+		//nolint:goconst
+		switch zoneRef.Kind {
+		case "Zone":
+			objects = append(objects, &dnsv1alpha2.RRset{
+				TypeMeta:   metav1.TypeMeta{APIVersion: dnsv1alpha2.GroupVersion.String(), Kind: "RRset"},
+				ObjectMeta: metav1.ObjectMeta{Name: getRegeneratedRRsetName(r), Namespace: namespace},
+				Spec:       spec,
+			})
+		case "ClusterZone":
+			objects = append(objects, &dnsv1alpha2.ClusterRRset{
+				TypeMeta:   metav1.TypeMeta{APIVersion: dnsv1alpha2.GroupVersion.String(), Kind: "ClusterRRset"},
+				ObjectMeta: metav1.ObjectMeta{Name: getRegeneratedRRsetName(r)},
+				Spec:       spec,
+			})
+		}
+	}
+	*objects[0].(dnsv1alpha2.GenericZone).GetSpec() = zoneSpec
+	return objects, nil
+}
+
+// ExportZone return the zone file of a zone, rendered from the specifications of its resources
+func ExportZone(ctx context.Context, zoneRef dnsv1alpha2.ZoneRef, namespace string, defaultTTL uint32, cl client.Reader) (string, error) {
+	zone, err := getZone(ctx, zoneRef, namespace, cl)
+	if err != nil {
+		return "", err
+	}
+	rrsets, err := getZoneDesiredRRsets(ctx, zoneRef, namespace, defaultTTL, cl)
+	if err != nil {
+		return "", err
+	}
+	if rrsets, err = appendZoneFileRRsets(ctx, zone, rrsets, cl); err != nil {
+		return "", err
+	}
+	nameservers := []string{}
+	for _, ns := range zone.GetSpec().Nameservers {
+		nameservers = append(nameservers, makeCanonical(ns))
+	}
+	apexNS := powerdns.RRset{Name: ptr.To(makeCanonical(zoneRef.Name)), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(DEFAULT_TTL_FOR_NS_RECORDS), Records: toPdnsRecords(nameservers)}
+	return renderZoneFile(append([]powerdns.RRset{apexNS}, rrsets...)), nil
+}
+
+// DiffZone return the differences between the content of a zone in PowerDNS ("-") and
+// the specifications of its resources ("+"). SOA and apex NS records are ignored.
+func DiffZone(ctx context.Context, zoneRef dnsv1alpha2.ZoneRef, namespace string, defaultTTL uint32, cl client.Reader, PDNSClient PdnsClienter) ([]string, error) {
+	zone, err := getZone(ctx, zoneRef, namespace, cl)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := getZoneDesiredRRsets(ctx, zoneRef, namespace, defaultTTL, cl)
+	if err != nil {
+		return nil, err
+	}
+	if desired, err = appendZoneFileRRsets(ctx, zone, desired, cl); err != nil {
+		return nil, err
+	}
+	zoneRes, err := PDNSClient.Zones.Get(ctx, zoneRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	current := slices.DeleteFunc(slices.Clone(zoneRes.RRsets), isPublishedForChildZone)
+	return computeRestoreChanges(zoneRef.Name, current, desired).diff, nil
+}
+
+// getZone return the Zone or ClusterZone referenced
+func getZone(ctx context.Context, zoneRef dnsv1alpha2.ZoneRef, namespace string, cl client.Reader) (dnsv1alpha2.GenericZone, error) {
+	var zone dnsv1alpha2.GenericZone
+	//nolint:goconst
+	switch zoneRef.Kind {
+	case "Zone":
+		zone = &dnsv1alpha2.Zone{}
+	case "ClusterZone":
+		zone = &dnsv1alpha2.ClusterZone{}
+		namespace = ""
+	default:
+		return nil, fmt.Errorf("unknown zone kind %q", zoneRef.Kind)
+	}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: zoneRef.Name}, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// getZoneDesiredRRsets return the RRsets described by the RRset and ClusterRRset resources referencing a zone, sorted by name and type
+func getZoneDesiredRRsets(ctx context.Context, zoneRef dnsv1alpha2.ZoneRef, namespace string, defaultTTL uint32, cl client.Reader) ([]powerdns.RRset, error) {
+	generic := []dnsv1alpha2.GenericRRset{}
+	rrsetList := &dnsv1alpha2.RRsetList{}
+	listOptions := []client.ListOption{}
+	if zoneRef.Kind == "Zone" {
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+	if err := cl.List(ctx, rrsetList, listOptions...); err != nil {
+		return nil, err
+	}
+	for i := range rrsetList.Items {
+		generic = append(generic, &rrsetList.Items[i])
+	}
+	if zoneRef.Kind == "ClusterZone" {
+		clusterRRsetList := &dnsv1alpha2.ClusterRRsetList{}
+		if err := cl.List(ctx, clusterRRsetList); err != nil {
+			return nil, err
+		}
+		for i := range clusterRRsetList.Items {
+			generic = append(generic, &clusterRRsetList.Items[i])
+		}
+	}
+
+	rrsets := []powerdns.RRset{}
+	now := time.Now().UTC()
+	for _, gr := range generic {
+		if gr.GetSpec().ZoneRef != zoneRef || !gr.GetDeletionTimestamp().IsZero() || isAbsentRRset(gr) || !isActiveRRset(gr, now) {
+			continue
+		}
+		if gr.GetSpec().TargetRef != nil {
+			// The records of an unresolved target are unknown
+			records, err := resolveTargetRef(ctx, gr, cl)
+			if err != nil {
+				continue
+			}
+			gr.GetSpec().Records = records
+		}
+		rrsets = append(rrsets, powerdns.RRset{
+			Name:    ptr.To(getRRsetName(gr)),
+			Type:    ptr.To(powerdns.RRType(gr.GetSpec().Type)),
+			TTL:     ptr.To(getRRsetTTL(gr, defaultTTL)),
+			Records: toPdnsRecords(getRRsetRecords(gr)),
+		})
+	}
+	slices.SortFunc(rrsets, func(a, b powerdns.RRset) int {
+		return strings.Compare(ptr.Deref(a.Name, "")+"/"+string(ptr.Deref(a.Type, "")), ptr.Deref(b.Name, "")+"/"+string(ptr.Deref(b.Type, "")))
+	})
+	return mergeSharedRRsets(rrsets), nil
+}
+
+// appendZoneFileRRsets return the sorted RRsets with the ones of the zone file of the zone, not described by
+// the RRsets, except the SOA and apex NS records
+func appendZoneFileRRsets(ctx context.Context, zone dnsv1alpha2.GenericZone, rrsets []powerdns.RRset, cl client.Reader) ([]powerdns.RRset, error) {
+	zoneFile, err := getZoneFile(ctx, zone, cl)
+	if err != nil || zoneFile == nil {
+		return rrsets, err
+	}
+	zoneFileRRsets, err := parseBINDZoneFile(zone.GetName(), *zoneFile)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(rrsets))
+	for _, r := range rrsets {
+		keys = append(keys, strings.ToLower(ptr.Deref(r.Name, ""))+"/"+string(ptr.Deref(r.Type, "")))
+	}
+	for _, r := range zoneFileRRsets {
+		if isZoneManagedRRset(zone.GetName(), r) || slices.Contains(keys, ptr.Deref(r.Name, "")+"/"+string(ptr.Deref(r.Type, ""))) {
+			continue
+		}
+		rrsets = append(rrsets, r)
+	}
+	slices.SortFunc(rrsets, func(a, b powerdns.RRset) int {
+		return strings.Compare(ptr.Deref(a.Name, "")+"/"+string(ptr.Deref(a.Type, "")), ptr.Deref(b.Name, "")+"/"+string(ptr.Deref(b.Type, "")))
+	})
+	return rrsets, nil
+}
+
+// mergeSharedRRsets merge the records of the sorted RRsets with the same name and type,
+// described by several resources with the Patch strategy
+func mergeSharedRRsets(rrsets []powerdns.RRset) []powerdns.RRset {
+	merged := []powerdns.RRset{}
+	for _, rrset := range rrsets {
+		if n := len(merged); n > 0 && ptr.Deref(merged[n-1].Name, "") == ptr.Deref(rrset.Name, "") && ptr.Deref(merged[n-1].Type, "") == ptr.Deref(rrset.Type, "") {
+			records := append(recordsContent(merged[n-1]), recordsContent(rrset)...)
+			slices.Sort(records)
+			merged[n-1].Records = toPdnsRecords(slices.Compact(records))
+			continue
+		}
+		merged = append(merged, rrset)
+	}
+	return merged
+}
+
+// rrsetSpecFromExternal return the specification of a RRset resource describing an external RRset
+func rrsetSpecFromExternal(rrset powerdns.RRset, zoneRef dnsv1alpha2.ZoneRef) dnsv1alpha2.RRsetSpec {
+	return dnsv1alpha2.RRsetSpec{
+		Type:    string(ptr.Deref(rrset.Type, "")),
+		Name:    ptr.Deref(rrset.Name, ""),
+		TTL:     ptr.To(ptr.Deref(rrset.TTL, 0)),
+		Records: recordsContent(rrset),
+		ZoneRef: zoneRef,
+	}
+}
+
+// isPublishedForChildZone return True if the external RRset has been published by the operator on behalf of a child zone
+func isPublishedForChildZone(externalRecord powerdns.RRset) bool {
+	for _, c := range externalRecord.Comments {
+		if ptr.Deref(c.Account, "") == PDNS_COMMENT_ACCOUNT &&
+			(ptr.Deref(c.Content, "") == DS_RECORDS_COMMENT || ptr.Deref(c.Content, "") == DELEGATION_COMMENT) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestImportZone(t *testing.T) {
+	ctx := context.Background()
+
+	var testCases = []struct {
+		description string
+		zoneRef     dnsv1alpha2.ZoneRef
+		wantKind    string
+		e           bool
+	}{
+		{"Existing zone as Zone", dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}, "Zone", false},
+		{"Existing zone as ClusterZone", dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}, "ClusterZone", false},
+		{"Unknown kind", dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Unknown"}, "", true},
+		{"Non-existing zone", dnsv1alpha2.ZoneRef{Name: "example1.org", Kind: "Zone"}, "", true},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			objects, err := ImportZone(ctx, tc.zoneRef, "example", PDNSClient)
+			if (err != nil) != tc.e {
+				t.Fatalf("got %v, want error %v", err, tc.e)
+			}
+			if err != nil {
+				return
+			}
+			zone := objects[0].(dnsv1alpha2.GenericZone)
+			if zone.GetTypeMeta().Kind != tc.wantKind || zone.GetName() != tc.zoneRef.Name {
+				t.Errorf("got %s %s, want %s %s", zone.GetTypeMeta().Kind, zone.GetName(), tc.wantKind, tc.zoneRef.Name)
+			}
+			if !cmp.Equal(zone.GetSpec().Kind, MASTER_KIND_ZONE) || !cmp.Equal(zone.GetSpec().Catalog, ptr.To("catalog.org.")) {
+				t.Errorf("got spec %v, want kind %s and catalog catalog.org.", zone.GetSpec(), MASTER_KIND_ZONE)
+			}
+		})
+	}
+}
+
+func TestIsPublishedForChildZone(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrset       powerdns.RRset
+		want        bool
+	}{
+		{"No comment", powerdns.RRset{}, false},
+		{"RRset resource comment", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To("managed by a RRset"), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, false},
+		{"DS records", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(DS_RECORDS_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, true},
+		{"Delegation records", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(DELEGATION_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}}}, true},
+		{"Foreign delegation records", powerdns.RRset{Comments: []powerdns.Comment{{Content: ptr.To(DELEGATION_COMMENT), Account: ptr.To("admin")}}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			published := isPublishedForChildZone(tc.rrset)
+			if !cmp.Equal(published, tc.want) {
+				t.Errorf("got %v, want %v", published, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeSharedRRsets(t *testing.T) {
+	rrsets := []powerdns.RRset{
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeMX), Records: toPdnsRecords([]string{"10 mx.example.org."})},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeTXT), Records: toPdnsRecords([]string{`"MS=ms12345678"`})},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeTXT), Records: toPdnsRecords([]string{`"google-site-verification=abc"`, `"MS=ms12345678"`})},
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeTXT), Records: toPdnsRecords([]string{`"MS=ms12345678"`})},
+	}
+
+	merged := mergeSharedRRsets(rrsets)
+	got := []string{}
+	for _, rrset := range merged {
+		got = append(got, ptr.Deref(rrset.Name, "")+" "+string(ptr.Deref(rrset.Type, ""))+" "+strings.Join(recordsContent(rrset), ","))
+	}
+	want := []string{
+		"example.org. MX 10 mx.example.org.",
+		`example.org. TXT "MS=ms12345678","google-site-verification=abc"`,
+		`www.example.org. TXT "MS=ms12345678"`,
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// PEER_CONSISTENCY_CHECK_INTERVAL is the interval between the consistency checks of a zone against the peers
+	PEER_CONSISTENCY_CHECK_INTERVAL = 5 * time.Minute
+
+	ZoneReasonPeersConsistent  = "PeersConsistent"
+	ZoneMessagePeersConsistent = "Zone served with the same serial by all the peers"
+	ZoneReasonPeersDiverged    = "PeersDiverged"
+	ZoneMessagePeersDiverged   = "Zone diverging on the peers:"
+)
+
+// getDivergentPeers return, for each peer not serving the zone with the serial of the managed PowerDNS server
+// (zone missing or unreachable peer included), the detail of the divergence
+func getDivergentPeers(ctx context.Context, zone dnsv1alpha2.GenericZone, serial *uint32, peers []PdnsPeer, log logr.Logger) map[string]string {
+	divergent := map[string]string{}
+	for _, peer := range peers {
+		peerZone, err := peer.Zones.Get(ctx, zone.GetObjectMeta().Name)
+		switch {
+		case err != nil && err.Error() == ZONE_NOT_FOUND_MSG:
+			divergent[peer.URL] = "missing zone"
+		case err != nil:
+			log.Error(err, "Failed to get zone from peer", "peer", peer.URL)
+			divergent[peer.URL] = "unreachable"
+		case ptr.Deref(peerZone.Serial, 0) != ptr.Deref(serial, 0):
+			divergent[peer.URL] = fmt.Sprintf("serial %d", ptr.Deref(peerZone.Serial, 0))
+		}
+	}
+	return divergent
+}
+
+// getConsistencyCondition return the Consistent condition reporting the peers diverging from the managed PowerDNS server
+func getConsistencyCondition(divergent map[string]string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               "Consistent",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionTrue,
+		Reason:             ZoneReasonPeersConsistent,
+		Message:            ZoneMessagePeersConsistent,
+	}
+	if len(divergent) != 0 {
+		details := make([]string, 0, len(divergent))
+		for url, detail := range divergent {
+			details = append(details, url+" ("+detail+")")
+		}
+		slices.Sort(details)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ZoneReasonPeersDiverged
+		condition.Message = ZoneMessagePeersDiverged + " " + strings.Join(details, ", ")
+	}
+	return condition
+}
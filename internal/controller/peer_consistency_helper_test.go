@@ -0,0 +1,66 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetDivergentPeers(t *testing.T) {
+	writeToZonesMap(makeCanonical("peers.example.org"), &powerdns.Zone{Name: ptr.To("peers.example.org."), Serial: ptr.To(uint32(2025010101))})
+	defer deleteFromZonesMap(makeCanonical("peers.example.org"))
+	peers := []PdnsPeer{{URL: "https://pdns-2:8081", Zones: mockZonesClient{}}}
+
+	var testCases = []struct {
+		description string
+		zone        string
+		serial      uint32
+		want        map[string]string
+	}{
+		{"Same serial", "peers.example.org", 2025010101, map[string]string{}},
+		{"Other serial", "peers.example.org", 2025010102, map[string]string{"https://pdns-2:8081": "serial 2025010101"}},
+		{"Missing zone", "missing.example.org", 2025010101, map[string]string{"https://pdns-2:8081": "missing zone"}},
+		{"Unreachable peer", FAKE_SITE, 2025010101, map[string]string{"https://pdns-2:8081": "unreachable"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: tc.zone}}
+			got := getDivergentPeers(context.Background(), zone, ptr.To(tc.serial), peers, logr.Discard())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected divergence (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetConsistencyCondition(t *testing.T) {
+	condition := getConsistencyCondition(map[string]string{"https://pdns-3:8081": "missing zone", "https://pdns-2:8081": "serial 1"})
+	if condition.Status != metav1.ConditionFalse || condition.Reason != ZoneReasonPeersDiverged {
+		t.Errorf("got %s %s, want diverged", condition.Status, condition.Reason)
+	}
+	if want := ZoneMessagePeersDiverged + " https://pdns-2:8081 (serial 1), https://pdns-3:8081 (missing zone)"; condition.Message != want {
+		t.Errorf("got %q, want %q", condition.Message, want)
+	}
+	if condition := getConsistencyCondition(map[string]string{}); condition.Status != metav1.ConditionTrue {
+		t.Errorf("got %s, want consistent", condition.Status)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DELETION_PRIORITY is the work queue priority of resources being deleted, higher than the
+	// default (0) and the initial list (handler.LowPriority) priorities
+	DELETION_PRIORITY = 100
+)
+
+// enqueueDeletionsFirst return an event handler enqueuing the resources being deleted with DELETION_PRIORITY,
+// so that their finalizers are processed ahead of creations and updates, e.g. on restart with a large backlog.
+// It complements the handler of For(): the priority queue keeps the highest priority of a request added twice.
+// Resources not being deleted are ignored.
+func enqueueDeletionsFirst() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueDeletion(e.Object, q)
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueDeletion(e.ObjectNew, q)
+		},
+	}
+}
+
+// enqueueDeletion add the resource to the queue with DELETION_PRIORITY if it is being deleted.
+// Without priority queue, the resource is added as is.
+func enqueueDeletion(obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if obj == nil || obj.GetDeletionTimestamp().IsZero() {
+		return
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)}
+	if pq, ok := q.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+		pq.AddWithOpts(priorityqueue.AddOpts{Priority: ptr.To(DELETION_PRIORITY)}, req)
+		return
+	}
+	q.Add(req)
+}
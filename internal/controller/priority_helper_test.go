@@ -0,0 +1,66 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestEnqueueDeletionsFirst(t *testing.T) {
+	now := metav1.Now()
+	created := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "default"}}
+	updatedOld := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "updated", Namespace: "default", ResourceVersion: "1"}}
+	updated := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "updated", Namespace: "default", ResourceVersion: "2"}}
+	deleted := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "default", DeletionTimestamp: &now, Finalizers: []string{RESOURCES_FINALIZER_NAME}}}
+
+	q := priorityqueue.New[reconcile.Request]("test")
+	defer q.ShutDown()
+
+	// Events of the initial list, as enqueued by the handler of For()
+	forHandler := handler.EnqueueRequestForObject{}
+	for _, o := range []*dnsv1alpha2.RRset{created, deleted} {
+		forHandler.Create(context.Background(), event.CreateEvent{Object: o, IsInInitialList: true}, q)
+	}
+	forHandler.Update(context.Background(), event.UpdateEvent{ObjectOld: updatedOld, ObjectNew: updated}, q)
+
+	// Only the deleted resource is enqueued again, with a higher priority
+	h := enqueueDeletionsFirst()
+	for _, o := range []*dnsv1alpha2.RRset{created, deleted} {
+		h.Create(context.Background(), event.CreateEvent{Object: o, IsInInitialList: true}, q)
+	}
+	h.Update(context.Background(), event.UpdateEvent{ObjectOld: updatedOld, ObjectNew: updated}, q)
+
+	if q.Len() != 3 {
+		t.Errorf("got %d queued requests, want 3", q.Len())
+	}
+	req, priority, _ := q.GetWithPriority()
+	if req.Name != deleted.Name || priority != DELETION_PRIORITY {
+		t.Errorf("got %s with priority %d, want %s with priority %d", req.Name, priority, deleted.Name, DELETION_PRIORITY)
+	}
+	req, priority, _ = q.GetWithPriority()
+	if req.Name != updated.Name || priority != 0 {
+		t.Errorf("got %s with priority %d, want %s with priority %d", req.Name, priority, updated.Name, 0)
+	}
+	req, priority, _ = q.GetWithPriority()
+	if req.Name != created.Name || priority != handler.LowPriority {
+		t.Errorf("got %s with priority %d, want %s with priority %d", req.Name, priority, created.Name, handler.LowPriority)
+	}
+}
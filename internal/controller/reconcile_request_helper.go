@@ -0,0 +1,37 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RECONCILE_ANNOTATION forces the synchronization of a resource with PowerDNS, its value is free (e.g. a timestamp).
+// The annotation is left as is, the value handled is recorded in the status, a new value requests a new synchronization.
+const RECONCILE_ANNOTATION = "dns.cav.enablers.ob/reconcile"
+
+// isReconcileRequested return True if a synchronization of the resource is requested through the annotation,
+// with another value than the last one handled
+func isReconcileRequested(obj client.Object, lastHandled *string) bool {
+	request := obj.GetAnnotations()[RECONCILE_ANNOTATION]
+	return request != "" && request != ptr.Deref(lastHandled, "")
+}
+
+// getHandledReconcileRequest return the last value of the annotation handled, once the synchronization is done:
+// the request is kept on a transient failure, retried
+func getHandledReconcileRequest(obj client.Object, lastHandled *string, retried bool) *string {
+	if retried || !isReconcileRequested(obj, lastHandled) {
+		return lastHandled
+	}
+	return ptr.To(obj.GetAnnotations()[RECONCILE_ANNOTATION])
+}
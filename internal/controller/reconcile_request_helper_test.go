@@ -0,0 +1,71 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsReconcileRequested(t *testing.T) {
+	var testCases = []struct {
+		description string
+		annotations map[string]string
+		lastHandled *string
+		want        bool
+	}{
+		{"Timestamp", map[string]string{RECONCILE_ANNOTATION: "2025-01-01T00:00:00Z"}, nil, true},
+		{"Already handled", map[string]string{RECONCILE_ANNOTATION: "2025-01-01T00:00:00Z"}, ptr.To("2025-01-01T00:00:00Z"), false},
+		{"New value", map[string]string{RECONCILE_ANNOTATION: "2025-01-02T00:00:00Z"}, ptr.To("2025-01-01T00:00:00Z"), true},
+		{"Empty value", map[string]string{RECONCILE_ANNOTATION: ""}, nil, false},
+		{"Other annotation", map[string]string{RETRANSFER_ANNOTATION: RETRANSFER_ANNOTATION_VALUE}, nil, false},
+		{"No annotation", nil, nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := isReconcileRequested(rrset, tc.lastHandled); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetHandledReconcileRequest(t *testing.T) {
+	var testCases = []struct {
+		description string
+		annotations map[string]string
+		lastHandled *string
+		retried     bool
+		want        *string
+	}{
+		{"Handled", map[string]string{RECONCILE_ANNOTATION: "2"}, ptr.To("1"), false, ptr.To("2")},
+		{"Retried", map[string]string{RECONCILE_ANNOTATION: "2"}, ptr.To("1"), true, ptr.To("1")},
+		{"Annotation removed", nil, ptr.To("1"), false, ptr.To("1")},
+		{"Never requested", nil, nil, false, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := getHandledReconcileRequest(rrset, tc.lastHandled, tc.retried); !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", ptr.Deref(got, ""), ptr.Deref(tc.want, ""))
+			}
+		})
+	}
+}
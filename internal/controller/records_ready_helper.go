@@ -0,0 +1,191 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZoneReasonRecordsSynced  = "RecordsSynced"
+	ZoneReasonRecordsFailed  = "RecordsFailed"
+	ZoneReasonRecordsPending = "RecordsPending"
+)
+
+// getZoneRefIndexKey return the key the RRsets and ClusterRRsets referencing a zone are indexed with
+func getZoneRefIndexKey(zoneRef dnsv1alpha2.ZoneRef) string {
+	return zoneRef.Kind + "/" + zoneRef.Name
+}
+
+// getZoneRef return the reference to the zone
+func getZoneRef(zone dnsv1alpha2.GenericZone) dnsv1alpha2.ZoneRef {
+	if _, ok := zone.(*dnsv1alpha2.ClusterZone); ok {
+		return dnsv1alpha2.ZoneRef{Name: zone.GetName(), Kind: "ClusterZone"}
+	}
+	return dnsv1alpha2.ZoneRef{Name: zone.GetName(), Kind: "Zone"}
+}
+
+// getReferencingRRsets return the RRsets and ClusterRRsets referencing the zone
+func getReferencingRRsets(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client) ([]dnsv1alpha2.GenericRRset, error) {
+	key := getZoneRefIndexKey(getZoneRef(zone))
+	var rrsets dnsv1alpha2.RRsetList
+	if err := cl.List(ctx, &rrsets, client.InNamespace(zone.GetNamespace()), client.MatchingFields{"RRset.ZoneRef": key}); err != nil {
+		return nil, err
+	}
+	var clusterRRsets dnsv1alpha2.ClusterRRsetList
+	if err := cl.List(ctx, &clusterRRsets, client.MatchingFields{"ClusterRRset.ZoneRef": key}); err != nil {
+		return nil, err
+	}
+	referencing := make([]dnsv1alpha2.GenericRRset, 0, len(rrsets.Items)+len(clusterRRsets.Items))
+	for i := range rrsets.Items {
+		referencing = append(referencing, &rrsets.Items[i])
+	}
+	for i := range clusterRRsets.Items {
+		referencing = append(referencing, &clusterRRsets.Items[i])
+	}
+	return referencing, nil
+}
+
+// enqueueRRsetsOfZone return an event handler enqueuing the RRsets (or the ClusterRRsets, with cluster) referencing a zone
+func enqueueRRsetsOfZone(cl client.Client, cluster bool) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		zone, ok := obj.(dnsv1alpha2.GenericZone)
+		if !ok {
+			return nil
+		}
+		referencing, err := getReferencingRRsets(ctx, zone, cl)
+		if err != nil {
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, rrset := range referencing {
+			if _, isCluster := rrset.(*dnsv1alpha2.ClusterRRset); isCluster == cluster {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rrset)})
+			}
+		}
+		return requests
+	})
+}
+
+// zoneChangedPredicate filters the changes of the specification, or of the annotations, of the zones
+func zoneChangedPredicate() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+}
+
+// ownedRRsetChangedPredicate filters the changes of the owned RRsets the zone reconciliation depends on: a change
+// of the synchronization status, summarized in the RecordsReady condition, or of the records in PowerDNS, bumping the
+// serial of the zone, or of its owner. The other changes (conditions, pending changes, revisions) are ignored.
+func ownedRRsetChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(dnsv1alpha2.GenericRRset)
+			if !ok {
+				return true
+			}
+			rrset, ok := e.ObjectNew.(dnsv1alpha2.GenericRRset)
+			if !ok {
+				return true
+			}
+			return ptr.Deref(old.GetStatus().SyncStatus, "") != ptr.Deref(rrset.GetStatus().SyncStatus, "") ||
+				!old.GetStatus().LastUpdateTime.Equal(rrset.GetStatus().LastUpdateTime) ||
+				!equality.Semantic.DeepEqual(old.GetOwnerReferences(), rrset.GetOwnerReferences())
+		},
+	}
+}
+
+// isOrphanedRRset return True if the RRset is controlled by a previous zone with the same name, deleted and recreated since
+func isOrphanedRRset(rrset dnsv1alpha2.GenericRRset, zone dnsv1alpha2.GenericZone) bool {
+	ref := metav1.GetControllerOf(rrset)
+	return ref != nil && ref.Kind == getZoneRef(zone).Kind && ref.Name == zone.GetName() && ref.UID != zone.GetUID()
+}
+
+// reownOrphanedRRsets make the zone the owner of the RRsets controlled by a previous zone with the same name:
+// they are not garbage collected, and they are synchronized again in the recreated zone
+func reownOrphanedRRsets(ctx context.Context, zone dnsv1alpha2.GenericZone, rrsets []dnsv1alpha2.GenericRRset, cl client.Client, log logr.Logger) error {
+	for _, rrset := range rrsets {
+		if !isOrphanedRRset(rrset, zone) || !rrset.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := ctrl.SetControllerReference(zone, rrset, cl.Scheme()); err != nil {
+			return err
+		}
+		if err := cl.Update(ctx, rrset); err != nil {
+			return err
+		}
+		log.Info("Orphaned RRset owned again", "RRset.Name", rrset.GetName(), "RRset.Namespace", rrset.GetNamespace())
+	}
+	return nil
+}
+
+// getOwnedRRsets return the RRsets and ClusterRRsets owned by the zone
+func getOwnedRRsets(zone dnsv1alpha2.GenericZone, rrsets []dnsv1alpha2.GenericRRset) []dnsv1alpha2.GenericRRset {
+	owned := []dnsv1alpha2.GenericRRset{}
+	for _, rrset := range rrsets {
+		if metav1.IsControlledBy(rrset, zone) {
+			owned = append(owned, rrset)
+		}
+	}
+	return owned
+}
+
+// getRecordsReadyCondition return the RecordsReady condition summarizing the statuses of the RRsets owned by the zone,
+// e.g. "142/145 synced, 3 failed"
+func getRecordsReadyCondition(rrsets []dnsv1alpha2.GenericRRset) metav1.Condition {
+	var synced, failed int
+	for _, rrset := range rrsets {
+		switch ptr.Deref(rrset.GetStatus().SyncStatus, "") {
+		case SUCCEEDED_STATUS:
+			synced++
+		case FAILED_STATUS:
+			failed++
+		}
+	}
+	pending := len(rrsets) - synced - failed
+
+	condition := metav1.Condition{
+		Type:               "RecordsReady",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionTrue,
+		Reason:             ZoneReasonRecordsSynced,
+		Message:            fmt.Sprintf("%d/%d synced", synced, len(rrsets)),
+	}
+	if failed != 0 {
+		condition.Message += fmt.Sprintf(", %d failed", failed)
+	}
+	if pending != 0 {
+		condition.Message += fmt.Sprintf(", %d pending", pending)
+	}
+	switch {
+	case failed != 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ZoneReasonRecordsFailed
+	case pending != 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ZoneReasonRecordsPending
+	}
+	return condition
+}
@@ -0,0 +1,107 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetRecordsReadyCondition(t *testing.T) {
+	synced := &dnsv1alpha2.RRset{Status: dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(SUCCEEDED_STATUS)}}
+	failed := &dnsv1alpha2.ClusterRRset{Status: dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(FAILED_STATUS)}}
+	pending := &dnsv1alpha2.RRset{Status: dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(PENDING_STATUS)}}
+
+	var testCases = []struct {
+		description string
+		rrsets      []dnsv1alpha2.GenericRRset
+		status      metav1.ConditionStatus
+		reason      string
+		message     string
+	}{
+		{"No RRset", nil, metav1.ConditionTrue, ZoneReasonRecordsSynced, "0/0 synced"},
+		{"All synced", []dnsv1alpha2.GenericRRset{synced, synced}, metav1.ConditionTrue, ZoneReasonRecordsSynced, "2/2 synced"},
+		{"Failed RRset", []dnsv1alpha2.GenericRRset{synced, failed, pending}, metav1.ConditionFalse, ZoneReasonRecordsFailed, "1/3 synced, 1 failed, 1 pending"},
+		{"Pending RRset", []dnsv1alpha2.GenericRRset{synced, &dnsv1alpha2.RRset{}}, metav1.ConditionFalse, ZoneReasonRecordsPending, "1/2 synced, 1 pending"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			condition := getRecordsReadyCondition(tc.rrsets)
+			if condition.Status != tc.status || condition.Reason != tc.reason || condition.Message != tc.message {
+				t.Errorf("got %s %s %q, want %s %s %q", condition.Status, condition.Reason, condition.Message, tc.status, tc.reason, tc.message)
+			}
+		})
+	}
+}
+
+func TestIsOrphanedRRset(t *testing.T) {
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", UID: "recreated"}}
+	controllerOf := func(kind, name, uid string) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+			{Kind: kind, Name: name, UID: types.UID(uid), Controller: ptr.To(true)},
+		}}}
+	}
+
+	var testCases = []struct {
+		description string
+		rrset       dnsv1alpha2.GenericRRset
+		want        bool
+	}{
+		{"Owned by the deleted zone", controllerOf("Zone", "example.org", "deleted"), true},
+		{"Owned by the zone", controllerOf("Zone", "example.org", "recreated"), false},
+		{"Owned by a cluster zone", controllerOf("ClusterZone", "example.org", "deleted"), false},
+		{"Owned by another zone", controllerOf("Zone", "example.com", "deleted"), false},
+		{"Not owned", &dnsv1alpha2.RRset{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isOrphanedRRset(tc.rrset, zone); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOwnedRRsetChangedPredicate(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC))
+	rrset := func(syncStatus string, lastUpdateTime *metav1.Time, conditions ...metav1.Condition) *dnsv1alpha2.RRset {
+		return &dnsv1alpha2.RRset{Status: dnsv1alpha2.RRsetStatus{SyncStatus: ptr.To(syncStatus), LastUpdateTime: lastUpdateTime, Conditions: conditions}}
+	}
+	var testCases = []struct {
+		description string
+		old         *dnsv1alpha2.RRset
+		new         *dnsv1alpha2.RRset
+		want        bool
+	}{
+		{"Unchanged", rrset(SUCCEEDED_STATUS, &now), rrset(SUCCEEDED_STATUS, &now), false},
+		{"Synchronization status changed", rrset(PENDING_STATUS, &now), rrset(SUCCEEDED_STATUS, &now), true},
+		{"Records modified", rrset(SUCCEEDED_STATUS, nil), rrset(SUCCEEDED_STATUS, &now), true},
+		{"Condition changed", rrset(PENDING_STATUS, &now), rrset(PENDING_STATUS, &now, metav1.Condition{Type: "Available", Reason: RrsetReasonPendingWindow}), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := ownedRRsetChangedPredicate().Update(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new}); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// RRSET_REVISIONS_LIMIT is the number of revisions kept in the status of the RRsets
+	RRSET_REVISIONS_LIMIT = 10
+
+	// ROLLBACK_ANNOTATION restores the records of a revision of the RRset, its value is the revision
+	ROLLBACK_ANNOTATION = "dns.cav.enablers.ob/rollback"
+)
+
+// appendRRsetRevision return the revisions with the records of the RRset applied to PowerDNS appended (with the Merge
+// strategy, the union of the records of the contributing RRsets), when they differ from the last revision,
+// keeping the RRSET_REVISIONS_LIMIT last ones
+func appendRRsetRevision(revisions []dnsv1alpha2.RRsetRevision, rrset dnsv1alpha2.GenericRRset, appliedTime metav1.Time) []dnsv1alpha2.RRsetRevision {
+	records := getRRsetRecords(rrset)
+	if len(revisions) != 0 {
+		last := revisions[len(revisions)-1]
+		if ptr.Equal(last.TTL, rrset.GetSpec().TTL) && slices.Equal(last.Records, records) {
+			return revisions
+		}
+	}
+	revisions = append(slices.Clone(revisions), dnsv1alpha2.RRsetRevision{
+		Revision:    rrset.GetGeneration(),
+		AppliedTime: appliedTime,
+		TTL:         rrset.GetSpec().TTL,
+		Records:     records,
+	})
+	return revisions[max(0, len(revisions)-RRSET_REVISIONS_LIMIT):]
+}
+
+// rollbackRRset restore the records of the revision in the specification of the RRset, as structured NAPTR records
+// when the RRset uses them, return False when the revision is not found in its status, or the records are resolved
+// from a target
+func rollbackRRset(rrset dnsv1alpha2.GenericRRset, revision string) bool {
+	number, err := strconv.ParseInt(revision, 10, 64)
+	if err != nil || rrset.GetSpec().TargetRef != nil {
+		return false
+	}
+	for _, r := range rrset.GetStatus().Revisions {
+		if r.Revision == number {
+			rrset.GetSpec().TTL = r.TTL
+			rrset.GetSpec().Records = slices.Clone(r.Records)
+			if len(rrset.GetSpec().NAPTR) != 0 {
+				rrset.GetSpec().Records, rrset.GetSpec().NAPTR = splitNAPTRRecords(r.Records)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// splitNAPTRRecords return the records which are not NAPTR records in the presentation format, and the NAPTR records
+func splitNAPTRRecords(records []string) ([]string, []dnsv1alpha2.NAPTRRecord) {
+	var others []string
+	var naptr []dnsv1alpha2.NAPTRRecord
+	for _, record := range records {
+		if r, ok := parseNAPTRRecordContent(record); ok {
+			naptr = append(naptr, r)
+		} else {
+			others = append(others, record)
+		}
+	}
+	return others, naptr
+}
+
+// parseNAPTRRecordContent return the NAPTR record of a content in the presentation format:
+// order preference "flags" "service" "regexp" replacement, and False if the content is not a NAPTR record
+func parseNAPTRRecordContent(content string) (dnsv1alpha2.NAPTRRecord, bool) {
+	fields := splitCharacterStrings(content)
+	if len(fields) != 6 {
+		return dnsv1alpha2.NAPTRRecord{}, false
+	}
+	order, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return dnsv1alpha2.NAPTRRecord{}, false
+	}
+	preference, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return dnsv1alpha2.NAPTRRecord{}, false
+	}
+	r := dnsv1alpha2.NAPTRRecord{
+		Order:       uint16(order),
+		Preference:  uint16(preference),
+		Flags:       fields[2],
+		Service:     fields[3],
+		Regexp:      fields[4],
+		Replacement: fields[5],
+	}
+	return r, naptrRecordContent(r) == content
+}
+
+// splitCharacterStrings return the fields of a content separated by spaces, the quoted character strings unquoted
+func splitCharacterStrings(content string) []string {
+	fields := []string{}
+	var field strings.Builder
+	quoted, escaped, started := false, false, false
+	for _, c := range content {
+		switch {
+		case escaped:
+			field.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+			started = true
+		case c == ' ' && !quoted:
+			if started {
+				fields = append(fields, field.String())
+				field.Reset()
+				started = false
+			}
+			continue
+		default:
+			field.WriteRune(c)
+		}
+		started = true
+	}
+	if started {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
@@ -0,0 +1,120 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestAppendRRsetRevision(t *testing.T) {
+	appliedTime := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	first := dnsv1alpha2.RRsetRevision{Revision: 1, AppliedTime: appliedTime, TTL: ptr.To(uint32(300)), Records: []string{"192.0.2.1"}}
+	full := []dnsv1alpha2.RRsetRevision{}
+	for i := range RRSET_REVISIONS_LIMIT {
+		full = append(full, dnsv1alpha2.RRsetRevision{Revision: int64(i + 1), Records: []string{"192.0.2.1"}})
+	}
+
+	var testCases = []struct {
+		description string
+		revisions   []dnsv1alpha2.RRsetRevision
+		ttl         uint32
+		records     []string
+		want        []int64
+	}{
+		{"First revision", nil, 300, []string{"192.0.2.1"}, []int64{11}},
+		{"Same records", []dnsv1alpha2.RRsetRevision{first}, 300, []string{"192.0.2.1"}, []int64{1}},
+		{"Other records", []dnsv1alpha2.RRsetRevision{first}, 300, []string{"192.0.2.2", "192.0.2.1"}, []int64{1, 11}},
+		{"Other TTL", []dnsv1alpha2.RRsetRevision{first}, 600, []string{"192.0.2.1"}, []int64{1, 11}},
+		{"Oldest revision dropped", full, 300, []string{"192.0.2.2"}, []int64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Generation: 11},
+				Spec:       dnsv1alpha2.RRsetSpec{TTL: ptr.To(tc.ttl), Records: tc.records},
+			}
+			got := []int64{}
+			for _, r := range appendRRsetRevision(tc.revisions, rrset, appliedTime) {
+				got = append(got, r.Revision)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected revisions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRollbackRRset(t *testing.T) {
+	revisions := []dnsv1alpha2.RRsetRevision{
+		{Revision: 1, TTL: ptr.To(uint32(300)), Records: []string{"192.0.2.1"}},
+		{Revision: 2, TTL: ptr.To(uint32(600)), Records: []string{"192.0.2.2"}},
+	}
+
+	var testCases = []struct {
+		description string
+		revision    string
+		want        bool
+		wantSpec    dnsv1alpha2.RRsetSpec
+	}{
+		{"Known revision", "1", true, dnsv1alpha2.RRsetSpec{TTL: ptr.To(uint32(300)), Records: []string{"192.0.2.1"}}},
+		{"Unknown revision", "3", false, dnsv1alpha2.RRsetSpec{TTL: ptr.To(uint32(60)), Records: []string{"192.0.2.3"}}},
+		{"Invalid revision", "latest", false, dnsv1alpha2.RRsetSpec{TTL: ptr.To(uint32(60)), Records: []string{"192.0.2.3"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrset := &dnsv1alpha2.RRset{
+				Spec:   dnsv1alpha2.RRsetSpec{TTL: ptr.To(uint32(60)), Records: []string{"192.0.2.3"}},
+				Status: dnsv1alpha2.RRsetStatus{Revisions: revisions},
+			}
+			if got := rollbackRRset(rrset, tc.revision); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+			if diff := cmp.Diff(tc.wantSpec, rrset.Spec); diff != "" {
+				t.Errorf("unexpected specification (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRollbackNAPTRRRset(t *testing.T) {
+	appliedTime := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	naptr := []dnsv1alpha2.NAPTRRecord{
+		{Order: 100, Preference: 10, Flags: "U", Service: "E2U+sip", Regexp: `!^\+(.*)$!sip:\1@example.org!`, Replacement: "."},
+		{Order: 100, Preference: 20, Flags: "S", Service: "SIP+D2U", Replacement: "_sip._udp.example.org."},
+	}
+	rrset := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec:       dnsv1alpha2.RRsetSpec{Type: "NAPTR", TTL: ptr.To(uint32(300)), NAPTR: naptr},
+	}
+	rrset.Status.Revisions = appendRRsetRevision(nil, rrset, appliedTime)
+	if len(rrset.Status.Revisions) != 1 || len(rrset.Status.Revisions[0].Records) != len(naptr) {
+		t.Fatalf("unexpected revisions %v", rrset.Status.Revisions)
+	}
+
+	rrset.Spec.NAPTR = naptr[:1]
+	if !rollbackRRset(rrset, "1") {
+		t.Fatal("revision not restored")
+	}
+	want := dnsv1alpha2.RRsetSpec{Type: "NAPTR", TTL: ptr.To(uint32(300)), NAPTR: naptr}
+	if diff := cmp.Diff(want, rrset.Spec); diff != "" {
+		t.Errorf("unexpected specification (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,649 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"golang.org/x/net/dns/dnsmessage"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	RFC2136_LABEL           = "dns.cav.enablers.ob/rfc2136"
+	RFC2136_KEYS_ANNOTATION = "dns.cav.enablers.ob/rfc2136-keys"
+	RFC2136_RESOURCE        = "rfc2136-"
+	RFC2136_UPDATE_OPCODE   = dnsmessage.OpCode(5)
+	RFC2136_REQUEST_TIMEOUT = 10 * time.Second
+	RFC2136_TCP_TIMEOUT     = 30 * time.Second
+	// RFC2136_KEYS_REFRESH_INTERVAL is the interval between two reads of the TSIG keys Secret
+	RFC2136_KEYS_REFRESH_INTERVAL = 30 * time.Second
+	// RFC2136_MAX_INFLIGHT is the maximum number of messages and TCP connections served at once
+	RFC2136_MAX_INFLIGHT = 64
+
+	// Response codes of the dynamic updates (RFC 2136)
+	RCODE_YXDOMAIN = dnsmessage.RCode(6)
+	RCODE_YXRRSET  = dnsmessage.RCode(7)
+	RCODE_NXRRSET  = dnsmessage.RCode(8)
+	RCODE_NOTAUTH  = dnsmessage.RCode(9)
+	RCODE_NOTZONE  = dnsmessage.RCode(10)
+
+	TYPE_ANY = uint16(255)
+)
+
+// rfc2136Types are the record types supported by the dynamic updates, by type code
+var rfc2136Types = map[uint16]string{
+	1:  "A",
+	2:  "NS",
+	5:  "CNAME",
+	12: "PTR",
+	15: "MX",
+	16: "TXT",
+	28: "AAAA",
+	33: "SRV",
+	49: "DHCID",
+}
+
+// RFC2136Gateway accepts dynamic updates (RFC 2136), authenticated by TSIG (RFC 8945), on UDP and TCP, and
+// translates them into RRsets of the Zones of its namespace. A Zone is open to the keys listed by its
+// RFC2136_KEYS_ANNOTATION annotation. The RRsets written by the gateway are labelled, the updates of names
+// and types described by other RRsets are refused.
+type RFC2136Gateway struct {
+	Client client.Client
+	// Reader reads the TSIG keys without caching the Secrets, at most once per RFC2136_KEYS_REFRESH_INTERVAL
+	Reader client.Reader
+	Addr   string
+	// Namespace of the Zones open to the dynamic updates, where the RRsets are written
+	Namespace string
+	// TSIGSecret is the name of the Secret, in the namespace, containing the TSIG keys by key name
+	TSIGSecret string
+
+	// mu serializes the updates of this replica. The updates served by other replicas are detected
+	// through the resource versions of the RRsets, and get a SERVFAIL response to be retried.
+	mu sync.Mutex
+
+	// keysMu protects the TSIG keys read from the Secret and the time they were read
+	keysMu   sync.Mutex
+	keys     map[string][]byte
+	keysRead time.Time
+}
+
+// rfc2136Record is a record of the prerequisite or update section of a dynamic update
+type rfc2136Record struct {
+	Name    string
+	Type    uint16
+	Class   uint16
+	TTL     uint32
+	Content string
+}
+
+// rfc2136Error is the response code of a refused dynamic update
+type rfc2136Error struct {
+	rcode   dnsmessage.RCode
+	message string
+}
+
+func (e *rfc2136Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.rcode, e.message)
+}
+
+func newRFC2136Error(rcode dnsmessage.RCode, format string, args ...any) error {
+	return &rfc2136Error{rcode: rcode, message: fmt.Sprintf(format, args...)}
+}
+
+// NeedLeaderElection return False: the updates are served by all the replicas, through the Kubernetes API
+func (g *RFC2136Gateway) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the dynamic updates until the context is done
+func (g *RFC2136Gateway) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("rfc2136")
+	packetConn, err := net.ListenPacket("udp", g.Addr)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", g.Addr)
+	if err != nil {
+		_ = packetConn.Close()
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = packetConn.Close()
+		_ = listener.Close()
+	}()
+	log.Info("Serving RFC 2136 dynamic updates", "addr", g.Addr)
+
+	// The messages and connections beyond RFC2136_MAX_INFLIGHT are dropped
+	inflight := make(chan struct{}, RFC2136_MAX_INFLIGHT)
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := packetConn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			select {
+			case inflight <- struct{}{}:
+			default:
+				continue
+			}
+			request := append([]byte{}, buf[:n]...)
+			go func() {
+				defer func() { <-inflight }()
+				if response := g.handle(ctx, request); response != nil {
+					_, _ = packetConn.WriteTo(response, addr)
+				}
+			}()
+		}
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case inflight <- struct{}{}:
+		default:
+			_ = conn.Close()
+			continue
+		}
+		go func() {
+			defer func() { <-inflight }()
+			g.serveTCP(ctx, conn)
+		}()
+	}
+}
+
+// serveTCP serves the length prefixed messages of a TCP connection
+func (g *RFC2136Gateway) serveTCP(ctx context.Context, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	for {
+		_ = conn.SetDeadline(time.Now().Add(RFC2136_TCP_TIMEOUT))
+		length := make([]byte, 2)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return
+		}
+		request := make([]byte, binary.BigEndian.Uint16(length))
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		response := g.handle(ctx, request)
+		if response == nil {
+			return
+		}
+		if _, err := conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(response))), response...)); err != nil {
+			return
+		}
+	}
+}
+
+// handle return the response to a message, or nil if the message cannot be answered
+func (g *RFC2136Gateway) handle(ctx context.Context, request []byte) []byte {
+	log := ctrl.Log.WithName("rfc2136")
+	ctx, cancel := context.WithTimeout(ctx, RFC2136_REQUEST_TIMEOUT)
+	defer cancel()
+
+	unsigned, tsig, err := splitTSIG(request)
+	if err != nil {
+		return rfc2136Response(request, dnsmessage.RCodeFormatError, nil)
+	}
+	var p dnsmessage.Parser
+	header, err := p.Start(unsigned)
+	if err != nil || header.Response {
+		return nil
+	}
+	if header.OpCode != RFC2136_UPDATE_OPCODE {
+		return rfc2136Response(unsigned, dnsmessage.RCodeNotImplemented, nil)
+	}
+	if tsig == nil {
+		return rfc2136Response(unsigned, dnsmessage.RCodeRefused, nil)
+	}
+
+	// Authentication
+	responseTSIG := &tsigRecord{
+		KeyName:    tsig.KeyName,
+		Algorithm:  tsig.Algorithm,
+		TimeSigned: uint64(time.Now().Unix()),
+		Fudge:      TSIG_FUDGE,
+		OriginalID: tsig.OriginalID,
+	}
+	secret, err := g.getTSIGKey(ctx, tsig.KeyName)
+	if err != nil {
+		log.Error(err, "Failed to read the TSIG keys")
+		return rfc2136Response(unsigned, dnsmessage.RCodeServerFailure, nil)
+	}
+	if _, ok := tsigAlgorithms[tsig.Algorithm]; secret == nil || !ok {
+		responseTSIG.Error = TSIG_BADKEY
+		return signRFC2136Response(rfc2136Response(unsigned, RCODE_NOTAUTH, nil), responseTSIG, nil, nil)
+	}
+	switch verifyTSIG(unsigned, tsig, secret, time.Now()) {
+	case TSIG_BADSIG:
+		responseTSIG.Error = TSIG_BADSIG
+		return signRFC2136Response(rfc2136Response(unsigned, RCODE_NOTAUTH, nil), responseTSIG, nil, nil)
+	case TSIG_BADTIME:
+		responseTSIG.Error = TSIG_BADTIME
+		responseTSIG.OtherData = binary.BigEndian.AppendUint16(binary.BigEndian.AppendUint32(nil, uint32(responseTSIG.TimeSigned>>16)), uint16(responseTSIG.TimeSigned))
+		return signRFC2136Response(rfc2136Response(unsigned, RCODE_NOTAUTH, nil), responseTSIG, secret, tsig.MAC)
+	}
+
+	zone, prerequisites, updates, err := parseRFC2136Update(&p)
+	rcode := dnsmessage.RCodeSuccess
+	if err == nil {
+		err = g.update(ctx, zone, tsig.KeyName, prerequisites, updates)
+	}
+	if err != nil {
+		rcode = dnsmessage.RCodeServerFailure
+		var updateErr *rfc2136Error
+		if errors.As(err, &updateErr) {
+			rcode = updateErr.rcode
+		}
+		log.Info("Dynamic update refused", "zone", zone, "key", tsig.KeyName, "reason", err.Error())
+	} else {
+		log.Info("Dynamic update applied", "zone", zone, "key", tsig.KeyName, "updates", len(updates))
+	}
+	return signRFC2136Response(rfc2136Response(unsigned, rcode, &zone), responseTSIG, secret, tsig.MAC)
+}
+
+// getTSIGKey return the secret of a TSIG key, or nil if the key is unknown.
+// The keys are read from the Secret at most once per RFC2136_KEYS_REFRESH_INTERVAL, whatever the messages received.
+func (g *RFC2136Gateway) getTSIGKey(ctx context.Context, keyName string) ([]byte, error) {
+	g.keysMu.Lock()
+	defer g.keysMu.Unlock()
+	if g.keys == nil || time.Since(g.keysRead) > RFC2136_KEYS_REFRESH_INTERVAL {
+		secret := &corev1.Secret{}
+		if err := g.Reader.Get(ctx, client.ObjectKey{Namespace: g.Namespace, Name: g.TSIGSecret}, secret); err != nil {
+			return nil, err
+		}
+		g.keys = map[string][]byte{}
+		for name, key := range secret.Data {
+			g.keys[getTSIGKeyName(name)] = key
+		}
+		g.keysRead = time.Now()
+	}
+	return g.keys[getTSIGKeyName(keyName)], nil
+}
+
+// getTSIGKeyName return the canonical form of a TSIG key name: lowercased, without trailing dot
+func getTSIGKeyName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// isRFC2136KeyAllowed return True if the zone is open to the dynamic updates signed by the TSIG key
+func isRFC2136KeyAllowed(zone *dnsv1alpha2.Zone, keyName string) bool {
+	for key := range strings.SplitSeq(zone.GetAnnotations()[RFC2136_KEYS_ANNOTATION], ",") {
+		if key = strings.TrimSpace(key); key != "" && getTSIGKeyName(key) == getTSIGKeyName(keyName) {
+			return true
+		}
+	}
+	return false
+}
+
+// update checks the prerequisites and applies the updates to the RRsets of the zone
+func (g *RFC2136Gateway) update(ctx context.Context, zone string, keyName string, prerequisites, updates []rfc2136Record) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	zoneRef := dnsv1alpha2.ZoneRef{Name: strings.TrimSuffix(zone, "."), Kind: "Zone"}
+	zoneResource := &dnsv1alpha2.Zone{}
+	if err := g.Client.Get(ctx, client.ObjectKey{Namespace: g.Namespace, Name: zoneRef.Name}, zoneResource); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return newRFC2136Error(RCODE_NOTAUTH, "zone %s is not open to dynamic updates", zone)
+		}
+		return err
+	}
+	if !isRFC2136KeyAllowed(zoneResource, keyName) {
+		return newRFC2136Error(dnsmessage.RCodeRefused, "zone %s is not open to the key %s", zone, keyName)
+	}
+
+	desired, err := getZoneDesiredRRsets(ctx, zoneRef, g.Namespace, 0, g.Client)
+	if err != nil {
+		return err
+	}
+	if err := checkRFC2136Prerequisites(zone, prerequisites, desired); err != nil {
+		return err
+	}
+
+	// Records of the RRsets written by the gateway, and names and types of the other RRsets
+	var rrsetList dnsv1alpha2.RRsetList
+	if err := g.Client.List(ctx, &rrsetList, client.InNamespace(g.Namespace)); err != nil {
+		return err
+	}
+	owned := map[string]*dnsv1alpha2.RRset{}
+	foreign := map[string]bool{}
+	for i := range rrsetList.Items {
+		rrset := &rrsetList.Items[i]
+		if rrset.Spec.ZoneRef != zoneRef {
+			continue
+		}
+		key := strings.ToLower(getRRsetName(rrset)) + "/" + rrset.Spec.Type
+		if rrset.Labels[RFC2136_LABEL] == "true" {
+			owned[key] = rrset
+		} else {
+			foreign[key] = true
+		}
+	}
+	records, ttls := map[string][]string{}, map[string]uint32{}
+	for key, rrset := range owned {
+		records[key] = slices.Clone(rrset.Spec.Records)
+		ttls[key] = ptr.Deref(rrset.Spec.TTL, 0)
+	}
+
+	if err := applyRFC2136Updates(zone, updates, records, ttls, foreign); err != nil {
+		return err
+	}
+
+	for key, recs := range records {
+		name, rrType, _ := strings.Cut(key, "/")
+		rrset := owned[key]
+		if len(recs) == 0 {
+			if rrset != nil {
+				// The RRset is only deleted in the version read, not if changed meanwhile by another replica
+				precondition := client.Preconditions{ResourceVersion: ptr.To(rrset.ResourceVersion)}
+				if err := g.Client.Delete(ctx, rrset, precondition); client.IgnoreNotFound(err) != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if rrset != nil && slices.Equal(recs, rrset.Spec.Records) && ptr.Deref(rrset.Spec.TTL, 0) == ttls[key] {
+			continue
+		}
+		readVersion := ""
+		if rrset != nil {
+			readVersion = rrset.ResourceVersion
+		}
+		rrset = &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getGeneratedRRsetName(RFC2136_RESOURCE+strings.ToLower(rrType), name),
+				Namespace: g.Namespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, g.Client, rrset, func() error {
+			if rrset.ResourceVersion != readVersion {
+				return fmt.Errorf("RRset %s changed during the update", key)
+			}
+			if rrset.Labels == nil {
+				rrset.Labels = map[string]string{}
+			}
+			rrset.Labels[RFC2136_LABEL] = "true"
+			rrset.Spec = dnsv1alpha2.RRsetSpec{
+				Type:    rrType,
+				Name:    name,
+				TTL:     ptr.To(ttls[key]),
+				Records: recs,
+				ZoneRef: zoneRef,
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRFC2136Prerequisites checks the prerequisites of an update (RFC 2136 section 3.2) against the RRsets of the zone
+func checkRFC2136Prerequisites(zone string, prerequisites []rfc2136Record, rrsets []powerdns.RRset) error {
+	existing := map[string][]string{}
+	names := map[string]bool{}
+	for _, rrset := range rrsets {
+		name := strings.ToLower(ptr.Deref(rrset.Name, ""))
+		existing[name+"/"+string(ptr.Deref(rrset.Type, ""))] = recordsContent(rrset)
+		names[name] = true
+	}
+	valueDependent := map[string][]string{}
+	for _, r := range prerequisites {
+		if r.TTL != 0 {
+			return newRFC2136Error(dnsmessage.RCodeFormatError, "prerequisite %s with a TTL", r.Name)
+		}
+		if !isInZone(r.Name, zone) {
+			return newRFC2136Error(RCODE_NOTZONE, "prerequisite %s out of the zone", r.Name)
+		}
+		key := r.Name + "/" + rfc2136Types[r.Type]
+		switch {
+		case r.Class == CLASS_ANY && r.Type == TYPE_ANY:
+			if !names[r.Name] {
+				return newRFC2136Error(dnsmessage.RCodeNameError, "name %s not in use", r.Name)
+			}
+		case r.Class == CLASS_ANY:
+			if _, ok := existing[key]; !ok {
+				return newRFC2136Error(RCODE_NXRRSET, "RRset %s does not exist", key)
+			}
+		case r.Class == CLASS_NONE && r.Type == TYPE_ANY:
+			if names[r.Name] {
+				return newRFC2136Error(RCODE_YXDOMAIN, "name %s in use", r.Name)
+			}
+		case r.Class == CLASS_NONE:
+			if _, ok := existing[key]; ok {
+				return newRFC2136Error(RCODE_YXRRSET, "RRset %s exists", key)
+			}
+		case r.Class == uint16(dnsmessage.ClassINET):
+			valueDependent[key] = append(valueDependent[key], r.Content)
+		default:
+			return newRFC2136Error(dnsmessage.RCodeFormatError, "prerequisite %s with an unexpected class", r.Name)
+		}
+	}
+	for key, records := range valueDependent {
+		slices.Sort(records)
+		if !slices.Equal(slices.Compact(records), existing[key]) {
+			return newRFC2136Error(RCODE_NXRRSET, "RRset %s differs", key)
+		}
+	}
+	return nil
+}
+
+// applyRFC2136Updates applies the updates (RFC 2136 section 3.4) to the records and TTLs of the RRsets written by the gateway.
+// The RRsets described by other resources, and the SOA and apex NS records, cannot be updated.
+func applyRFC2136Updates(zone string, updates []rfc2136Record, records map[string][]string, ttls map[string]uint32, foreign map[string]bool) error {
+	// Prescan
+	for _, r := range updates {
+		if !isInZone(r.Name, zone) {
+			return newRFC2136Error(RCODE_NOTZONE, "update %s out of the zone", r.Name)
+		}
+		switch r.Class {
+		case uint16(dnsmessage.ClassINET):
+			if r.Type == TYPE_ANY || r.Content == "" {
+				return newRFC2136Error(dnsmessage.RCodeFormatError, "update %s without data", r.Name)
+			}
+		case CLASS_ANY:
+			if r.TTL != 0 || r.Content != "" {
+				return newRFC2136Error(dnsmessage.RCodeFormatError, "deletion of %s with data", r.Name)
+			}
+		case CLASS_NONE:
+			if r.TTL != 0 || r.Type == TYPE_ANY {
+				return newRFC2136Error(dnsmessage.RCodeFormatError, "deletion of a record of %s without type", r.Name)
+			}
+		default:
+			return newRFC2136Error(dnsmessage.RCodeFormatError, "update %s with an unexpected class", r.Name)
+		}
+		if r.Type == TYPE_ANY {
+			continue
+		}
+		key := r.Name + "/" + rfc2136Types[r.Type]
+		if r.Name == zone && r.Type == uint16(dnsmessage.TypeNS) {
+			return newRFC2136Error(dnsmessage.RCodeRefused, "apex NS records are managed by the zone")
+		}
+		if foreign[key] {
+			return newRFC2136Error(dnsmessage.RCodeRefused, "RRset %s is managed by another resource", key)
+		}
+	}
+
+	for _, r := range updates {
+		key := r.Name + "/" + rfc2136Types[r.Type]
+		switch {
+		case r.Class == uint16(dnsmessage.ClassINET):
+			recs := append(records[key], r.Content)
+			slices.Sort(recs)
+			records[key] = slices.Compact(recs)
+			ttls[key] = r.TTL
+		case r.Class == CLASS_ANY && r.Type == TYPE_ANY:
+			for k := range records {
+				if strings.HasPrefix(k, r.Name+"/") {
+					records[k] = nil
+				}
+			}
+		case r.Class == CLASS_ANY:
+			if _, ok := records[key]; ok {
+				records[key] = nil
+			}
+		case r.Class == CLASS_NONE:
+			if recs, ok := records[key]; ok {
+				records[key] = slices.DeleteFunc(recs, func(c string) bool { return c == r.Content })
+			}
+		}
+	}
+	return nil
+}
+
+// parseRFC2136Update return the zone, the prerequisites and the updates of a dynamic update message
+func parseRFC2136Update(p *dnsmessage.Parser) (string, []rfc2136Record, []rfc2136Record, error) {
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return "", nil, nil, newRFC2136Error(dnsmessage.RCodeFormatError, "malformed zone section")
+	}
+	if len(questions) != 1 || questions[0].Type != dnsmessage.TypeSOA || questions[0].Class != dnsmessage.ClassINET {
+		return "", nil, nil, newRFC2136Error(dnsmessage.RCodeFormatError, "zone section must contain a single SOA question")
+	}
+	zone := strings.ToLower(questions[0].Name.String())
+	prerequisites, err := parseRFC2136Records(p, p.AnswerHeader)
+	if err != nil {
+		return zone, nil, nil, err
+	}
+	updates, err := parseRFC2136Records(p, p.AuthorityHeader)
+	if err != nil {
+		return zone, nil, nil, err
+	}
+	return zone, prerequisites, updates, nil
+}
+
+// parseRFC2136Records return the records of a section, read by their header function
+func parseRFC2136Records(p *dnsmessage.Parser, next func() (dnsmessage.ResourceHeader, error)) ([]rfc2136Record, error) {
+	records := []rfc2136Record{}
+	for {
+		h, err := next()
+		if err == dnsmessage.ErrSectionDone {
+			return records, nil
+		}
+		if err != nil {
+			return nil, newRFC2136Error(dnsmessage.RCodeFormatError, "malformed record")
+		}
+		r := rfc2136Record{Name: strings.ToLower(h.Name.String()), Type: uint16(h.Type), Class: uint16(h.Class), TTL: h.TTL}
+		if _, ok := rfc2136Types[r.Type]; !ok && r.Type != TYPE_ANY {
+			return nil, newRFC2136Error(dnsmessage.RCodeNotImplemented, "unsupported record type %d", r.Type)
+		}
+		if h.Length == 0 {
+			if _, err := p.UnknownResource(); err != nil {
+				return nil, newRFC2136Error(dnsmessage.RCodeFormatError, "malformed record")
+			}
+		} else if r.Content, err = rfc2136RecordContent(p, h.Type); err != nil {
+			return nil, newRFC2136Error(dnsmessage.RCodeFormatError, "malformed %s record", rfc2136Types[r.Type])
+		}
+		records = append(records, r)
+	}
+}
+
+// rfc2136RecordContent return the content of the record of the parser, in the presentation format
+func rfc2136RecordContent(p *dnsmessage.Parser, rrType dnsmessage.Type) (string, error) {
+	switch rrType {
+	case dnsmessage.TypeA:
+		r, err := p.AResource()
+		return netip.AddrFrom4(r.A).String(), err
+	case dnsmessage.TypeAAAA:
+		r, err := p.AAAAResource()
+		return netip.AddrFrom16(r.AAAA).String(), err
+	case dnsmessage.TypeCNAME:
+		r, err := p.CNAMEResource()
+		return r.CNAME.String(), err
+	case dnsmessage.TypeNS:
+		r, err := p.NSResource()
+		return r.NS.String(), err
+	case dnsmessage.TypePTR:
+		r, err := p.PTRResource()
+		return r.PTR.String(), err
+	case dnsmessage.TypeMX:
+		r, err := p.MXResource()
+		return fmt.Sprintf("%d %s", r.Pref, r.MX.String()), err
+	case dnsmessage.TypeSRV:
+		r, err := p.SRVResource()
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target.String()), err
+	case dnsmessage.TypeTXT:
+		r, err := p.TXTResource()
+		quoted := make([]string, 0, len(r.TXT))
+		for _, s := range r.TXT {
+			quoted = append(quoted, quoteCharacterString(s))
+		}
+		return strings.Join(quoted, " "), err
+	default:
+		// DHCID (RFC 4701), presented in base64
+		r, err := p.UnknownResource()
+		return base64.StdEncoding.EncodeToString(r.Data), err
+	}
+}
+
+// rfc2136Response return the unsigned response to a request, with the zone section when given
+func rfc2136Response(request []byte, rcode dnsmessage.RCode, zone *string) []byte {
+	if len(request) < DNS_HEADER_SIZE {
+		return nil
+	}
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:       binary.BigEndian.Uint16(request),
+		Response: true,
+		OpCode:   dnsmessage.OpCode((request[2] >> 3) & 0x0F),
+		RCode:    rcode,
+	})
+	if zone != nil && *zone != "" {
+		if name, err := dnsmessage.NewName(*zone); err == nil {
+			_ = b.StartQuestions()
+			_ = b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET})
+		}
+	}
+	response, err := b.Finish()
+	if err != nil {
+		return nil
+	}
+	return response
+}
+
+// signRFC2136Response append the TSIG record to the response
+func signRFC2136Response(response []byte, tsig *tsigRecord, secret, requestMAC []byte) []byte {
+	if response == nil {
+		return nil
+	}
+	signed, err := appendTSIG(response, tsig, secret, requestMAC)
+	if err != nil {
+		return response
+	}
+	return signed
+}
@@ -0,0 +1,220 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	"golang.org/x/net/dns/dnsmessage"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// rfc2136RCode return the response code of an update error
+func rfc2136RCode(err error) dnsmessage.RCode {
+	var updateErr *rfc2136Error
+	if err == nil {
+		return dnsmessage.RCodeSuccess
+	}
+	if errors.As(err, &updateErr) {
+		return updateErr.rcode
+	}
+	return dnsmessage.RCodeServerFailure
+}
+
+func TestParseRFC2136Update(t *testing.T) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 1, OpCode: RFC2136_UPDATE_OPCODE})
+	_ = b.StartQuestions()
+	_ = b.Question(dnsmessage.Question{Name: dnsmessage.MustNewName("Example.org."), Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET})
+	_ = b.StartAnswers()
+	_ = b.UnknownResource(dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.example.org."), Class: dnsmessage.Class(CLASS_NONE)}, dnsmessage.UnknownResource{Type: dnsmessage.TypeA})
+	_ = b.StartAuthorities()
+	_ = b.AResource(dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.example.org."), Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+	_ = b.TXTResource(dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.example.org."), Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.TXTResource{TXT: []string{"v=1", `say "hi"`}})
+	_ = b.MXResource(dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("example.org."), Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx.example.org.")})
+	_ = b.UnknownResource(dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("old.example.org."), Class: dnsmessage.Class(CLASS_ANY)}, dnsmessage.UnknownResource{Type: dnsmessage.Type(TYPE_ANY)})
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p dnsmessage.Parser
+	if _, err := p.Start(msg); err != nil {
+		t.Fatal(err)
+	}
+	zone, prerequisites, updates, err := parseRFC2136Update(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone != "example.org." {
+		t.Errorf("got zone %s", zone)
+	}
+	wantPrerequisites := []rfc2136Record{{Name: "www.example.org.", Type: 1, Class: CLASS_NONE}}
+	if diff := cmp.Diff(wantPrerequisites, prerequisites); diff != "" {
+		t.Errorf("unexpected prerequisites (-want +got):\n%s", diff)
+	}
+	wantUpdates := []rfc2136Record{
+		{Name: "www.example.org.", Type: 1, Class: 1, TTL: 60, Content: "192.0.2.1"},
+		{Name: "www.example.org.", Type: 16, Class: 1, TTL: 60, Content: `"v=1" "say \"hi\""`},
+		{Name: "example.org.", Type: 15, Class: 1, TTL: 60, Content: "10 mx.example.org."},
+		{Name: "old.example.org.", Type: TYPE_ANY, Class: CLASS_ANY},
+	}
+	if diff := cmp.Diff(wantUpdates, updates); diff != "" {
+		t.Errorf("unexpected updates (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckRFC2136Prerequisites(t *testing.T) {
+	rrsets := []powerdns.RRset{
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), Records: toPdnsRecords([]string{"192.0.2.2", "192.0.2.1"})},
+	}
+	var testCases = []struct {
+		description   string
+		prerequisites []rfc2136Record
+		want          dnsmessage.RCode
+	}{
+		{"No prerequisite", nil, dnsmessage.RCodeSuccess},
+		{"Name in use", []rfc2136Record{{Name: "www.example.org.", Type: TYPE_ANY, Class: CLASS_ANY}}, dnsmessage.RCodeSuccess},
+		{"Name not in use", []rfc2136Record{{Name: "ftp.example.org.", Type: TYPE_ANY, Class: CLASS_ANY}}, dnsmessage.RCodeNameError},
+		{"RRset exists", []rfc2136Record{{Name: "www.example.org.", Type: 1, Class: CLASS_ANY}}, dnsmessage.RCodeSuccess},
+		{"RRset does not exist", []rfc2136Record{{Name: "www.example.org.", Type: 28, Class: CLASS_ANY}}, RCODE_NXRRSET},
+		{"Name unexpectedly in use", []rfc2136Record{{Name: "www.example.org.", Type: TYPE_ANY, Class: CLASS_NONE}}, RCODE_YXDOMAIN},
+		{"RRset unexpectedly exists", []rfc2136Record{{Name: "www.example.org.", Type: 1, Class: CLASS_NONE}}, RCODE_YXRRSET},
+		{"Value dependent RRset matches", []rfc2136Record{
+			{Name: "www.example.org.", Type: 1, Class: 1, Content: "192.0.2.1"},
+			{Name: "www.example.org.", Type: 1, Class: 1, Content: "192.0.2.2"},
+		}, dnsmessage.RCodeSuccess},
+		{"Value dependent RRset differs", []rfc2136Record{{Name: "www.example.org.", Type: 1, Class: 1, Content: "192.0.2.1"}}, RCODE_NXRRSET},
+		{"Prerequisite out of the zone", []rfc2136Record{{Name: "www.example.com.", Type: TYPE_ANY, Class: CLASS_ANY}}, RCODE_NOTZONE},
+		{"Prerequisite with a TTL", []rfc2136Record{{Name: "www.example.org.", Type: TYPE_ANY, Class: CLASS_ANY, TTL: 60}}, dnsmessage.RCodeFormatError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := rfc2136RCode(checkRFC2136Prerequisites("example.org.", tc.prerequisites, rrsets)); got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyRFC2136Updates(t *testing.T) {
+	var testCases = []struct {
+		description string
+		updates     []rfc2136Record
+		want        map[string][]string
+		wantTTLs    map[string]uint32
+		wantRCode   dnsmessage.RCode
+	}{
+		{
+			"Add records",
+			[]rfc2136Record{
+				{Name: "www.example.org.", Type: 1, Class: 1, TTL: 60, Content: "192.0.2.3"},
+				{Name: "ftp.example.org.", Type: 28, Class: 1, TTL: 120, Content: "2001:db8::1"},
+			},
+			map[string][]string{"www.example.org./A": {"192.0.2.1", "192.0.2.3"}, "www.example.org./TXT": {`"v=1"`}, "ftp.example.org./AAAA": {"2001:db8::1"}},
+			map[string]uint32{"www.example.org./A": 60, "www.example.org./TXT": 300, "ftp.example.org./AAAA": 120},
+			dnsmessage.RCodeSuccess,
+		},
+		{
+			"Delete a record",
+			[]rfc2136Record{{Name: "www.example.org.", Type: 1, Class: CLASS_NONE, Content: "192.0.2.1"}},
+			map[string][]string{"www.example.org./A": {}, "www.example.org./TXT": {`"v=1"`}},
+			map[string]uint32{"www.example.org./A": 300, "www.example.org./TXT": 300},
+			dnsmessage.RCodeSuccess,
+		},
+		{
+			"Delete a RRset",
+			[]rfc2136Record{{Name: "www.example.org.", Type: 16, Class: CLASS_ANY}},
+			map[string][]string{"www.example.org./A": {"192.0.2.1"}, "www.example.org./TXT": nil},
+			map[string]uint32{"www.example.org./A": 300, "www.example.org./TXT": 300},
+			dnsmessage.RCodeSuccess,
+		},
+		{
+			"Delete all the RRsets of a name",
+			[]rfc2136Record{{Name: "www.example.org.", Type: TYPE_ANY, Class: CLASS_ANY}},
+			map[string][]string{"www.example.org./A": nil, "www.example.org./TXT": nil},
+			map[string]uint32{"www.example.org./A": 300, "www.example.org./TXT": 300},
+			dnsmessage.RCodeSuccess,
+		},
+		{
+			"RRset managed by another resource",
+			[]rfc2136Record{{Name: "mail.example.org.", Type: 1, Class: 1, TTL: 60, Content: "192.0.2.3"}},
+			nil, nil, dnsmessage.RCodeRefused,
+		},
+		{
+			"Apex NS records",
+			[]rfc2136Record{{Name: "example.org.", Type: 2, Class: 1, TTL: 60, Content: "ns3.example.org."}},
+			nil, nil, dnsmessage.RCodeRefused,
+		},
+		{
+			"Update out of the zone",
+			[]rfc2136Record{{Name: "www.example.com.", Type: 1, Class: 1, TTL: 60, Content: "192.0.2.3"}},
+			nil, nil, RCODE_NOTZONE,
+		},
+		{
+			"Deletion with data",
+			[]rfc2136Record{{Name: "www.example.org.", Type: 1, Class: CLASS_ANY, Content: "192.0.2.1"}},
+			nil, nil, dnsmessage.RCodeFormatError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			records := map[string][]string{"www.example.org./A": {"192.0.2.1"}, "www.example.org./TXT": {`"v=1"`}}
+			ttls := map[string]uint32{"www.example.org./A": 300, "www.example.org./TXT": 300}
+			foreign := map[string]bool{"mail.example.org./A": true}
+			err := applyRFC2136Updates("example.org.", tc.updates, records, ttls, foreign)
+			if got := rfc2136RCode(err); got != tc.wantRCode {
+				t.Fatalf("got %s, want %s", got, tc.wantRCode)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, records); diff != "" {
+				t.Errorf("unexpected records (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantTTLs, ttls); diff != "" {
+				t.Errorf("unexpected TTLs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsRFC2136KeyAllowed(t *testing.T) {
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		keyName     string
+		want        bool
+	}{
+		{"No annotation", nil, "dhcp-key.", false},
+		{"Listed key", map[string]string{RFC2136_KEYS_ANNOTATION: "certbot, dhcp-key"}, "dhcp-key.", true},
+		{"Listed key with another case", map[string]string{RFC2136_KEYS_ANNOTATION: "DHCP-Key."}, "dhcp-key.", true},
+		{"Other key", map[string]string{RFC2136_KEYS_ANNOTATION: "certbot"}, "dhcp-key.", false},
+		{"Empty entry", map[string]string{RFC2136_KEYS_ANNOTATION: ","}, ".", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := isRFC2136KeyAllowed(zone, tc.keyName); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
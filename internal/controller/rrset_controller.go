@@ -15,6 +15,7 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -23,12 +24,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dnsutil"
 )
 
 const (
@@ -36,27 +41,74 @@ const (
 	RrsetReasonSynchronizationFailed = "SynchronizationFailed"
 	RrsetReasonDuplicated            = "RrsetDuplicated"
 	RrsetReasonSynced                = "RrsetSynced"
+	RrsetReasonDryRun                = "DryRun"
+	RrsetReasonConflict              = "RrsetConflict"
+	RrsetReasonOwnershipConflict     = "OwnershipConflict"
+	RrsetReasonApexProtected         = "ApexProtected"
+	RrsetReasonUnmanagedRecords      = "UnmanagedRecords"
 	RrsetMessageDuplicated           = "Already existing RRset with the same FQDN"
+	RrsetMessageConflict             = "Already existing RRset with the same FQDN and the conflicting type"
+	RrsetMessageDNAMEConflict        = "Already existing RRset conflicting with the DNAME redirection of the subtree:"
 	RrsetMessageSyncSucceeded        = "RRset synced with PowerDNS instance"
 	RrsetMessageNonExistentZone      = "non-existent zone:"
 	RrsetMessageUnavailableZone      = "unavailable zone:"
+	RrsetMessageDryRun               = "Dry-run mode, pending changes are not applied to PowerDNS"
+	RrsetMessageOwnershipConflict    = "Records owned by the operator of another cluster:"
+	RrsetMessageUnmanagedRecords     = "Already existing records in PowerDNS, not written by the operator:"
+	RrsetMessageApexProtected        = "The SOA and NS records of the zone apex are managed by the zone, the annotation " + APEX_OVERRIDE_ANNOTATION + " allows to override them"
+
+	DRY_RUN_ANNOTATION       = "dns.cav.enablers.ob/dry-run"
+	DRY_RUN_ANNOTATION_VALUE = "true"
+
+	APEX_OVERRIDE_ANNOTATION       = dnsutil.APEX_OVERRIDE_ANNOTATION
+	APEX_OVERRIDE_ANNOTATION_VALUE = dnsutil.APEX_OVERRIDE_ANNOTATION_VALUE
+
+	RRSET_PATCH_STRATEGY = "Patch"
+	RRSET_MERGE_STRATEGY = "Merge"
+	RRSET_ABSENT_ENSURE  = "Absent"
 )
 
+// RRsetOptions are the settings of the operator applied to the synchronization of the RRsets and ClusterRRsets
+type RRsetOptions struct {
+	// DryRun disables the writes to PowerDNS, the pending changes are reported in the status
+	DryRun bool
+	// DefaultTTL is the TTL of the records when not specified
+	DefaultTTL uint32
+	// ClusterID identifies the operator instance owning the records, when several clusters write into the same PowerDNS
+	ClusterID string
+	// ConflictPolicy resolves the conflicts between RRsets and ClusterRRsets claiming the same name and type
+	ConflictPolicy string
+	// CheckUnmanagedRecords prevents the records not written by the operator from being overwritten on the first apply
+	CheckUnmanagedRecords bool
+	// MaintenanceWindows are the periods the changes are applied in, when the zone defines none
+	MaintenanceWindows []dnsv1alpha2.MaintenanceWindow
+	// VerifiedApprovals reports the approvers are checked by the admission webhooks, the approvals are ignored otherwise
+	VerifiedApprovals bool
+}
+
 // RRsetReconciler reconciles a RRset object
 type RRsetReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	PDNSClient PdnsClienter
+	// RRsetOptions are the settings of the synchronization of the RRsets
+	RRsetOptions
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+	// Resync enqueues the resources failed during a PowerDNS outage when the API is available again, if not nil
+	Resync source.Source
 }
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(rrsetsStatusesMetric)
+	metrics.Registry.MustRegister(rrsetsStatusesMetric, rrsetsLastSyncMetric)
 }
 
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=rrsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -97,7 +149,7 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	original := rrset.DeepCopy()
 	if !isDeleted && isModified {
 		meta.RemoveStatusCondition(&rrset.Status.Conditions, "Available")
-		if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+		if err := patchStatusIfChanged(ctx, rrset, original, r.Client); err != nil {
 			log.Error(err, "unable to patch RRSet status")
 			return ctrl.Result{}, err
 		}
@@ -147,11 +199,10 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 					Reason:             RrsetReasonZoneNotAvailable,
 					Message:            RrsetMessageNonExistentZone + err.Error(),
 				})
-				if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
+				if err := commitRrsetStatus(ctx, rrset, original, r.Client); err != nil {
 					log.Error(err, "unable to patch RRSet status")
 					return ctrl.Result{}, err
 				}
-				updateRrsetsMetrics(getRRsetName(rrset), rrset)
 			}
 
 			// Race condition when creating Zone+RRset at the same time
@@ -164,58 +215,69 @@ func (r *RRsetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 	// If a Zone/ClusterZone exists but is in Failed Status
-	zoneIsInFailedStatus := (zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS)
-	if zoneIsInFailedStatus {
-		original = rrset.DeepCopy()
-		rrset.Status.SyncStatus = ptr.To(FAILED_STATUS)
-		rrset.Status.ObservedGeneration = &rrset.Generation
-		meta.SetStatusCondition(&rrset.Status.Conditions, metav1.Condition{
-			Type:               "Available",
-			Status:             metav1.ConditionFalse,
-			LastTransitionTime: metav1.NewTime(time.Now().UTC()),
-			Reason:             RrsetReasonZoneNotAvailable,
-			Message:            RrsetMessageUnavailableZone + zone.GetName(),
-		})
-		if err := r.Status().Patch(ctx, rrset, client.MergeFrom(original)); err != nil {
-			log.Error(err, "unable to patch RRSet status")
+	if zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS {
+		return ctrl.Result{}, rrsetFailedZoneReconcile(ctx, rrset, zone, isDeleted, r.Client, log)
+	}
+
+	opts := r.RRsetOptions
+	opts.DryRun = isDryRun(rrset, r.DryRun)
+	// The records of a RRset are not applied when its namespace is not allowed by the zone
+	if !isDeleted {
+		allowed, err := rrsetNamespaceReconcile(ctx, rrset, zone, opts, r.Client, r.PDNSClient, log)
+		if err != nil || !allowed {
 			return ctrl.Result{}, err
 		}
-
-		// Update metrics
-		updateRrsetsMetrics(getRRsetName(rrset), rrset)
-
-		if isDeleted {
-			if controllerutil.ContainsFinalizer(rrset, METRICS_FINALIZER_NAME) {
-				controllerutil.RemoveFinalizer(rrset, METRICS_FINALIZER_NAME)
-				// Remove resource metrics
-				removeRrsetMetrics(rrset)
-				if err := r.Update(ctx, rrset); err != nil {
-					log.Error(err, "Failed to remove finalizer")
-					return ctrl.Result{}, err
-				}
-			}
+		// A RRset of a namespace allowed again is applied, as if modified
+		if isNamespaceAllowedAgain(rrset) {
+			isModified = true
 		}
-
-		return ctrl.Result{}, nil
 	}
 
-	return rrsetReconcile(ctx, rrset, zone, isModified, isDeleted, lastUpdateTime, r.Scheme, r.Client, r.PDNSClient, log)
+	return rrsetReconcile(ctx, rrset, zone, isModified, isDeleted, opts, lastUpdateTime, r.Scheme, r.Client, r.PDNSClient, log)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RRsetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// We use indexer to ensure that only one RRset exists for DNS entry
+	// We use indexer to find the RRsets claiming a DNS entry, whatever their status
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.Entry.Name", func(rawObj client.Object) []string {
-		// grab the RRset object, extract its name...
-		var RRsetName string
-		if rawObj.(*dnsv1alpha2.RRset).Status.SyncStatus == nil || *rawObj.(*dnsv1alpha2.RRset).Status.SyncStatus == SUCCEEDED_STATUS {
-			RRsetName = getRRsetName(rawObj.(*dnsv1alpha2.RRset)) + "/" + rawObj.(*dnsv1alpha2.RRset).Spec.Type
-		}
-		return []string{RRsetName}
+		return []string{getRRsetEntryIndexKey(rawObj.(*dnsv1alpha2.RRset))}
+	}); err != nil {
+		return err
+	}
+	// We use indexer to find the DNAME redirections above a name
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.DNAME", func(rawObj client.Object) []string {
+		return getDNAMEIndexKeys(rawObj.(*dnsv1alpha2.RRset))
 	}); err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	// We use indexer to list the RRsets of a zone without listing the whole namespace
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.ZoneRef", func(rawObj client.Object) []string {
+		return []string{getZoneRefIndexKey(rawObj.(*dnsv1alpha2.RRset).Spec.ZoneRef)}
+	}); err != nil {
+		return err
+	}
+	// We use indexer to find the RRsets resolved from a Service when its addresses change
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.RRset{}, "RRset.TargetRef", func(rawObj client.Object) []string {
+		return getTargetRefIndexKeys(rawObj.(*dnsv1alpha2.RRset))
+	}); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.RRset{}).
-		Complete(r)
+		Watches(&dnsv1alpha2.RRset{}, enqueueDeletionsFirst()).
+		Watches(&corev1.Service{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.RRsetList{}, "RRset.TargetRef")).
+		// The namespaces allowed, the approvals and the windows required, and the freeze, of a zone are checked again on its changes
+		Watches(&dnsv1alpha2.Zone{}, enqueueRRsetsOfZone(r.Client, false), builder.WithPredicates(zoneChangedPredicate())).
+		Watches(&dnsv1alpha2.ClusterZone{}, enqueueRRsetsOfZone(r.Client, false), builder.WithPredicates(zoneChangedPredicate())).
+		// and on the changes of the labels of the namespaces
+		Watches(&corev1.Namespace{}, enqueueRRsetsOfNamespace(r.Client), builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	if isResolvingConflicts(r.ConflictPolicy) {
+		// The RRsets claiming the same name and type are elected again on the changes of any of them
+		b = b.Watches(&dnsv1alpha2.RRset{}, enqueueContendingRRsets(r.Client, false), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			Watches(&dnsv1alpha2.ClusterRRset{}, enqueueContendingRRsets(r.Client, false), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+	if r.Resync != nil {
+		b = b.WatchesRawSource(r.Resync)
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
 }
@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -120,7 +121,7 @@ var _ = Describe("RRset Controller", func() {
 				},
 				Type:    resourceType,
 				Name:    resourceDNSName,
-				TTL:     resourceTTL,
+				TTL:     ptr.To(resourceTTL),
 				Records: resourceRecords,
 				Comment: &comment,
 			}
@@ -186,6 +187,7 @@ var _ = Describe("RRset Controller", func() {
 
 			Expect(countRrsetsMetrics()-ic).To(Equal(0), "No more metric should have been created")
 			Expect(getRrsetMetricWithLabels(resourceDNSName+"."+zoneRef+".", resourceType, SUCCEEDED_STATUS, resourceName, resourceNamespace)).To(Equal(1.0), "metric should be 1.0")
+			Expect(getRrsetLastSyncMetricWithLabels(resourceName, resourceNamespace)).To(BeNumerically(">", 0), "last sync metric should be set")
 			Expect(getMockedRecordsForType(resourceName, resourceType)).To(Equal(resourceRecords))
 			Expect(getMockedTTL(resourceName, resourceType)).To(Equal(resourceTTL))
 			Expect(getMockedComment(resourceName, resourceType)).To(Equal(resourceComment))
@@ -271,7 +273,7 @@ var _ = Describe("RRset Controller", func() {
 				},
 			}
 			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
-				resource.Spec.TTL = modifiedResourceTTL
+				resource.Spec.TTL = ptr.To(modifiedResourceTTL)
 				return nil
 			})
 			Expect(err).NotTo(HaveOccurred())
@@ -388,7 +390,7 @@ var _ = Describe("RRset Controller", func() {
 			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
 				resource.Spec = dnsv1alpha2.RRsetSpec{
 					Type:    recreationResourceType,
-					TTL:     recreationResourceTTL,
+					TTL:     ptr.To(recreationResourceTTL),
 					Name:    recreationResourceDNSName,
 					Records: []string{recreationRecord},
 					Comment: &recreationResourceComment,
@@ -463,7 +465,7 @@ var _ = Describe("RRset Controller", func() {
 				},
 			}
 			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
-				resource.Spec.TTL = modifiedResourceTTL
+				resource.Spec.TTL = ptr.To(modifiedResourceTTL)
 				resource.Spec.Name = resourceDNSName
 				resource.Spec.Comment = &modifiedResourceComment
 				resource.Spec.Records = modifiedResourceRecords
@@ -517,7 +519,7 @@ var _ = Describe("RRset Controller", func() {
 				fakeResource.Spec = dnsv1alpha2.RRsetSpec{
 					Type:    fakeResourceType,
 					Name:    fakeResourceDNSName,
-					TTL:     fakeResourceTTL,
+					TTL:     ptr.To(fakeResourceTTL),
 					Records: fakeRecords,
 					Comment: &fakeResourceComment,
 					ZoneRef: dnsv1alpha2.ZoneRef{
@@ -582,7 +584,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -650,7 +652,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -717,7 +719,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -783,7 +785,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -850,7 +852,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -917,7 +919,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -984,7 +986,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -1084,7 +1086,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    additionalResourceType,
 					Name:    additionalResourceName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: additionalResourceRecords,
 					Comment: &additionalResourceComment,
 				}
@@ -1152,7 +1154,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    badTypeResourceType,
 					Name:    badTypeResourceDNSName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: badTypeResourceRecords,
 					Comment: &badTypeResourceComment,
 				}
@@ -1215,7 +1217,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    badFormatResourceType,
 					Name:    badFormatResourceDNSName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: badFormatResourceRecords,
 					Comment: &badFormatResourceComment,
 				}
@@ -1278,7 +1280,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    unquotedResourceType,
 					Name:    unquotedResourceDNSName,
-					TTL:     resourceTTL,
+					TTL:     ptr.To(resourceTTL),
 					Records: unquotedResourceRecords,
 					Comment: &unquotedResourceComment,
 				}
@@ -1314,6 +1316,72 @@ var _ = Describe("RRset Controller", func() {
 		})
 	})
 
+	Context("When creating RRset in dry-run mode", func() {
+		It("should report the pending changes without applying them", Label("rrset-creation", "dry-run"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			dryRunResourceName := "dryrun"
+			dryRunResourceRecords := []string{"10.0.0.1"}
+			dryRunLookupKey := types.NamespacedName{
+				Name:      dryRunResourceName,
+				Namespace: resourceNamespace,
+			}
+
+			By("Creating the RRset resource with the dry-run annotation")
+			dryRunResource := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        dryRunResourceName,
+					Namespace:   resourceNamespace,
+					Annotations: map[string]string{DRY_RUN_ANNOTATION: DRY_RUN_ANNOTATION_VALUE},
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{
+						Name: zoneRef,
+						Kind: resourceZoneKind,
+					},
+					Type:    resourceType,
+					Name:    dryRunResourceName,
+					TTL:     ptr.To(resourceTTL),
+					Records: dryRunResourceRecords,
+				},
+			}
+			Expect(k8sClient.Create(ctx, dryRunResource)).To(Succeed())
+			DnsFqdn := getRRsetName(dryRunResource)
+
+			By("Getting the pending changes")
+			pendingResource := &dnsv1alpha2.RRset{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, dryRunLookupKey, pendingResource)
+				return err == nil && pendingResource.IsInExpectedStatus(FIRST_GENERATION, PENDING_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(pendingResource.Status.PendingChanges).To(Equal([]string{"+ " + DnsFqdn + " 300 IN A 10.0.0.1"}))
+			_, found := readFromRecordsMap(makeCanonical(DnsFqdn))
+			Expect(found).To(BeFalse(), "Record should not be created in dry-run mode")
+
+			By("Removing the dry-run annotation")
+			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, pendingResource, func() error {
+				delete(pendingResource.Annotations, DRY_RUN_ANNOTATION)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			appliedResource := &dnsv1alpha2.RRset{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, dryRunLookupKey, appliedResource)
+				return err == nil && appliedResource.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(appliedResource.Status.PendingChanges).To(BeEmpty(), "Pending changes should be cleared")
+			Expect(getMockedRecordsForType(DnsFqdn, resourceType)).To(Equal(dryRunResourceRecords))
+
+			By("Deleting the RRset resource")
+			Expect(k8sClient.Delete(ctx, appliedResource)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, dryRunLookupKey, appliedResource)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
 	Context("When creating a RRset with an existing RRset with same FQDN", func() {
 		It("should reconcile the resource with Failed status", Label("wrong-rrset", "already-existing"), func() {
 			ic := countRrsetsMetrics()
@@ -1343,7 +1411,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    existingResourceType,
 					Name:    existingResourceDNSName,
-					TTL:     existingResourceTTL,
+					TTL:     ptr.To(existingResourceTTL),
 					Records: existingResourceRecords,
 					Comment: &existingResourceComment,
 				}
@@ -1405,7 +1473,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    pendingResourceType,
 					Name:    pendingResourceDNSName,
-					TTL:     pendingResourceTTL,
+					TTL:     ptr.To(pendingResourceTTL),
 					Records: pendingResourceRecords,
 					Comment: &pendingResourceComment,
 				}
@@ -1497,7 +1565,7 @@ var _ = Describe("RRset Controller", func() {
 					},
 					Type:    recreationResourceType,
 					Name:    recreationResourceDNSName,
-					TTL:     recreationResourceTTL,
+					TTL:     ptr.To(recreationResourceTTL),
 					Records: recreationResourceRecords,
 					Comment: &recreationResourceComment,
 				}
@@ -1530,7 +1598,7 @@ var _ = Describe("RRset Controller", func() {
 				resource.Spec = dnsv1alpha2.RRsetSpec{
 					Type:    recreationResourceType,
 					Name:    recreationResourceDNSName,
-					TTL:     recreationResourceTTL,
+					TTL:     ptr.To(recreationResourceTTL),
 					Records: recreationResourceRecords,
 					Comment: &recreationResourceComment,
 					ZoneRef: dnsv1alpha2.ZoneRef{
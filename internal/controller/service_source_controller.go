@@ -0,0 +1,212 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// SOURCE_ENDPOINT_HOSTNAMES_ANNOTATION publishes, for a headless Service, the records of each endpoint hostname
+	// below the hostnames of the Service (e.g. the members of a StatefulSet)
+	SOURCE_ENDPOINT_HOSTNAMES_ANNOTATION = "dns.cav.enablers.ob/endpoint-hostnames"
+)
+
+// serviceGroupVersionKind is the kind of the owner references of the RRsets generated for the Services
+var serviceGroupVersionKind = corev1.SchemeGroupVersion.WithKind("Service")
+
+// ServiceSourceReconciler publishes records for annotated Services:
+// a CNAME record to the external name of the Services of type ExternalName,
+// and A/AAAA records of the ready endpoints of the headless Services
+type ServiceSourceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ExternalName enables the records of the Services of type ExternalName
+	ExternalName bool
+	// Headless enables the records of the headless Services, from their EndpointSlices
+	Headless bool
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+
+func (r *ServiceSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile Service", "Service.Name", req.Name)
+
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, req.NamespacedName, svc); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The generated RRsets are controlled by their zone, they are deleted with the Service
+		if err := deleteSourceRRsets(ctx, req.NamespacedName, serviceGroupVersionKind, "", r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	// The RRsets of a previous Service of the same name are not taken over
+	if err := deleteSourceRRsets(ctx, req.NamespacedName, serviceGroupVersionKind, svc.UID, r.Client); err != nil {
+		log.Error(err, "Failed to delete generated RRsets")
+		return ctrl.Result{}, err
+	}
+
+	// The RRsets of a Service being deleted, not annotated anymore, or not of a published type anymore, are deleted
+	desired := []dnsv1alpha2.RRset{}
+	if svc.DeletionTimestamp.IsZero() && isAnnotatedSource(svc) && ((r.ExternalName && svc.Spec.Type == corev1.ServiceTypeExternalName) || (r.Headless && isHeadlessService(svc))) {
+		settings, err := getSourceSettings(svc.Annotations)
+		if err != nil {
+			// A new reconciliation is triggered on the modification of the annotations
+			log.Error(err, "Invalid annotations, the records are not published")
+			return ctrl.Result{}, nil
+		}
+		if svc.Spec.Type == corev1.ServiceTypeExternalName {
+			desired = getExternalNameRRsets(svc, settings)
+		} else {
+			var endpointSlices discoveryv1.EndpointSliceList
+			if err := r.List(ctx, &endpointSlices, client.InNamespace(svc.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: svc.Name}); err != nil {
+				return ctrl.Result{}, err
+			}
+			desired = getHeadlessServiceRRsets(svc, settings, endpointSlices.Items)
+		}
+	}
+
+	if _, err := reconcileGeneratedRRsets(ctx, svc, desired, r.Scheme, r.Client); err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// isHeadlessService return True if the Service has no cluster IP
+func isHeadlessService(svc *corev1.Service) bool {
+	return svc.Spec.Type != corev1.ServiceTypeExternalName && svc.Spec.ClusterIP == corev1.ClusterIPNone
+}
+
+// getExternalNameRRsets return the CNAME RRsets of the hostnames of an ExternalName Service
+func getExternalNameRRsets(svc *corev1.Service, settings sourceSettings) []dnsv1alpha2.RRset {
+	rrsets := make([]dnsv1alpha2.RRset, 0, len(settings.Hostnames))
+	for _, hostname := range settings.Hostnames {
+		rrsets = append(rrsets, dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(svc.Name+"-cname", hostname)},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Type:    "CNAME",
+				Name:    hostname,
+				TTL:     settings.TTL,
+				Records: []string{makeCanonical(svc.Spec.ExternalName)},
+				ZoneRef: settings.ZoneRef,
+			},
+		})
+	}
+	return rrsets
+}
+
+// getHeadlessServiceRRsets return the A/AAAA RRsets of the ready endpoints of the IP family of a headless Service, for its hostnames
+// and, if requested, for the hostnames of the endpoints below them
+func getHeadlessServiceRRsets(svc *corev1.Service, settings sourceSettings, endpointSlices []discoveryv1.EndpointSlice) []dnsv1alpha2.RRset {
+	withEndpointHostnames := svc.Annotations[SOURCE_ENDPOINT_HOSTNAMES_ANNOTATION] == "true"
+	// Addresses by record type and name
+	addresses := map[string]map[string][]string{"A": {}, "AAAA": {}}
+	for _, endpointSlice := range endpointSlices {
+		rrType := ""
+		switch endpointSlice.AddressType {
+		case discoveryv1.AddressTypeIPv4:
+			rrType = "A"
+		case discoveryv1.AddressTypeIPv6:
+			rrType = "AAAA"
+		default:
+			continue
+		}
+		if !isIPFamilySelected(settings.IPFamily, rrType) {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			// A nil ready condition is an unknown state, to be interpreted as ready
+			if !ptr.Deref(endpoint.Conditions.Ready, true) {
+				continue
+			}
+			for _, hostname := range settings.Hostnames {
+				addresses[rrType][hostname] = append(addresses[rrType][hostname], endpoint.Addresses...)
+				if withEndpointHostnames && ptr.Deref(endpoint.Hostname, "") != "" {
+					name := *endpoint.Hostname + "." + hostname
+					addresses[rrType][name] = append(addresses[rrType][name], endpoint.Addresses...)
+				}
+			}
+		}
+	}
+
+	rrsets := []dnsv1alpha2.RRset{}
+	for _, rrType := range []string{"A", "AAAA"} {
+		names := make([]string, 0, len(addresses[rrType]))
+		for name := range addresses[rrType] {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			records := addresses[rrType][name]
+			slices.Sort(records)
+			rrsets = append(rrsets, dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(svc.Name+"-"+strings.ToLower(rrType), name)},
+				Spec: dnsv1alpha2.RRsetSpec{
+					Type:    rrType,
+					Name:    name,
+					TTL:     settings.TTL,
+					Records: slices.Compact(records),
+					ZoneRef: settings.ZoneRef,
+				},
+			})
+		}
+	}
+	return rrsets
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServiceSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("service-source").
+		For(&corev1.Service{}, builder.WithPredicates(annotatedSourcePredicate(SOURCE_HOSTNAME_ANNOTATION))).
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner)
+	if r.Headless {
+		b = b.Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			// Only the EndpointSlices of the annotated Services are reconciled
+			key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetLabels()[discoveryv1.LabelServiceName]}
+			svc := &corev1.Service{}
+			if key.Name == "" || r.Get(ctx, key, svc) != nil || !isAnnotatedSource(svc) {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: key}}
+		}))
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
@@ -0,0 +1,184 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("Service Source Controller", func() {
+
+	const (
+		zoneName          = "example14.org"
+		resourceName      = "legacy-db"
+		headlessName      = "kafka"
+		resourceNamespace = "example14"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	rrsetNamespacedName := types.NamespacedName{
+		Name:      resourceName + "-cname-db.example14.org",
+		Namespace: resourceNamespace,
+	}
+
+	headlessNamespacedName := types.NamespacedName{
+		Name:      headlessName,
+		Namespace: resourceNamespace,
+	}
+	headlessRRsetNames := []string{headlessName + "-a-kafka-0.kafka.example14.org", headlessName + "-a-kafka.example14.org"}
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instances Service and EndpointSlice")
+		for _, name := range []types.NamespacedName{typeNamespacedName, headlessNamespacedName} {
+			svc := &corev1.Service{}
+			if err := k8sClient.Get(ctx, name, svc); err == nil {
+				Expect(k8sClient.Delete(ctx, svc)).To(Succeed())
+			}
+		}
+		endpointSlice := &discoveryv1.EndpointSlice{}
+		if err := k8sClient.Get(ctx, headlessNamespacedName, endpointSlice); err == nil {
+			Expect(k8sClient.Delete(ctx, endpointSlice)).To(Succeed())
+		}
+
+		By("Checking the generated RRsets are deleted with their Service")
+		for _, name := range append(headlessRRsetNames, rrsetNamespacedName.Name) {
+			rrset := &dnsv1alpha2.RRset{}
+			key := types.NamespacedName{Name: name, Namespace: resourceNamespace}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, key, rrset)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		}
+	})
+
+	Context("When creating an annotated ExternalName Service", func() {
+		It("should publish the CNAME RRset and remove it with the annotation", Label("externalname-creation"), func() {
+			ctx := context.Background()
+			By("Creating the Service")
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+					Annotations: map[string]string{
+						SOURCE_HOSTNAME_ANNOTATION: "db.example14.org",
+						SOURCE_ZONE_ANNOTATION:     zoneName,
+						SOURCE_TTL_ANNOTATION:      "60",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:         corev1.ServiceTypeExternalName,
+					ExternalName: "db.rds.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, svc)).To(Succeed())
+
+			By("Getting the generated RRset")
+			rrset := &dnsv1alpha2.RRset{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+			}, timeout, interval).Should(Succeed())
+			Expect(rrset.Spec.Type).To(Equal("CNAME"))
+			Expect(rrset.Spec.Name).To(Equal("db.example14.org."))
+			Expect(rrset.Spec.TTL).To(Equal(ptr.To(uint32(60))))
+			Expect(rrset.Spec.Records).To(Equal([]string{"db.rds.example.com."}))
+			Expect(rrset.Spec.ZoneRef).To(Equal(dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"}))
+
+			By("Removing the annotation")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, svc)).To(Succeed())
+			delete(svc.Annotations, SOURCE_HOSTNAME_ANNOTATION)
+			Expect(k8sClient.Update(ctx, svc)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+				return errors.IsNotFound(err) || (err == nil && !rrset.DeletionTimestamp.IsZero())
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When creating an annotated headless Service", func() {
+		It("should publish the records of the ready endpoints", Label("headless-creation"), func() {
+			ctx := context.Background()
+			By("Creating the Service and its EndpointSlice")
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      headlessName,
+					Namespace: resourceNamespace,
+					Annotations: map[string]string{
+						SOURCE_HOSTNAME_ANNOTATION:           "kafka.example14.org",
+						SOURCE_ZONE_ANNOTATION:               zoneName,
+						SOURCE_ENDPOINT_HOSTNAMES_ANNOTATION: "true",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: corev1.ClusterIPNone,
+					Ports:     []corev1.ServicePort{{Name: "kafka", Port: 9092}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, svc)).To(Succeed())
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      headlessName,
+					Namespace: resourceNamespace,
+					Labels:    map[string]string{discoveryv1.LabelServiceName: headlessName},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{Addresses: []string{"10.0.0.10"}, Hostname: ptr.To("kafka-0"), Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+					{Addresses: []string{"10.0.0.11"}, Hostname: ptr.To("kafka-1"), Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, endpointSlice)).To(Succeed())
+
+			By("Getting the generated RRsets")
+			for _, name := range headlessRRsetNames {
+				rrset := &dnsv1alpha2.RRset{}
+				Eventually(func() error {
+					return k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: resourceNamespace}, rrset)
+				}, timeout, interval).Should(Succeed())
+				Expect(rrset.Spec.Type).To(Equal("A"))
+				Expect(rrset.Spec.Records).To(Equal([]string{"10.0.0.10"}))
+			}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: headlessName + "-a-kafka-1.kafka.example14.org", Namespace: resourceNamespace}, &dnsv1alpha2.RRset{})).NotTo(Succeed())
+
+			By("Marking the second endpoint ready")
+			Expect(k8sClient.Get(ctx, headlessNamespacedName, endpointSlice)).To(Succeed())
+			endpointSlice.Endpoints[1].Conditions.Ready = ptr.To(true)
+			Expect(k8sClient.Update(ctx, endpointSlice)).To(Succeed())
+			rrset := &dnsv1alpha2.RRset{}
+			Eventually(func() []string {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: headlessRRsetNames[1], Namespace: resourceNamespace}, rrset); err != nil {
+					return nil
+				}
+				return rrset.Spec.Records
+			}, timeout, interval).Should(Equal([]string{"10.0.0.10", "10.0.0.11"}))
+		})
+	})
+})
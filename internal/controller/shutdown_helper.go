@@ -0,0 +1,58 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	DEFAULT_SHUTDOWN_GRACE_PERIOD = 30 * time.Second
+)
+
+// drainingReconciler shields in-flight reconciliations from the manager shutdown: their context is only
+// cancelled once the grace period has elapsed, so that PowerDNS mutations and the corresponding
+// status patches complete. New reconciliations are not started by the controllers once stopped.
+type drainingReconciler struct {
+	reconcile.Reconciler
+	gracePeriod time.Duration
+}
+
+// drainOnShutdown wrap a reconciler to let its in-flight reconciliations complete within the grace
+// period on shutdown. A zero grace period leaves the reconciler unchanged.
+func drainOnShutdown(r reconcile.Reconciler, gracePeriod time.Duration) reconcile.Reconciler {
+	if gracePeriod <= 0 {
+		return r
+	}
+	return &drainingReconciler{Reconciler: r, gracePeriod: gracePeriod}
+}
+
+func (d *drainingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-time.After(d.gracePeriod):
+				cancel()
+			case <-drainCtx.Done():
+			}
+		case <-drainCtx.Done():
+		}
+	}()
+	return d.Reconciler.Reconcile(drainCtx, req)
+}
@@ -0,0 +1,56 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDrainOnShutdown(t *testing.T) {
+	var testCases = []struct {
+		description string
+		gracePeriod time.Duration
+		duration    time.Duration
+		wantErr     error
+	}{
+		{"Reconciliation completed within the grace period", 500 * time.Millisecond, 50 * time.Millisecond, nil},
+		{"Reconciliation exceeding the grace period", 50 * time.Millisecond, 500 * time.Millisecond, context.Canceled},
+		{"No grace period", 0, 500 * time.Millisecond, context.Canceled},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The reconciliation lasts duration, unless its context is cancelled
+			r := drainOnShutdown(reconcile.Func(func(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+				select {
+				case <-time.After(tc.duration):
+					return ctrl.Result{}, nil
+				case <-ctx.Done():
+					return ctrl.Result{}, ctx.Err()
+				}
+			}), tc.gracePeriod)
+
+			// The manager shutdown is requested while the reconciliation is in-flight
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			if err != tc.wantErr {
+				t.Errorf("got %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// Annotations of the Kubernetes objects the source controllers publish records for
+const (
+	SOURCE_HOSTNAME_ANNOTATION  = "dns.cav.enablers.ob/hostname"
+	SOURCE_ZONE_ANNOTATION      = "dns.cav.enablers.ob/zone"
+	SOURCE_ZONE_KIND_ANNOTATION = "dns.cav.enablers.ob/zone-kind"
+	SOURCE_TTL_ANNOTATION       = "dns.cav.enablers.ob/ttl"
+	SOURCE_TARGET_ANNOTATION    = "dns.cav.enablers.ob/target"
+	SOURCE_IP_FAMILY_ANNOTATION = "dns.cav.enablers.ob/ip-family"
+)
+
+// IP families of the addresses published for the objects
+const (
+	IP_FAMILY_IPV4       = "IPv4"
+	IP_FAMILY_IPV6       = "IPv6"
+	IP_FAMILY_DUAL_STACK = "DualStack"
+)
+
+// sourceSettings are the settings of the records published for an annotated object
+type sourceSettings struct {
+	Hostnames []string
+	ZoneRef   dnsv1alpha2.ZoneRef
+	TTL       *uint32
+	// IPFamily of the addresses published, both IPv4 and IPv6 when empty
+	IPFamily string
+}
+
+// isAnnotatedSource return True if records must be published for the object
+func isAnnotatedSource(obj client.Object) bool {
+	return hasAnnotation(obj, SOURCE_HOSTNAME_ANNOTATION)
+}
+
+func hasAnnotation(obj client.Object, annotation string) bool {
+	_, ok := obj.GetAnnotations()[annotation]
+	return ok
+}
+
+// getSourceSettings return the settings of the annotations of an object: the comma separated hostnames, all in the zone,
+// the zone and its kind (Zone, in the namespace of the object, by default), the TTL in seconds and the IP family.
+// Without hostname annotation, the hostnames derived from the object which belong to the zone are used.
+func getSourceSettings(annotations map[string]string, derivedHostnames ...string) (sourceSettings, error) {
+	settings := sourceSettings{
+		ZoneRef: dnsv1alpha2.ZoneRef{Name: strings.TrimSuffix(annotations[SOURCE_ZONE_ANNOTATION], "."), Kind: "Zone"},
+	}
+	if settings.ZoneRef.Name == "" {
+		return settings, fmt.Errorf("annotation %s is required", SOURCE_ZONE_ANNOTATION)
+	}
+	if kind, ok := annotations[SOURCE_ZONE_KIND_ANNOTATION]; ok {
+		if kind != "Zone" && kind != "ClusterZone" {
+			return settings, fmt.Errorf("annotation %s must be Zone or ClusterZone", SOURCE_ZONE_KIND_ANNOTATION)
+		}
+		settings.ZoneRef.Kind = kind
+	}
+	if ttl, ok := annotations[SOURCE_TTL_ANNOTATION]; ok {
+		value, err := strconv.ParseUint(ttl, 10, 31)
+		if err != nil {
+			return settings, fmt.Errorf("annotation %s must be a number of seconds", SOURCE_TTL_ANNOTATION)
+		}
+		settings.TTL = ptr.To(uint32(value))
+	}
+	if family, ok := annotations[SOURCE_IP_FAMILY_ANNOTATION]; ok {
+		if family != IP_FAMILY_IPV4 && family != IP_FAMILY_IPV6 && family != IP_FAMILY_DUAL_STACK {
+			return settings, fmt.Errorf("annotation %s must be IPv4, IPv6 or DualStack", SOURCE_IP_FAMILY_ANNOTATION)
+		}
+		if family != IP_FAMILY_DUAL_STACK {
+			settings.IPFamily = family
+		}
+	}
+	zone := strings.ToLower(makeCanonical(settings.ZoneRef.Name))
+	hostnames, annotated := annotations[SOURCE_HOSTNAME_ANNOTATION]
+	if !annotated {
+		for _, hostname := range derivedHostnames {
+			hostname = strings.ToLower(makeCanonical(hostname))
+			if isInZone(hostname, zone) && !slices.Contains(settings.Hostnames, hostname) {
+				settings.Hostnames = append(settings.Hostnames, hostname)
+			}
+		}
+		if len(settings.Hostnames) == 0 {
+			return settings, fmt.Errorf("no hostname in zone %s", settings.ZoneRef.Name)
+		}
+		return settings, nil
+	}
+	for _, hostname := range strings.Split(hostnames, ",") {
+		hostname = strings.ToLower(makeCanonical(strings.TrimSpace(hostname)))
+		if hostname == "" {
+			continue
+		}
+		if !isInZone(hostname, zone) {
+			return settings, fmt.Errorf("hostname %s is not in zone %s", hostname, settings.ZoneRef.Name)
+		}
+		settings.Hostnames = append(settings.Hostnames, hostname)
+	}
+	if len(settings.Hostnames) == 0 {
+		return settings, fmt.Errorf("annotation %s has no hostname", SOURCE_HOSTNAME_ANNOTATION)
+	}
+	return settings, nil
+}
+
+// annotatedSourcePredicate filters the events of the objects with the annotation, or not anymore, for records,
+// including their deletion: the generated RRsets are controlled by their zone, they are deleted by the reconciliation.
+// A deletion missed while the operator is stopped is caught up on start, through the watch of the owned RRsets.
+func annotatedSourcePredicate(annotation string) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasAnnotation(e.Object, annotation)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return hasAnnotation(e.ObjectOld, annotation) || hasAnnotation(e.ObjectNew, annotation)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasAnnotation(e.Object, annotation)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return hasAnnotation(e.Object, annotation)
+		},
+	}
+}
+
+// isIPFamilySelected return True if the addresses of the A or AAAA type are published with the IP family:
+// IPv4 for A, IPv6 for AAAA, or both when empty
+func isIPFamilySelected(family string, rrType string) bool {
+	return family == "" || (family == IP_FAMILY_IPV4) == (rrType == "A")
+}
+
+// getTargetRRsets return the RRsets of the hostnames pointing to the targets: A/AAAA RRsets of the IP addresses
+// of the IP family, or a CNAME RRset of the first hostname target when there is no such IP address
+func getTargetRRsets(ownerName string, settings sourceSettings, targets []string) []dnsv1alpha2.RRset {
+	records := map[string][]string{}
+	for _, target := range targets {
+		addr, err := netip.ParseAddr(target)
+		switch {
+		case err != nil:
+			records["CNAME"] = append(records["CNAME"], makeCanonical(target))
+		case addr.Unmap().Is4():
+			if isIPFamilySelected(settings.IPFamily, "A") {
+				records["A"] = append(records["A"], addr.Unmap().String())
+			}
+		case isIPFamilySelected(settings.IPFamily, "AAAA"):
+			records["AAAA"] = append(records["AAAA"], addr.String())
+		}
+	}
+	if len(records["A"]) > 0 || len(records["AAAA"]) > 0 {
+		delete(records, "CNAME")
+	} else if len(records["CNAME"]) > 1 {
+		slices.Sort(records["CNAME"])
+		records["CNAME"] = records["CNAME"][:1]
+	}
+
+	rrsets := []dnsv1alpha2.RRset{}
+	for _, rrType := range []string{"A", "AAAA", "CNAME"} {
+		if len(records[rrType]) == 0 {
+			continue
+		}
+		slices.Sort(records[rrType])
+		for _, hostname := range settings.Hostnames {
+			rrsets = append(rrsets, dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(ownerName+"-"+strings.ToLower(rrType), hostname)},
+				Spec: dnsv1alpha2.RRsetSpec{
+					Type:    rrType,
+					Name:    hostname,
+					TTL:     settings.TTL,
+					Records: slices.Compact(slices.Clone(records[rrType])),
+					ZoneRef: settings.ZoneRef,
+				},
+			})
+		}
+	}
+	return rrsets
+}
+
+// splitSourceTargets return the comma separated targets of an annotation
+func splitSourceTargets(in string) []string {
+	targets := []string{}
+	for _, target := range strings.Split(in, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,149 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetSourceSettings(t *testing.T) {
+	var testCases = []struct {
+		description string
+		annotations map[string]string
+		want        sourceSettings
+		wantErr     bool
+	}{
+		{
+			"Default kind",
+			map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org"},
+			sourceSettings{Hostnames: []string{"db.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			false,
+		},
+		{
+			"Several hostnames, ClusterZone and TTL",
+			map[string]string{SOURCE_HOSTNAME_ANNOTATION: "DB.example.org., db2.example.org", SOURCE_ZONE_ANNOTATION: "example.org.", SOURCE_ZONE_KIND_ANNOTATION: "ClusterZone", SOURCE_TTL_ANNOTATION: "60"},
+			sourceSettings{Hostnames: []string{"db.example.org.", "db2.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "ClusterZone"}, TTL: ptr.To(uint32(60))},
+			false,
+		},
+		{
+			"IPv6 family",
+			map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org", SOURCE_IP_FAMILY_ANNOTATION: "IPv6"},
+			sourceSettings{Hostnames: []string{"db.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}, IPFamily: IP_FAMILY_IPV6},
+			false,
+		},
+		{
+			"Dual-stack family",
+			map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org", SOURCE_IP_FAMILY_ANNOTATION: "DualStack"},
+			sourceSettings{Hostnames: []string{"db.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			false,
+		},
+		{"Missing zone", map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org"}, sourceSettings{}, true},
+		{"Invalid IP family", map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org", SOURCE_IP_FAMILY_ANNOTATION: "ipv4"}, sourceSettings{}, true},
+		{"Invalid kind", map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org", SOURCE_ZONE_KIND_ANNOTATION: "Other"}, sourceSettings{}, true},
+		{"Invalid TTL", map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.org", SOURCE_ZONE_ANNOTATION: "example.org", SOURCE_TTL_ANNOTATION: "1m"}, sourceSettings{}, true},
+		{"Hostname out of the zone", map[string]string{SOURCE_HOSTNAME_ANNOTATION: "db.example.com", SOURCE_ZONE_ANNOTATION: "example.org"}, sourceSettings{}, true},
+		{"No hostname", map[string]string{SOURCE_HOSTNAME_ANNOTATION: " , ", SOURCE_ZONE_ANNOTATION: "example.org"}, sourceSettings{}, true},
+		{
+			"Derived hostnames in the zone",
+			map[string]string{SOURCE_ZONE_ANNOTATION: "example.org"},
+			sourceSettings{Hostnames: []string{"www.example.org.", "*.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+			false,
+		},
+		{"No derived hostname in the zone", map[string]string{SOURCE_ZONE_ANNOTATION: "example.net"}, sourceSettings{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := getSourceSettings(tc.annotations, "www.example.org", "*.example.org", "www.example.com", "WWW.example.org.")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v", err)
+			}
+			if err == nil && !cmp.Equal(got, tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetHeadlessServiceRRsets(t *testing.T) {
+	endpointSlices := []discoveryv1.EndpointSlice{
+		{
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.2"}, Hostname: ptr.To("db-1")},
+				{Addresses: []string{"10.0.0.1"}, Hostname: ptr.To("db-0"), Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+				{Addresses: []string{"10.0.0.3"}, Hostname: ptr.To("db-2"), Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+			},
+		},
+		{
+			AddressType: discoveryv1.AddressTypeIPv6,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"fd00::1"}, Hostname: ptr.To("db-0")}},
+		},
+		{
+			AddressType: discoveryv1.AddressTypeFQDN,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"db.example.com"}}},
+		},
+	}
+	var testCases = []struct {
+		description string
+		annotations map[string]string
+		ipFamily    string
+		want        map[string][]string
+	}{
+		{
+			"Service hostnames only",
+			nil,
+			"",
+			map[string][]string{"db-a-db.example.org": {"10.0.0.1", "10.0.0.2"}, "db-aaaa-db.example.org": {"fd00::1"}},
+		},
+		{
+			"IPv4 family",
+			nil,
+			IP_FAMILY_IPV4,
+			map[string][]string{"db-a-db.example.org": {"10.0.0.1", "10.0.0.2"}},
+		},
+		{
+			"Endpoint hostnames",
+			map[string]string{SOURCE_ENDPOINT_HOSTNAMES_ANNOTATION: "true"},
+			"",
+			map[string][]string{
+				"db-a-db-0.db.example.org":    {"10.0.0.1"},
+				"db-a-db-1.db.example.org":    {"10.0.0.2"},
+				"db-a-db.example.org":         {"10.0.0.1", "10.0.0.2"},
+				"db-aaaa-db-0.db.example.org": {"fd00::1"},
+				"db-aaaa-db.example.org":      {"fd00::1"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			settings := sourceSettings{Hostnames: []string{"db.example.org."}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}, IPFamily: tc.ipFamily}
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db", Annotations: tc.annotations}}
+			got := map[string][]string{}
+			for _, rrset := range getHeadlessServiceRRsets(svc, settings, endpointSlices) {
+				got[rrset.Name] = rrset.Spec.Records
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected RRsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
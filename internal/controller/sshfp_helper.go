@@ -0,0 +1,90 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	SSHFP_SHA1_FINGERPRINT   = "SHA1"
+	SSHFP_SHA256_FINGERPRINT = "SHA256"
+)
+
+// sshfpAlgorithms are the SSHFP algorithm numbers (RFC 4255, RFC 6594, RFC 7479, RFC 8709) of the SSH public key types
+var sshfpAlgorithms = map[string]int{
+	"ssh-rsa":             1,
+	"ssh-dss":             2,
+	"ecdsa-sha2-nistp256": 3,
+	"ecdsa-sha2-nistp384": 3,
+	"ecdsa-sha2-nistp521": 3,
+	"ssh-ed25519":         4,
+	"ssh-ed448":           6,
+}
+
+// sshfpFingerprintTypes are the SSHFP fingerprint type numbers
+var sshfpFingerprintTypes = map[string]int{
+	SSHFP_SHA1_FINGERPRINT:   1,
+	SSHFP_SHA256_FINGERPRINT: 2,
+}
+
+// sshfpRecords return the sorted contents of the SSHFP records of SSH public keys in the OpenSSH format, one per line
+// (e.g. "ssh-ed25519 AAAAC3Nza... root@host"), for each fingerprint type. Empty lines and comments are ignored.
+func sshfpRecords(publicKeys string, fingerprintTypes []string) ([]string, error) {
+	if len(fingerprintTypes) == 0 {
+		fingerprintTypes = []string{SSHFP_SHA256_FINGERPRINT}
+	}
+	records := []string{}
+	for line := range strings.Lines(publicKeys) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid SSH public key %q", strings.TrimSpace(line))
+		}
+		algorithm, ok := sshfpAlgorithms[fields[0]]
+		if !ok {
+			return nil, fmt.Errorf("unsupported SSH public key type %q", fields[0])
+		}
+		blob, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH public key %q: %w", fields[0], err)
+		}
+		// The key blob starts with its type, as a length-prefixed string
+		if len(blob) < 4 || uint64(len(blob)) < 4+uint64(binary.BigEndian.Uint32(blob)) || string(blob[4:4+binary.BigEndian.Uint32(blob)]) != fields[0] {
+			return nil, fmt.Errorf("invalid SSH public key %q: key type mismatch", fields[0])
+		}
+		for _, fpType := range fingerprintTypes {
+			var fingerprint []byte
+			switch fpType {
+			case SSHFP_SHA1_FINGERPRINT:
+				sum := sha1.Sum(blob) //nolint:gosec
+				fingerprint = sum[:]
+			case SSHFP_SHA256_FINGERPRINT:
+				sum := sha256.Sum256(blob)
+				fingerprint = sum[:]
+			default:
+				return nil, fmt.Errorf("unsupported fingerprint type %q", fpType)
+			}
+			records = append(records, fmt.Sprintf("%d %d %x", algorithm, sshfpFingerprintTypes[fpType], fingerprint))
+		}
+	}
+	slices.Sort(records)
+	return slices.Compact(records), nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSshfpRecords(t *testing.T) {
+	ed25519Key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINTOZofaFO/qPYtW+zOkeeVIw+0ELtEbFj9DkZnWxGp7 root@web1"
+	sha1Record := "4 1 c254bfdf260efd999723b1c4330d62cc97bbfd77"
+	sha256Record := "4 2 844b3faa2da49d9ac4a941ee52172ad2b99e435468b80c434b3de6997edee177"
+
+	var testCases = []struct {
+		description      string
+		publicKeys       string
+		fingerprintTypes []string
+		want             []string
+		wantErr          bool
+	}{
+		{"Default fingerprint type", ed25519Key, nil, []string{sha256Record}, false},
+		{"All fingerprint types", ed25519Key, []string{SSHFP_SHA256_FINGERPRINT, SSHFP_SHA1_FINGERPRINT}, []string{sha1Record, sha256Record}, false},
+		{"Comments and empty lines", "# host keys\n\n" + ed25519Key + "\n", nil, []string{sha256Record}, false},
+		{"Duplicated keys", ed25519Key + "\n" + ed25519Key, nil, []string{sha256Record}, false},
+		{"No key", "", nil, []string{}, false},
+		{"Unsupported key type", "ssh-foo AAAAC3NzaC1lZDI1NTE5AAAAINTOZofaFO/qPYtW+zOkeeVIw+0ELtEbFj9DkZnWxGp7", nil, nil, true},
+		{"Invalid base64", "ssh-ed25519 not-base64", nil, nil, true},
+		{"Key type mismatch", "ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAINTOZofaFO/qPYtW+zOkeeVIw+0ELtEbFj9DkZnWxGp7", nil, nil, true},
+		{"Missing key", "ssh-ed25519", nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			records, err := sshfpRecords(tc.publicKeys, tc.fingerprintTypes)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if !cmp.Equal(records, tc.want) {
+				t.Errorf("got %v, want %v", records, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	SSHFPReasonSourceNotAvailable = "SourceNotAvailable"
+	SSHFPReasonInvalidKeys        = "InvalidKeys"
+	SSHFPReasonGenerationFailed   = "GenerationFailed"
+	SSHFPReasonGenerated          = "RRsetsGenerated"
+	SSHFPMessageGenerated         = "SSHFP RRsets generated from the host keys"
+)
+
+// SSHFPRecordReconciler reconciles a SSHFPRecord object
+type SSHFPRecordReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=sshfprecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=sshfprecords/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=sshfprecords/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *SSHFPRecordReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile SSHFPRecord", "SSHFPRecord.Name", req.Name)
+
+	// SSHFPRecord
+	sshfp := &dnsv1alpha2.SSHFPRecord{}
+	err := r.Get(ctx, req.NamespacedName, sshfp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !sshfp.DeletionTimestamp.IsZero() {
+		// The generated RRsets are controlled by their zone, they are deleted with the SSHFPRecord
+		if err := deleteGeneratedRRsets(ctx, sshfp, r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := addGeneratorFinalizer(ctx, sshfp, r.Client); err != nil {
+		log.Error(err, "Failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	// The source is watched, a new reconciliation is triggered on its creation or modification
+	publicKeys, err := r.getPublicKeys(ctx, sshfp)
+	if err != nil {
+		if err := patchSSHFPRecordStatus(ctx, sshfp, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  SSHFPReasonSourceNotAvailable,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch SSHFPRecord status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	desired := []dnsv1alpha2.RRset{}
+	hosts := make([]string, 0, len(publicKeys))
+	for host := range publicKeys {
+		hosts = append(hosts, host)
+	}
+	slices.Sort(hosts)
+	for _, host := range hosts {
+		records, err := sshfpRecords(publicKeys[host], sshfp.Spec.FingerprintTypes)
+		if err == nil && len(records) == 0 {
+			err = fmt.Errorf("no SSH public key")
+		}
+		if err != nil {
+			if err := patchSSHFPRecordStatus(ctx, sshfp, nil, FAILED_STATUS, r.Client, metav1.Condition{
+				Type:    "Available",
+				Status:  metav1.ConditionFalse,
+				Reason:  SSHFPReasonInvalidKeys,
+				Message: fmt.Sprintf("host %s: %v", host, err),
+			}); err != nil {
+				log.Error(err, "unable to patch SSHFPRecord status")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		desired = append(desired, dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(sshfp.Name, host)},
+			Spec: dnsv1alpha2.RRsetSpec{
+				Type:    "SSHFP",
+				Name:    host,
+				TTL:     sshfp.Spec.TTL,
+				Records: records,
+				ZoneRef: sshfp.Spec.ZoneRef,
+			},
+		})
+	}
+
+	names, err := reconcileGeneratedRRsets(ctx, sshfp, desired, r.Scheme, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		if err := patchSSHFPRecordStatus(ctx, sshfp, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  SSHFPReasonGenerationFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch SSHFPRecord status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := patchSSHFPRecordStatus(ctx, sshfp, names, SUCCEEDED_STATUS, r.Client, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  SSHFPReasonGenerated,
+		Message: SSHFPMessageGenerated,
+	}); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch SSHFPRecord status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getPublicKeys return the public keys of the source of the SSHFPRecord, by host
+func (r *SSHFPRecordReconciler) getPublicKeys(ctx context.Context, sshfp *dnsv1alpha2.SSHFPRecord) (map[string]string, error) {
+	source := sshfp.Spec.Source
+	if source.ConfigMap != nil {
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: sshfp.Namespace, Name: source.ConfigMap.Name}, cm); err != nil {
+			return nil, err
+		}
+		return cm.Data, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: sshfp.Namespace, Name: source.Secret.Name}, secret); err != nil {
+		return nil, err
+	}
+	publicKeys := make(map[string]string, len(secret.Data))
+	for host, keys := range secret.Data {
+		publicKeys[host] = string(keys)
+	}
+	return publicKeys, nil
+}
+
+func patchSSHFPRecordStatus(ctx context.Context, sshfp *dnsv1alpha2.SSHFPRecord, rrsets []string, status string, cl client.Client, condition metav1.Condition) error {
+	original := sshfp.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&sshfp.Status.Conditions, condition)
+	if rrsets != nil {
+		sshfp.Status.RRsets = rrsets
+	}
+	sshfp.Status.SyncStatus = ptr.To(status)
+	sshfp.Status.ObservedGeneration = ptr.To(sshfp.GetGeneration())
+	return cl.Status().Patch(ctx, sshfp, client.MergeFrom(original))
+}
+
+// getSSHFPSourceKey return the "<kind>/<name>" key of the source of a SSHFPRecord
+func getSSHFPSourceKey(source dnsv1alpha2.SSHFPSource) string {
+	if source.ConfigMap != nil {
+		return "ConfigMap/" + source.ConfigMap.Name
+	}
+	return "Secret/" + ptr.Deref(source.Secret, corev1.LocalObjectReference{}).Name
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SSHFPRecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// We use indexer to find the SSHFPRecords to reconcile on a modification of their source
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.SSHFPRecord{}, "SSHFPRecord.Source", func(rawObj client.Object) []string {
+		return []string{getSSHFPSourceKey(rawObj.(*dnsv1alpha2.SSHFPRecord).Spec.Source)}
+	}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.SSHFPRecord{}).
+		Watches(&dnsv1alpha2.SSHFPRecord{}, enqueueDeletionsFirst()).
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner).
+		Watches(&corev1.ConfigMap{}, enqueueForSourceObject(r.Client, &dnsv1alpha2.SSHFPRecordList{}, "SSHFPRecord.Source", "ConfigMap")).
+		Watches(&corev1.Secret{}, enqueueForSourceObject(r.Client, &dnsv1alpha2.SSHFPRecordList{}, "SSHFPRecord.Source", "Secret")).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
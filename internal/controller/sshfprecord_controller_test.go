@@ -0,0 +1,234 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("SSHFPRecord Controller", func() {
+
+	const (
+		zoneName          = "example10.org"
+		resourceName      = "hosts"
+		resourceNamespace = "example10"
+		sourceName        = "host-keys"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example10.org", "ns2.example10.org"}
+	hostKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAINTOZofaFO/qPYtW+zOkeeVIw+0ELtEbFj9DkZnWxGp7 root@web1"
+	hostRecord := "4 2 844b3faa2da49d9ac4a941ee52172ad2b99e435468b80c434b3de6997edee177"
+	rotatedHostKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOK2eGSdYDtDPAdKNHOUxlX/MT0/tdjlQh8xwQdQ+GCi root@web1"
+	rotatedHostRecord := "4 2 5e603b6fde7690b79b80391cd1dcd377e3121bde3f8a02753dbde52a623123bb"
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	rrsetNamespacedName := types.NamespacedName{
+		Name:      resourceName + "-web1",
+		Namespace: resourceNamespace,
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+
+		By("creating the source ConfigMap")
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sourceName,
+				Namespace: resourceNamespace,
+			},
+			Data: map[string]string{"web1": hostKey},
+		}
+		Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance SSHFPRecord")
+		sshfp := &dnsv1alpha2.SSHFPRecord{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, sshfp); err == nil {
+			Expect(k8sClient.Delete(ctx, sshfp)).To(Succeed())
+		}
+		Expect(k8sClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: sourceName, Namespace: resourceNamespace}})).To(Succeed())
+
+		By("Checking the generated RRset is deleted with its generator")
+		rrset := &dnsv1alpha2.RRset{}
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	Context("When creating a SSHFPRecord", func() {
+		It("should generate the SSHFP RRsets and update them on key rotation", Label("sshfprecord-creation", "key-rotation"), func() {
+			ctx := context.Background()
+			By("Creating the SSHFPRecord")
+			sshfp := &dnsv1alpha2.SSHFPRecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.SSHFPRecordSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.SSHFPSource{ConfigMap: &corev1.LocalObjectReference{Name: sourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshfp)).To(Succeed())
+
+			By("Getting the generated RRset")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, sshfp)
+				return err == nil && sshfp.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(sshfp.Status.RRsets).To(Equal([]string{rrsetNamespacedName.Name}))
+			rrset := &dnsv1alpha2.RRset{}
+			Expect(k8sClient.Get(ctx, rrsetNamespacedName, rrset)).To(Succeed())
+			Expect(rrset.Spec.Type).To(Equal("SSHFP"))
+			Expect(rrset.Spec.Name).To(Equal("web1"))
+			Expect(rrset.Spec.Records).To(Equal([]string{hostRecord}))
+
+			By("Rotating the host key")
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: resourceNamespace}, cm)).To(Succeed())
+			cm.Data["web1"] = rotatedHostKey
+			Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+				return err == nil && len(rrset.Spec.Records) == 1 && rrset.Spec.Records[0] == rotatedHostRecord
+			}, timeout, interval).Should(BeTrue())
+
+			By("Removing the host")
+			delete(cm.Data, "web1")
+			Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+				return errors.IsNotFound(err) || (err == nil && !rrset.DeletionTimestamp.IsZero())
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When creating a SSHFPRecord generating an existing RRset", func() {
+		It("should not take over the RRset", Label("sshfprecord-creation", "existing-rrset"), func() {
+			ctx := context.Background()
+			By("Creating the RRset")
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rrsetNamespacedName.Name,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					Type:    "A",
+					Name:    "web1",
+					TTL:     ptr.To(uint32(300)),
+					Records: []string{"1.1.1.1"},
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rrset)).To(Succeed())
+
+			By("Creating the SSHFPRecord")
+			sshfp := &dnsv1alpha2.SSHFPRecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.SSHFPRecordSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.SSHFPSource{ConfigMap: &corev1.LocalObjectReference{Name: sourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshfp)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, sshfp)
+				return err == nil && sshfp.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(k8sClient.Get(ctx, rrsetNamespacedName, rrset)).To(Succeed())
+			Expect(rrset.Spec.Type).To(Equal("A"))
+			Expect(rrset.GetOwnerReferences()).NotTo(ContainElement(HaveField("UID", sshfp.GetUID())))
+
+			By("Deleting the RRset")
+			Expect(k8sClient.Delete(ctx, rrset)).To(Succeed())
+		})
+	})
+
+	Context("When creating a SSHFPRecord with a missing source", func() {
+		It("should reconcile the resource with Failed status", Label("sshfprecord-creation", "missing-source"), func() {
+			ctx := context.Background()
+			By("Creating the SSHFPRecord")
+			sshfp := &dnsv1alpha2.SSHFPRecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.SSHFPRecordSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.SSHFPSource{Secret: &corev1.LocalObjectReference{Name: "nonexistent"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshfp)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, sshfp)
+				return err == nil && sshfp.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,59 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// EMPTY_MERGE_PATCH is the merge patch of an object identical to the original
+const EMPTY_MERGE_PATCH = "{}"
+
+// patchStatusIfChanged patch the status of the object, modified from the original, only when it differs:
+// most reconciliations find PowerDNS in sync, and patching the same status again is a useless API request
+func patchStatusIfChanged(ctx context.Context, obj client.Object, original client.Object, cl client.Client) error {
+	patch := client.MergeFrom(original)
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	if string(data) == EMPTY_MERGE_PATCH {
+		return nil
+	}
+	return cl.Status().Patch(ctx, obj, patch)
+}
+
+// commitRrsetStatus patch the status of the RRset if changed, and update its metrics with it
+func commitRrsetStatus(ctx context.Context, gr dnsv1alpha2.GenericRRset, original dnsv1alpha2.GenericRRset, cl client.Client) error {
+	if err := patchStatusIfChanged(ctx, gr, original, cl); err != nil {
+		return err
+	}
+	if gr.GetStatus().SyncStatus != nil {
+		updateRrsetsMetrics(getRRsetName(gr), gr)
+	}
+	return nil
+}
+
+// commitZoneStatus patch the status of the zone if changed, and update its metrics with it
+func commitZoneStatus(ctx context.Context, gz dnsv1alpha2.GenericZone, original dnsv1alpha2.GenericZone, cl client.Client) error {
+	if err := patchStatusIfChanged(ctx, gz, original, cl); err != nil {
+		return err
+	}
+	if gz.GetStatus().SyncStatus != nil {
+		updateZonesMetrics(gz)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestPatchStatusIfChangedUnchanged(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	rrset := &dnsv1alpha2.RRset{
+		ObjectMeta: metav1.ObjectMeta{Name: "www", Namespace: "default", Generation: 2},
+		Spec:       dnsv1alpha2.RRsetSpec{Type: "A", Name: "www", Records: []string{"1.1.1.1"}, ZoneRef: dnsv1alpha2.ZoneRef{Name: "example.org", Kind: "Zone"}},
+		Status: dnsv1alpha2.RRsetStatus{
+			SyncStatus:         ptr.To(SUCCEEDED_STATUS),
+			ObservedGeneration: ptr.To(int64(2)),
+			Conditions:         []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, LastTransitionTime: transition, Reason: RrsetReasonSynced, Message: RrsetMessageSyncSucceeded}},
+		},
+	}
+	original := rrset.DeepCopy()
+
+	// The status computed again by a reconciliation finding PowerDNS in sync
+	conditions := rrset.Status.Conditions
+	meta.SetStatusCondition(&conditions, metav1.Condition{Type: "Available", Status: metav1.ConditionTrue, LastTransitionTime: metav1.Now(), Reason: RrsetReasonSynced, Message: RrsetMessageSyncSucceeded})
+	rrset.SetStatus(dnsv1alpha2.RRsetStatus{
+		SyncStatus:         ptr.To(SUCCEEDED_STATUS),
+		ObservedGeneration: ptr.To(rrset.GetGeneration()),
+		Conditions:         conditions,
+	})
+
+	// Without any change, the API server is not requested: the nil client is not used
+	if err := patchStatusIfChanged(context.Background(), rrset, original, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
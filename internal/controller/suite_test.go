@@ -22,6 +22,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -57,8 +58,11 @@ var (
 )
 
 var (
-	zones   sync.Map
-	records sync.Map
+	zones       sync.Map
+	records     sync.Map
+	retransfers atomic.Int32
+	objects     sync.Map
+	metadata    sync.Map
 )
 
 const (
@@ -193,8 +197,13 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
+		},
+		RRsetOptions: RRsetOptions{
+			DefaultTTL: DEFAULT_TTL,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -203,8 +212,13 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
+		},
+		RRsetOptions: RRsetOptions{
+			DefaultTTL: DEFAULT_TTL,
 		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -213,9 +227,13 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
+			SOASerials: mockSOASerials,
 		},
+		ZoneOptions: ZoneOptions{DNSSECKeyRolloverDays: DEFAULT_DNSSEC_KEY_ROLLOVER_DAYS},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -223,9 +241,71 @@ var _ = BeforeSuite(func() {
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		PDNSClient: PdnsClienter{
-			Records: m.Records,
-			Zones:   m.Zones,
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
+			SOASerials: mockSOASerials,
+		},
+		ZoneOptions: ZoneOptions{DNSSECKeyRolloverDays: DEFAULT_DNSSEC_KEY_ROLLOVER_DAYS},
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ZoneBackupReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		PDNSClient: PdnsClienter{
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
+		},
+		NewObjectStorageClient: NewMockObjectStorageClient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&SSHFPRecordReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&TLSARecordReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&MailDomainReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&DomainVerificationReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ServiceSourceReconciler{
+		Client:       k8sManager.GetClient(),
+		Scheme:       k8sManager.GetScheme(),
+		ExternalName: true,
+		Headless:     true,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ZoneRestoreReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		PDNSClient: PdnsClienter{
+			Records:    m.Records,
+			Zones:      m.Zones,
+			Cryptokeys: m.Cryptokeys,
+			Metadata:   m.Metadata,
 		},
+		NewObjectStorageClient: NewMockObjectStorageClient,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -249,6 +329,13 @@ var _ = BeforeSuite(func() {
 		"example5",
 		"example6",
 		"example7",
+		"example8",
+		"example9",
+		"example10",
+		"example11",
+		"example12",
+		"example13",
+		"example14",
 	}
 
 	for _, n := range namespaces {
@@ -273,17 +360,23 @@ var _ = AfterSuite(func() {
 })
 
 type mockClient struct {
-	Zones   mockZonesClient
-	Records mockRecordsClient
+	Zones      mockZonesClient
+	Records    mockRecordsClient
+	Cryptokeys mockCryptokeysClient
+	Metadata   mockMetadataClient
 }
 
 type mockZonesClient struct{}
 type mockRecordsClient struct{}
+type mockCryptokeysClient struct{}
+type mockMetadataClient struct{}
 
 func NewMockClient() mockClient {
 	return mockClient{
-		Zones:   mockZonesClient{},
-		Records: mockRecordsClient{},
+		Zones:      mockZonesClient{},
+		Records:    mockRecordsClient{},
+		Cryptokeys: mockCryptokeysClient{},
+		Metadata:   mockMetadataClient{},
 	}
 }
 
@@ -332,6 +425,15 @@ func (m mockZonesClient) Add(ctx context.Context, zone *powerdns.Zone) (*powerdn
 	return zone, nil
 }
 
+// mockSOASerials return the serial of the mocked zone, as served by its primaries
+func mockSOASerials(ctx context.Context, server string, zone string) (uint32, error) {
+	localZone, ok := readFromZonesMap(makeCanonical(zone))
+	if !ok {
+		return 0, fmt.Errorf("zone %s not served", zone)
+	}
+	return ptr.Deref(localZone.Serial, 0), nil
+}
+
 func (m mockZonesClient) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
 	// Specific behaviour to
 	// for "fake" domain, return an error
@@ -406,6 +508,53 @@ func (m mockZonesClient) Change(ctx context.Context, domain string, zone *powerd
 	return nil
 }
 
+func (m mockZonesClient) AxfrRetrieve(ctx context.Context, domain string) (*powerdns.AxfrRetrieveResult, error) {
+	// Specific behaviour to
+	// for "fake" domain, return an error
+	if domain == FAKE_SITE {
+		return nil, &powerdns.Error{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+			Message:    "Internal Server Error",
+		}
+	}
+
+	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
+		return nil, powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	retransfers.Add(1)
+	return &powerdns.AxfrRetrieveResult{Result: ptr.To("Added retrieval request for '" + makeCanonical(domain) + "' from primary")}, nil
+}
+
+func (m mockZonesClient) Export(ctx context.Context, domain string) (powerdns.Export, error) {
+	// Specific behaviour to
+	// for "fake" domain, return an error
+	if domain == FAKE_SITE {
+		return "", &powerdns.Error{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+			Message:    "Internal Server Error",
+		}
+	}
+
+	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
+		return "", powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	// Render the records belonging to the zone, one line per record
+	lines := []string{}
+	records.Range(func(key, _ any) bool {
+		rrset, _ := readFromRecordsMap(key.(string))
+		if isInZone(makeCanonical(ptr.Deref(rrset.Name, "")), makeCanonical(domain)) {
+			for _, r := range rrset.Records {
+				lines = append(lines, fmt.Sprintf("%s\t%d\tIN\t%s\t%s", *rrset.Name, ptr.Deref(rrset.TTL, 0), ptr.Deref(rrset.Type, ""), ptr.Deref(r.Content, "")))
+			}
+		}
+		return true
+	})
+	slices.Sort(lines)
+	return powerdns.Export(strings.Join(lines, "\n") + "\n"), nil
+}
+
 func (m mockRecordsClient) Get(ctx context.Context, domain string, name string, recordType *powerdns.RRType) ([]powerdns.RRset, error) {
 	results := []powerdns.RRset{}
 	if record, ok := readFromRecordsMap(makeCanonical(name)); ok {
@@ -484,7 +633,7 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 		for _, c := range rrset.Comments {
 			comment = *c.Content
 		}
-		isRRsetIdentical = reflect.DeepEqual(localRecords, content) && reflect.DeepEqual(*rrset.TTL, ttl) && reflect.DeepEqual(comment, specifiedComment)
+		isRRsetIdentical = reflect.DeepEqual(localRecords, content) && reflect.DeepEqual(*rrset.TTL, ttl) && (specifiedComment == "" || reflect.DeepEqual(comment, specifiedComment))
 	}
 
 	rrset.Name = &name
@@ -492,9 +641,9 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 	rrset.TTL = &ttl
 	rrset.ChangeType = powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace)
 	rrset.Records = make([]powerdns.Record, 0)
-	rrset.Comments = []powerdns.Comment{}
+	// Like PowerDNS, existing comments are kept when none are specified
 	if specifiedComment != "" {
-		rrset.Comments = append(rrset.Comments, powerdns.Comment{Content: &specifiedComment})
+		rrset.Comments = fakeRrset.Comments
 	}
 
 	for _, c := range content {
@@ -514,11 +663,127 @@ func (m mockRecordsClient) Change(ctx context.Context, domain string, name strin
 	return nil
 }
 
+func (m mockRecordsClient) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	for _, rrset := range rrSets.Sets {
+		switch ptr.Deref(rrset.ChangeType, "") {
+		case powerdns.ChangeTypeDelete:
+			if err := m.Delete(ctx, domain, *rrset.Name, *rrset.Type); err != nil {
+				return err
+			}
+		case powerdns.ChangeTypeReplace:
+			if err := m.Change(ctx, domain, *rrset.Name, *rrset.Type, ptr.Deref(rrset.TTL, 0), recordsContent(rrset), powerdns.WithComments(rrset.Comments...)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (m mockRecordsClient) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
 	deleteFromRecordsMap(makeCanonical(name))
 	return nil
 }
 
+func (m mockCryptokeysClient) List(ctx context.Context, domain string) ([]powerdns.Cryptokey, error) {
+	// Specific behaviour to
+	// for "fake" domain, return an error
+	if domain == FAKE_SITE {
+		return nil, &powerdns.Error{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+			Message:    "Internal Server Error",
+		}
+	}
+
+	zone, ok := readFromZonesMap(makeCanonical(domain))
+	if !ok {
+		return nil, powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	if !ptr.Deref(zone.DNSsec, false) {
+		return []powerdns.Cryptokey{}, nil
+	}
+	// Signed zones are served with a KSK and a ZSK
+	return []powerdns.Cryptokey{
+		{ID: ptr.To(uint64(1)), KeyType: ptr.To("ksk"), Active: ptr.To(true), DS: []string{
+			"12345 13 2 A5A3F49D0E7C7F8B9A1C2D3E4F5061728394A5B6C7D8E9F0A1B2C3D4E5F60718",
+			"12345 13 4 0F1E2D3C4B5A69788796A5B4C3D2E1F00F1E2D3C4B5A69788796A5B4C3D2E1F00F1E2D3C4B5A69788796A5B4C3D2E1F0",
+		}},
+		{ID: ptr.To(uint64(2)), KeyType: ptr.To("zsk"), Active: ptr.To(true)},
+	}, nil
+}
+
+func (m mockMetadataClient) Get(ctx context.Context, domain string, kind powerdns.MetadataKind) (*powerdns.Metadata, error) {
+	if _, ok := readFromZonesMap(makeCanonical(domain)); !ok {
+		return nil, powerdns.Error{StatusCode: ZONE_NOT_FOUND_CODE, Status: fmt.Sprintf("%d %s", ZONE_NOT_FOUND_CODE, ZONE_NOT_FOUND_MSG), Message: ZONE_NOT_FOUND_MSG}
+	}
+	// PowerDNS returns an empty list of values for an unset kind
+	values, _ := metadata.Load(makeCanonical(domain) + "/" + string(kind))
+	result := &powerdns.Metadata{Kind: powerdns.MetadataKindPtr(kind), Metadata: []string{}}
+	if values != nil {
+		result.Metadata = values.([]string)
+	}
+	return result, nil
+}
+
+func (m mockMetadataClient) Set(ctx context.Context, domain string, kind powerdns.MetadataKind, values []string) (*powerdns.Metadata, error) {
+	// Specific behaviour to
+	// for "fake" domain, return an error
+	if domain == FAKE_SITE {
+		return nil, &powerdns.Error{
+			StatusCode: 500,
+			Status:     "500 Internal Server Error",
+			Message:    "Internal Server Error",
+		}
+	}
+	metadata.Store(makeCanonical(domain)+"/"+string(kind), values)
+	return &powerdns.Metadata{Kind: powerdns.MetadataKindPtr(kind), Metadata: values}, nil
+}
+
+func (m mockMetadataClient) Delete(ctx context.Context, domain string, kind powerdns.MetadataKind) error {
+	metadata.Delete(makeCanonical(domain) + "/" + string(kind))
+	return nil
+}
+
+type mockObjectStorageClient struct{}
+
+func NewMockObjectStorageClient(target dnsv1alpha2.S3BackupTarget, accessKeyID, secretAccessKey string) (objectStorageClienter, error) {
+	return mockObjectStorageClient{}, nil
+}
+
+func (m mockObjectStorageClient) Put(ctx context.Context, bucket, key string, content []byte) error {
+	// Specific behaviour to
+	// for "fake" bucket, return an error
+	if bucket == FAKE_SITE {
+		return fmt.Errorf("500 Internal Server Error")
+	}
+	objects.Store(bucket+"/"+key, content)
+	return nil
+}
+
+func (m mockObjectStorageClient) Get(ctx context.Context, bucket, key string) ([]byte, error) {
+	content, ok := objects.Load(bucket + "/" + key)
+	if !ok {
+		return nil, fmt.Errorf("404 Not Found")
+	}
+	return content.([]byte), nil
+}
+
+func (m mockObjectStorageClient) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	keys := []string{}
+	objects.Range(func(key, _ any) bool {
+		if k, found := strings.CutPrefix(key.(string), bucket+"/"); found && strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	return keys, nil
+}
+
+func (m mockObjectStorageClient) Remove(ctx context.Context, bucket, key string) error {
+	objects.Delete(bucket + "/" + key)
+	return nil
+}
+
 func getMockedNameservers(zoneName string) (result []string) {
 	rrset, _ := readFromRecordsMap(makeCanonical(zoneName))
 	for _, r := range rrset.Records {
@@ -0,0 +1,94 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	RrsetReasonTargetUnavailable = "TargetUnavailable"
+)
+
+// getTargetRefNamespace return the namespace of the object the records of the RRset are resolved from
+func getTargetRefNamespace(gr dnsv1alpha2.GenericRRset) string {
+	if gr.GetNamespace() != "" {
+		return gr.GetNamespace()
+	}
+	return ptr.Deref(gr.GetSpec().TargetRef.Namespace, "")
+}
+
+// getTargetRefIndexKeys return the key the RRsets are indexed with, the Service their records are resolved from
+func getTargetRefIndexKeys(gr dnsv1alpha2.GenericRRset) []string {
+	if gr.GetSpec().TargetRef == nil {
+		return nil
+	}
+	return []string{getTargetRefNamespace(gr) + "/" + gr.GetSpec().TargetRef.Name}
+}
+
+// getServiceExternalAddresses return the external IP addresses of a Service: its LoadBalancer ingress IPs,
+// or its external IPs
+func getServiceExternalAddresses(svc *corev1.Service) []string {
+	addresses := []string{}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			addresses = append(addresses, ingress.IP)
+		}
+	}
+	if len(addresses) != 0 {
+		return addresses
+	}
+	return append(addresses, svc.Spec.ExternalIPs...)
+}
+
+// getTargetRecords return the sorted records of the type, A or AAAA, of the IP addresses of its family
+func getTargetRecords(rrType string, addresses []string) []string {
+	records := []string{}
+	for _, address := range addresses {
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			continue
+		}
+		if addr.Unmap().Is4() == (rrType == "A") {
+			records = append(records, addr.Unmap().String())
+		}
+	}
+	slices.Sort(records)
+	return slices.Compact(records)
+}
+
+// resolveTargetRef return the records of the RRset resolved from the current addresses of its target
+func resolveTargetRef(ctx context.Context, gr dnsv1alpha2.GenericRRset, cl client.Reader) ([]string, error) {
+	ref := gr.GetSpec().TargetRef
+	namespace := getTargetRefNamespace(gr)
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace of the Service %s is required", ref.Name)
+	}
+	svc := &corev1.Service{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, svc); err != nil {
+		return nil, err
+	}
+	records := getTargetRecords(gr.GetSpec().Type, getServiceExternalAddresses(svc))
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no external IP address of the %s type for the Service %s/%s", gr.GetSpec().Type, namespace, ref.Name)
+	}
+	return records, nil
+}
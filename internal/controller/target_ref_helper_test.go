@@ -0,0 +1,90 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetServiceExternalAddresses(t *testing.T) {
+	var testCases = []struct {
+		description string
+		svc         *corev1.Service
+		want        []string
+	}{
+		{"ClusterIP", &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIPs: []string{"10.0.0.80"}}}, []string{}},
+		{"External IPs", &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIPs: []string{"10.0.0.80"}, ExternalIPs: []string{"192.0.2.80"}}}, []string{"192.0.2.80"}},
+		{"LoadBalancer", &corev1.Service{
+			Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ExternalIPs: []string{"192.0.2.80"}},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "198.51.100.80"}, {Hostname: "lb.example.org"}}}},
+		}, []string{"198.51.100.80"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, getServiceExternalAddresses(tc.svc)); diff != "" {
+				t.Errorf("unexpected addresses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTargetRecords(t *testing.T) {
+	addresses := []string{"198.51.100.80", "2001:db8::80", "::ffff:192.0.2.80", "198.51.100.80", "lb.example.org"}
+	var testCases = []struct {
+		description string
+		rrType      string
+		want        []string
+	}{
+		{"IPv4 addresses", "A", []string{"192.0.2.80", "198.51.100.80"}},
+		{"IPv6 addresses", "AAAA", []string{"2001:db8::80"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, getTargetRecords(tc.rrType, addresses)); diff != "" {
+				t.Errorf("unexpected records (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTargetRefIndexKeys(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrset       dnsv1alpha2.GenericRRset
+		want        []string
+	}{
+		{"Without target", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Namespace: "web"}}, nil},
+		{"RRset", &dnsv1alpha2.RRset{ObjectMeta: metav1.ObjectMeta{Namespace: "web"}, Spec: dnsv1alpha2.RRsetSpec{
+			TargetRef: &dnsv1alpha2.TargetReference{Kind: "Service", Name: "ingress", Namespace: ptr.To("other")},
+		}}, []string{"web/ingress"}},
+		{"ClusterRRset", &dnsv1alpha2.ClusterRRset{Spec: dnsv1alpha2.RRsetSpec{
+			TargetRef: &dnsv1alpha2.TargetReference{Kind: "Service", Name: "ingress", Namespace: ptr.To("ingress-system")},
+		}}, []string{"ingress-system/ingress"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, getTargetRefIndexKeys(tc.rrset)); diff != "" {
+				t.Errorf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	DEFAULT_TLSA_USAGE         = int32(3)
+	DEFAULT_TLSA_SELECTOR      = int32(1)
+	DEFAULT_TLSA_MATCHING_TYPE = int32(1)
+	DEFAULT_TLSA_PROTOCOL      = "tcp"
+)
+
+// parseCertificates return the certificates of a PEM bundle, in their order
+func parseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	certificates := []*x509.Certificate{}
+	for block, rest := pem.Decode(bundle); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	return certificates, nil
+}
+
+// getTLSACertificate return the certificate a TLSA record with the usage is associated with:
+// the end entity certificate, first of the chain, for the PKIX-EE (1) and DANE-EE (3) usages,
+// the CA certificate, or the last certificate of the chain, for the PKIX-TA (0) and DANE-TA (2) usages
+func getTLSACertificate(chain, ca []byte, usage int32) (*x509.Certificate, error) {
+	certificates, err := parseCertificates(chain)
+	if err != nil {
+		return nil, err
+	}
+	if usage == 1 || usage == 3 {
+		return certificates[0], nil
+	}
+	if len(ca) != 0 {
+		caCertificates, err := parseCertificates(ca)
+		if err != nil {
+			return nil, err
+		}
+		return caCertificates[0], nil
+	}
+	return certificates[len(certificates)-1], nil
+}
+
+// tlsaRecord return the content of the TLSA record (RFC 6698) of a certificate
+func tlsaRecord(certificate *x509.Certificate, usage, selector, matchingType int32) (string, error) {
+	var data []byte
+	switch selector {
+	case 0:
+		data = certificate.Raw
+	case 1:
+		data = certificate.RawSubjectPublicKeyInfo
+	default:
+		return "", fmt.Errorf("unsupported selector %d", selector)
+	}
+	switch matchingType {
+	case 0:
+	case 1:
+		sum := sha256.Sum256(data)
+		data = sum[:]
+	case 2:
+		sum := sha512.Sum512(data)
+		data = sum[:]
+	default:
+		return "", fmt.Errorf("unsupported matching type %d", matchingType)
+	}
+	return fmt.Sprintf("%d %d %d %x", usage, selector, matchingType, data), nil
+}
+
+// getTLSAName return the owner name of the TLSA records of a service (e.g. "_443._tcp.www")
+func getTLSAName(name string, port int32, protocol string) string {
+	return fmt.Sprintf("_%d._%s.%s", port, protocol, name)
+}
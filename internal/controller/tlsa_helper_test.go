@@ -0,0 +1,114 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// generateTestCertificate return a PEM encoded certificate and its key, signed by the parent or self-signed
+func generateTestCertificate(commonName string, parent *x509.Certificate, parentKey crypto.Signer) ([]byte, *x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour).Truncate(time.Second),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), certificate, key, nil
+}
+
+func TestTlsaRecord(t *testing.T) {
+	caPEM, ca, caKey, err := generateTestCertificate("ca", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafPEM, leaf, _, err := generateTestCertificate("www.example.org", ca, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := append(append([]byte{}, leafPEM...), caPEM...)
+	leafSPKISHA256 := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+	leafSHA512 := sha512.Sum512(leaf.Raw)
+	caSPKISHA256 := sha256.Sum256(ca.RawSubjectPublicKeyInfo)
+
+	var testCases = []struct {
+		description  string
+		chain        []byte
+		ca           []byte
+		usage        int32
+		selector     int32
+		matchingType int32
+		want         string
+		wantErr      bool
+	}{
+		{"DANE-EE SPKI SHA-256", chain, nil, 3, 1, 1, fmt.Sprintf("3 1 1 %x", leafSPKISHA256), false},
+		{"PKIX-EE full certificate SHA-512", chain, nil, 1, 0, 2, fmt.Sprintf("1 0 2 %x", leafSHA512), false},
+		{"DANE-EE full certificate exact match", leafPEM, nil, 3, 0, 0, fmt.Sprintf("3 0 0 %x", leaf.Raw), false},
+		{"DANE-TA from the chain", chain, nil, 2, 1, 1, fmt.Sprintf("2 1 1 %x", caSPKISHA256), false},
+		{"DANE-TA from ca.crt", leafPEM, caPEM, 2, 1, 1, fmt.Sprintf("2 1 1 %x", caSPKISHA256), false},
+		{"No certificate", []byte("not a certificate"), nil, 3, 1, 1, "", true},
+		{"Invalid ca.crt", leafPEM, []byte("not a certificate"), 0, 1, 1, "", true},
+		{"Unsupported selector", chain, nil, 3, 2, 1, "", true},
+		{"Unsupported matching type", chain, nil, 3, 1, 3, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var record string
+			certificate, err := getTLSACertificate(tc.chain, tc.ca, tc.usage)
+			if err == nil {
+				record, err = tlsaRecord(certificate, tc.usage, tc.selector, tc.matchingType)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if record != tc.want {
+				t.Errorf("got %s, want %s", record, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetTLSAName(t *testing.T) {
+	if got := getTLSAName("www", 443, "tcp"); got != "_443._tcp.www" {
+		t.Errorf("got %s, want _443._tcp.www", got)
+	}
+}
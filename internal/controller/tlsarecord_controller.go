@@ -0,0 +1,204 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	TLSAReasonSourceNotAvailable = "SourceNotAvailable"
+	TLSAReasonInvalidCertificate = "InvalidCertificate"
+	TLSAReasonGenerationFailed   = "GenerationFailed"
+	TLSAReasonGenerated          = "RRsetsGenerated"
+	TLSAMessageGenerated         = "TLSA RRsets generated from the certificate"
+)
+
+// TLSARecordReconciler reconciles a TLSARecord object
+type TLSARecordReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=tlsarecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=tlsarecords/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=tlsarecords/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *TLSARecordReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile TLSARecord", "TLSARecord.Name", req.Name)
+
+	// TLSARecord
+	tlsa := &dnsv1alpha2.TLSARecord{}
+	err := r.Get(ctx, req.NamespacedName, tlsa)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !tlsa.DeletionTimestamp.IsZero() {
+		// The generated RRsets are controlled by their zone, they are deleted with the TLSARecord
+		if err := deleteGeneratedRRsets(ctx, tlsa, r.Client); err != nil {
+			log.Error(err, "Failed to delete generated RRsets")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := addGeneratorFinalizer(ctx, tlsa, r.Client); err != nil {
+		log.Error(err, "Failed to add finalizer")
+		return ctrl.Result{}, err
+	}
+
+	// The Secret is watched, a new reconciliation is triggered on its creation or on the certificate renewal
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: tlsa.Namespace, Name: tlsa.Spec.SecretRef.Name}, secret); err != nil {
+		if err := patchTLSARecordStatus(ctx, tlsa, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  TLSAReasonSourceNotAvailable,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch TLSARecord status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	usage := ptr.Deref(tlsa.Spec.Usage, DEFAULT_TLSA_USAGE)
+	record, notAfter, err := getSecretTLSARecord(secret, usage, ptr.Deref(tlsa.Spec.Selector, DEFAULT_TLSA_SELECTOR), ptr.Deref(tlsa.Spec.MatchingType, DEFAULT_TLSA_MATCHING_TYPE))
+	if err != nil {
+		if err := patchTLSARecordStatus(ctx, tlsa, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  TLSAReasonInvalidCertificate,
+			Message: fmt.Sprintf("secret %s: %v", secret.Name, err),
+		}); err != nil {
+			log.Error(err, "unable to patch TLSARecord status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	name := getTLSAName(tlsa.Spec.Name, tlsa.Spec.Port, ptr.Deref(tlsa.Spec.Protocol, DEFAULT_TLSA_PROTOCOL))
+	desired := []dnsv1alpha2.RRset{{
+		ObjectMeta: metav1.ObjectMeta{Name: getGeneratedRRsetName(tlsa.Name, name)},
+		Spec: dnsv1alpha2.RRsetSpec{
+			Type:    "TLSA",
+			Name:    name,
+			TTL:     tlsa.Spec.TTL,
+			Records: []string{record},
+			ZoneRef: tlsa.Spec.ZoneRef,
+		},
+	}}
+
+	names, err := reconcileGeneratedRRsets(ctx, tlsa, desired, r.Scheme, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to generate RRsets")
+		if err := patchTLSARecordStatus(ctx, tlsa, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  TLSAReasonGenerationFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch TLSARecord status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := patchTLSARecordStatus(ctx, tlsa, names, ptr.To(metav1.NewTime(notAfter)), SUCCEEDED_STATUS, r.Client, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  TLSAReasonGenerated,
+		Message: TLSAMessageGenerated,
+	}); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch TLSARecord status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getSecretTLSARecord return the TLSA record of the certificate of a Secret, and the expiration time of the certificate
+func getSecretTLSARecord(secret *corev1.Secret, usage, selector, matchingType int32) (string, time.Time, error) {
+	chain, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return "", time.Time{}, fmt.Errorf("no %s key", corev1.TLSCertKey)
+	}
+	certificate, err := getTLSACertificate(chain, secret.Data["ca.crt"], usage)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	record, err := tlsaRecord(certificate, usage, selector, matchingType)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	// Expiration time of the served certificate, whatever the usage
+	leaf, err := getTLSACertificate(chain, nil, DEFAULT_TLSA_USAGE)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return record, leaf.NotAfter, nil
+}
+
+func patchTLSARecordStatus(ctx context.Context, tlsa *dnsv1alpha2.TLSARecord, rrsets []string, notAfter *metav1.Time, status string, cl client.Client, condition metav1.Condition) error {
+	original := tlsa.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&tlsa.Status.Conditions, condition)
+	if rrsets != nil {
+		tlsa.Status.RRsets = rrsets
+	}
+	if notAfter != nil {
+		tlsa.Status.NotAfter = notAfter
+	}
+	tlsa.Status.SyncStatus = ptr.To(status)
+	tlsa.Status.ObservedGeneration = ptr.To(tlsa.GetGeneration())
+	return cl.Status().Patch(ctx, tlsa, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TLSARecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// We use indexer to find the TLSARecords to reconcile on a renewal of their certificate
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.TLSARecord{}, "TLSARecord.Secret", func(rawObj client.Object) []string {
+		return []string{"Secret/" + rawObj.(*dnsv1alpha2.TLSARecord).Spec.SecretRef.Name}
+	}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.TLSARecord{}).
+		Watches(&dnsv1alpha2.TLSARecord{}, enqueueDeletionsFirst()).
+		Owns(&dnsv1alpha2.RRset{}, builder.MatchEveryOwner).
+		Watches(&corev1.Secret{}, enqueueForSourceObject(r.Client, &dnsv1alpha2.TLSARecordList{}, "TLSARecord.Secret", "Secret")).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
@@ -0,0 +1,189 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("TLSARecord Controller", func() {
+
+	const (
+		zoneName          = "example11.org"
+		resourceName      = "www-https"
+		resourceNamespace = "example11"
+		secretName        = "www-tls"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example11.org", "ns2.example11.org"}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	rrsetNamespacedName := types.NamespacedName{
+		Name:      resourceName + "-443.tcp.www",
+		Namespace: resourceNamespace,
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance TLSARecord")
+		tlsa := &dnsv1alpha2.TLSARecord{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, tlsa); err == nil {
+			Expect(k8sClient.Delete(ctx, tlsa)).To(Succeed())
+		}
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: resourceNamespace}, secret); err == nil {
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+		}
+
+		By("Checking the generated RRset is deleted with its generator")
+		rrset := &dnsv1alpha2.RRset{}
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	Context("When creating a TLSARecord", func() {
+		It("should generate the TLSA RRset and update it on certificate renewal", Label("tlsarecord-creation", "certificate-renewal"), func() {
+			ctx := context.Background()
+			By("Creating the certificate Secret")
+			certPEM, cert, _, err := generateTestCertificate("www."+zoneName, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: resourceNamespace,
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: certPEM},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			By("Creating the TLSARecord")
+			tlsa := &dnsv1alpha2.TLSARecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.TLSARecordSpec{
+					ZoneRef:   dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Name:      "www",
+					Port:      443,
+					SecretRef: corev1.LocalObjectReference{Name: secretName},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tlsa)).To(Succeed())
+
+			By("Getting the generated RRset")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, tlsa)
+				return err == nil && tlsa.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(tlsa.Status.RRsets).To(Equal([]string{rrsetNamespacedName.Name}))
+			Expect(tlsa.Status.NotAfter.Time.Equal(cert.NotAfter)).To(BeTrue())
+			rrset := &dnsv1alpha2.RRset{}
+			Expect(k8sClient.Get(ctx, rrsetNamespacedName, rrset)).To(Succeed())
+			Expect(rrset.Spec.Type).To(Equal("TLSA"))
+			Expect(rrset.Spec.Name).To(Equal("_443._tcp.www"))
+			Expect(rrset.Spec.Records).To(Equal([]string{fmt.Sprintf("3 1 1 %x", sha256.Sum256(cert.RawSubjectPublicKeyInfo))}))
+
+			By("Renewing the certificate with a new key")
+			renewedPEM, renewed, _, err := generateTestCertificate("www."+zoneName, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: resourceNamespace}, secret)).To(Succeed())
+			secret.Data[corev1.TLSCertKey] = renewedPEM
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+			renewedRecord := fmt.Sprintf("3 1 1 %x", sha256.Sum256(renewed.RawSubjectPublicKeyInfo))
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, rrsetNamespacedName, rrset)
+				return err == nil && len(rrset.Spec.Records) == 1 && rrset.Spec.Records[0] == renewedRecord
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When creating a TLSARecord with a missing Secret", func() {
+		It("should reconcile the resource with Failed status", Label("tlsarecord-creation", "missing-secret"), func() {
+			ctx := context.Background()
+			By("Creating the TLSARecord")
+			tlsa := &dnsv1alpha2.TLSARecord{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.TLSARecordSpec{
+					ZoneRef:   dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Name:      "www",
+					Port:      443,
+					SecretRef: corev1.LocalObjectReference{Name: "nonexistent"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tlsa)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, tlsa)
+				return err == nil && tlsa.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
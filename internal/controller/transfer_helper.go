@@ -0,0 +1,205 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"golang.org/x/net/dns/dnsmessage"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// TRANSFER_STATUS_CHECK_INTERVAL is the interval between the checks of the transfers of a secondary zone
+	TRANSFER_STATUS_CHECK_INTERVAL = 5 * time.Minute
+	// TRANSFER_QUERY_TIMEOUT is the timeout of the SOA queries to the primaries, sent in parallel
+	TRANSFER_QUERY_TIMEOUT = 5 * time.Second
+
+	TRANSFER_RESULT_SUCCEEDED = "Succeeded"
+	TRANSFER_RESULT_PENDING   = "Pending"
+	TRANSFER_RESULT_FAILED    = "Failed"
+	TRANSFER_RESULT_UNKNOWN   = "Unknown"
+
+	ZoneReasonTransferSucceeded  = "TransferSucceeded"
+	ZoneMessageTransferSucceeded = "Zone transferred with the serial of the primaries"
+	ZoneReasonTransferPending    = "TransferPending"
+	ZoneMessageTransferPending   = "Zone not transferred from the primaries yet"
+	ZoneReasonTransferFailed     = "TransferFailed"
+	ZoneMessageTransferFailed    = "Zone behind the primaries, newer serial"
+	ZoneReasonTransferUnknown    = "TransferUnknown"
+	ZoneMessageTransferUnknown   = "No primary answered the SOA query"
+)
+
+// soaSerialQuerier return the serial of the SOA record of the zone served by the server
+type soaSerialQuerier func(ctx context.Context, server string, zone string) (uint32, error)
+
+// getTransferStatus return the state of the transfers of the secondary zone, comparing its serial in PowerDNS
+// to the serials served by its primaries. The time of the transfer is the first observation of the serial.
+func getTransferStatus(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneRes *powerdns.Zone, query soaSerialQuerier, now time.Time, log logr.Logger) *dnsv1alpha2.ZoneTransferStatus {
+	// The primaries are queried in parallel, an unreachable primary delays the reconciliation by the timeout at most
+	ctx, cancel := context.WithTimeout(ctx, TRANSFER_QUERY_TIMEOUT)
+	defer cancel()
+	primarySerials := make([]uint32, len(zoneRes.Masters))
+	errs := make([]error, len(zoneRes.Masters))
+	var wg sync.WaitGroup
+	for i, primary := range zoneRes.Masters {
+		wg.Go(func() {
+			primarySerials[i], errs[i] = query(ctx, primary, zone.GetObjectMeta().Name)
+		})
+	}
+	wg.Wait()
+
+	serial := ptr.Deref(zoneRes.Serial, 0)
+	transfer := &dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_UNKNOWN}
+	for i, primary := range zoneRes.Masters {
+		primarySerial, err := primarySerials[i], errs[i]
+		if err != nil {
+			log.Error(err, "Failed to query the SOA serial of the primary", "primary", primary)
+			continue
+		}
+		if transfer.PrimarySerial == nil || isNewerSerial(primarySerial, *transfer.PrimarySerial) {
+			transfer.PrimarySerial = ptr.To(primarySerial)
+		}
+		if transfer.Primary == nil && serial != 0 && primarySerial == serial {
+			transfer.Primary = ptr.To(primary)
+		}
+	}
+	switch {
+	case serial == 0:
+		transfer.Result = TRANSFER_RESULT_PENDING
+	case transfer.PrimarySerial == nil:
+		transfer.Result = TRANSFER_RESULT_UNKNOWN
+	case isNewerSerial(*transfer.PrimarySerial, serial):
+		transfer.Result = TRANSFER_RESULT_FAILED
+	default:
+		transfer.Result = TRANSFER_RESULT_SUCCEEDED
+	}
+
+	previous := zone.GetStatus().Transfer
+	if serial != 0 {
+		transfer.LastTransferTime = &metav1.Time{Time: now}
+		if previous != nil && previous.LastTransferTime != nil && ptr.Deref(zone.GetStatus().Serial, 0) == serial {
+			transfer.LastTransferTime = previous.LastTransferTime
+		}
+	}
+	return transfer
+}
+
+// isNewerSerial return True if the serial is newer than the other one, in the serial number arithmetic (RFC 1982)
+func isNewerSerial(serial uint32, other uint32) bool {
+	return serial != other && int32(serial-other) > 0
+}
+
+// getTransferCondition return the Transferred condition reporting the state of the transfers of the secondary zone
+func getTransferCondition(transfer *dnsv1alpha2.ZoneTransferStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               "Transferred",
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Status:             metav1.ConditionFalse,
+	}
+	switch transfer.Result {
+	case TRANSFER_RESULT_SUCCEEDED:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason, condition.Message = ZoneReasonTransferSucceeded, ZoneMessageTransferSucceeded
+		if transfer.Primary != nil {
+			condition.Message += " (" + *transfer.Primary + ")"
+		}
+	case TRANSFER_RESULT_PENDING:
+		condition.Reason, condition.Message = ZoneReasonTransferPending, ZoneMessageTransferPending
+	case TRANSFER_RESULT_FAILED:
+		condition.Reason, condition.Message = ZoneReasonTransferFailed, fmt.Sprintf("%s %d", ZoneMessageTransferFailed, ptr.Deref(transfer.PrimarySerial, 0))
+	default:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason, condition.Message = ZoneReasonTransferUnknown, ZoneMessageTransferUnknown
+	}
+	return condition
+}
+
+// getPrimaryAddress return the address of a primary, "ip" or "ip:port", with the DNS port by default
+func getPrimaryAddress(primary string) (string, error) {
+	if addrPort, err := netip.ParseAddrPort(primary); err == nil {
+		return addrPort.String(), nil
+	}
+	addr, err := netip.ParseAddr(primary)
+	if err != nil {
+		return "", err
+	}
+	return netip.AddrPortFrom(addr, 53).String(), nil
+}
+
+// querySOASerial return the serial of the SOA record of the zone served by the server, queried over UDP
+func querySOASerial(ctx context.Context, server string, zone string) (uint32, error) {
+	address, err := getPrimaryAddress(server)
+	if err != nil {
+		return 0, err
+	}
+	name, err := dnsmessage.NewName(makeCanonical(zone))
+	if err != nil {
+		return 0, err
+	}
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: uint16(rand.Uint32())},
+		Questions: []dnsmessage.Question{{Name: name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, TRANSFER_QUERY_TIMEOUT)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if _, err := conn.Write(packed); err != nil {
+		return 0, err
+	}
+	buffer := make([]byte, 4096)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return 0, err
+	}
+
+	var response dnsmessage.Message
+	if err := response.Unpack(buffer[:n]); err != nil {
+		return 0, err
+	}
+	if response.ID != query.ID {
+		return 0, fmt.Errorf("unexpected response ID %d", response.ID)
+	}
+	if response.RCode != dnsmessage.RCodeSuccess {
+		return 0, fmt.Errorf("SOA query answered with %s", response.RCode)
+	}
+	for _, answer := range response.Answers {
+		if soa, ok := answer.Body.(*dnsmessage.SOAResource); ok {
+			return soa.Serial, nil
+		}
+	}
+	return 0, fmt.Errorf("no SOA record served for %s", zone)
+}
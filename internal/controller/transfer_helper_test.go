@@ -0,0 +1,184 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	"golang.org/x/net/dns/dnsmessage"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetTransferStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+	observed := metav1.NewTime(now.Add(-time.Hour))
+	serials := map[string]uint32{"192.0.2.1": 2025060101, "192.0.2.2": 2025060102}
+	query := func(ctx context.Context, server string, zone string) (uint32, error) {
+		if serial, ok := serials[server]; ok {
+			return serial, nil
+		}
+		return 0, errors.New("timeout")
+	}
+
+	var testCases = []struct {
+		description string
+		primaries   []string
+		serial      uint32
+		status      dnsv1alpha2.ZoneStatus
+		want        *dnsv1alpha2.ZoneTransferStatus
+	}{
+		{"Never transferred", []string{"192.0.2.1"}, 0, dnsv1alpha2.ZoneStatus{},
+			&dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_PENDING, PrimarySerial: ptr.To(uint32(2025060101))}},
+		{"Up to date", []string{"192.0.2.1"}, 2025060101, dnsv1alpha2.ZoneStatus{},
+			&dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_SUCCEEDED, LastTransferTime: &metav1.Time{Time: now}, Primary: ptr.To("192.0.2.1"), PrimarySerial: ptr.To(uint32(2025060101))}},
+		{"Serial already observed", []string{"192.0.2.1"}, 2025060101, dnsv1alpha2.ZoneStatus{Serial: ptr.To(uint32(2025060101)), Transfer: &dnsv1alpha2.ZoneTransferStatus{LastTransferTime: &observed}},
+			&dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_SUCCEEDED, LastTransferTime: &observed, Primary: ptr.To("192.0.2.1"), PrimarySerial: ptr.To(uint32(2025060101))}},
+		{"Behind a primary", []string{"192.0.2.1", "192.0.2.2"}, 2025060101, dnsv1alpha2.ZoneStatus{Serial: ptr.To(uint32(2025060101)), Transfer: &dnsv1alpha2.ZoneTransferStatus{LastTransferTime: &observed}},
+			&dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_FAILED, LastTransferTime: &observed, Primary: ptr.To("192.0.2.1"), PrimarySerial: ptr.To(uint32(2025060102))}},
+		{"Unreachable primaries", []string{"192.0.2.3"}, 2025060101, dnsv1alpha2.ZoneStatus{},
+			&dnsv1alpha2.ZoneTransferStatus{Result: TRANSFER_RESULT_UNKNOWN, LastTransferTime: &metav1.Time{Time: now}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Status: tc.status}
+			zoneRes := &powerdns.Zone{Serial: ptr.To(tc.serial), Masters: tc.primaries}
+			got := getTransferStatus(context.Background(), zone, zoneRes, query, now, logr.Discard())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected transfer status (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetTransferStatusParallelQueries(t *testing.T) {
+	// The first primary does not answer before the second one: queried one after the other, the deadline expires
+	answered := make(chan struct{})
+	query := func(ctx context.Context, server string, zone string) (uint32, error) {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if server == "192.0.2.1" {
+			defer close(answered)
+			return 2025060101, nil
+		}
+		select {
+		case <-answered:
+			return 0, errors.New("timeout")
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	zone := &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}}
+	zoneRes := &powerdns.Zone{Serial: ptr.To(uint32(2025060101)), Masters: []string{"192.0.2.3", "192.0.2.1"}}
+	got := getTransferStatus(context.Background(), zone, zoneRes, query, time.Now(), logr.Discard())
+	if got.Result != TRANSFER_RESULT_SUCCEEDED || ptr.Deref(got.Primary, "") != "192.0.2.1" {
+		t.Errorf("got %v from %v, want %v from 192.0.2.1", got.Result, ptr.Deref(got.Primary, ""), TRANSFER_RESULT_SUCCEEDED)
+	}
+}
+
+func TestIsNewerSerial(t *testing.T) {
+	var testCases = []struct {
+		description string
+		serial      uint32
+		other       uint32
+		want        bool
+	}{
+		{"Newer", 2, 1, true},
+		{"Same", 1, 1, false},
+		{"Older", 1, 2, false},
+		{"Wrapped around", 1, 4294967295, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isNewerSerial(tc.serial, tc.other); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetPrimaryAddress(t *testing.T) {
+	var testCases = []struct {
+		primary string
+		want    string
+	}{
+		{"192.0.2.1", "192.0.2.1:53"},
+		{"192.0.2.1:5300", "192.0.2.1:5300"},
+		{"2001:db8::1", "[2001:db8::1]:53"},
+		{"[2001:db8::1]:5300", "[2001:db8::1]:5300"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.primary, func(t *testing.T) {
+			got, err := getPrimaryAddress(tc.primary)
+			if err != nil || got != tc.want {
+				t.Errorf("got %v (%v), want %v", got, err, tc.want)
+			}
+		})
+	}
+	if _, err := getPrimaryAddress("primary.example.org"); err == nil {
+		t.Errorf("expected an error for a host name")
+	}
+}
+
+func TestQuerySOASerial(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	go func() {
+		buffer := make([]byte, 512)
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if err := query.Unpack(buffer[:n]); err != nil {
+			return
+		}
+		response := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+			Questions: query.Questions,
+			Answers: []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: query.Questions[0].Name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET},
+				Body: &dnsmessage.SOAResource{
+					NS:     dnsmessage.MustNewName("ns1.example.org."),
+					MBox:   dnsmessage.MustNewName("hostmaster.example.org."),
+					Serial: 2025060101,
+				},
+			}},
+		}
+		packed, err := response.Pack()
+		if err != nil {
+			return
+		}
+		_, _ = conn.WriteTo(packed, addr)
+	}()
+
+	serial, err := querySOASerial(context.Background(), conn.LocalAddr().String(), "example.org")
+	if err != nil || serial != 2025060101 {
+		t.Errorf("got %d (%v), want 2025060101", serial, err)
+	}
+}
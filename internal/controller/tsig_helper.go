@@ -0,0 +1,259 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+const (
+	TSIG_TYPE  = uint16(250)
+	CLASS_ANY  = uint16(255)
+	CLASS_NONE = uint16(254)
+	TSIG_FUDGE = uint16(300)
+
+	// TSIG errors (RFC 8945)
+	TSIG_NO_ERROR = uint16(0)
+	TSIG_BADSIG   = uint16(16)
+	TSIG_BADKEY   = uint16(17)
+	TSIG_BADTIME  = uint16(18)
+
+	DNS_HEADER_SIZE = 12
+)
+
+// tsigAlgorithms are the HMAC algorithms supported to authenticate the messages, by algorithm name
+var tsigAlgorithms = map[string]func() hash.Hash{
+	"hmac-sha1.":   sha1.New,
+	"hmac-sha224.": sha256.New224,
+	"hmac-sha256.": sha256.New,
+	"hmac-sha384.": sha512.New384,
+	"hmac-sha512.": sha512.New,
+}
+
+// tsigRecord is the TSIG record (RFC 8945) of a message
+type tsigRecord struct {
+	KeyName    string
+	Algorithm  string
+	TimeSigned uint64
+	Fudge      uint16
+	MAC        []byte
+	OriginalID uint16
+	Error      uint16
+	OtherData  []byte
+}
+
+// readWireName return the lower case name at the offset of the message, following the compression pointers,
+// and the offset following the name
+func readWireName(msg []byte, off int) (string, int, error) {
+	labels := []string{}
+	next := -1
+	for jumps := 0; ; jumps++ {
+		if off >= len(msg) || jumps > len(msg) {
+			return "", 0, fmt.Errorf("malformed name")
+		}
+		length := int(msg[off])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")) + ".", next, nil
+		case length&0xC0 == 0xC0:
+			if off+1 >= len(msg) {
+				return "", 0, fmt.Errorf("malformed name")
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3FFF)
+		default:
+			if off+1+length > len(msg) {
+				return "", 0, fmt.Errorf("malformed name")
+			}
+			labels = append(labels, string(msg[off+1:off+1+length]))
+			off += 1 + length
+		}
+	}
+}
+
+// appendWireName append the uncompressed, lower case, wire format of the name
+func appendWireName(b []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(strings.ToLower(name), "."), ".") {
+		if label == "" {
+			continue
+		}
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0)
+}
+
+// splitTSIG return the message without its TSIG record, and the TSIG record, or nil if the message is not signed.
+// The TSIG record must be the last record of the message.
+func splitTSIG(msg []byte) ([]byte, *tsigRecord, error) {
+	if len(msg) < DNS_HEADER_SIZE {
+		return nil, nil, fmt.Errorf("message too short")
+	}
+	counts := []int{}
+	for i := 4; i < DNS_HEADER_SIZE; i += 2 {
+		counts = append(counts, int(binary.BigEndian.Uint16(msg[i:])))
+	}
+	if counts[3] == 0 {
+		return msg, nil, nil
+	}
+	off := DNS_HEADER_SIZE
+	var err error
+	for i := 0; i < counts[0]; i++ {
+		if _, off, err = readWireName(msg, off); err != nil {
+			return nil, nil, err
+		}
+		off += 4
+	}
+	records := counts[1] + counts[2] + counts[3]
+	for i := 0; i < records-1; i++ {
+		if _, off, err = readWireName(msg, off); err != nil {
+			return nil, nil, err
+		}
+		if off+10 > len(msg) {
+			return nil, nil, fmt.Errorf("message too short")
+		}
+		off += 10 + int(binary.BigEndian.Uint16(msg[off+8:]))
+	}
+
+	start := off
+	keyName, off, err := readWireName(msg, off)
+	if err != nil {
+		return nil, nil, err
+	}
+	if off+10 > len(msg) {
+		return nil, nil, fmt.Errorf("message too short")
+	}
+	if binary.BigEndian.Uint16(msg[off:]) != TSIG_TYPE {
+		return msg, nil, nil
+	}
+	rdlength := int(binary.BigEndian.Uint16(msg[off+8:]))
+	off += 10
+	if off+rdlength != len(msg) {
+		return nil, nil, fmt.Errorf("malformed TSIG record")
+	}
+	tsig := &tsigRecord{KeyName: keyName}
+	if tsig.Algorithm, off, err = readWireName(msg, off); err != nil {
+		return nil, nil, err
+	}
+	if off+10 > len(msg) {
+		return nil, nil, fmt.Errorf("malformed TSIG record")
+	}
+	tsig.TimeSigned = uint64(binary.BigEndian.Uint16(msg[off:]))<<32 | uint64(binary.BigEndian.Uint32(msg[off+2:]))
+	tsig.Fudge = binary.BigEndian.Uint16(msg[off+6:])
+	macSize := int(binary.BigEndian.Uint16(msg[off+8:]))
+	off += 10
+	if off+macSize+6 > len(msg) {
+		return nil, nil, fmt.Errorf("malformed TSIG record")
+	}
+	tsig.MAC = msg[off : off+macSize]
+	off += macSize
+	tsig.OriginalID = binary.BigEndian.Uint16(msg[off:])
+	tsig.Error = binary.BigEndian.Uint16(msg[off+2:])
+	otherLen := int(binary.BigEndian.Uint16(msg[off+4:]))
+	off += 6
+	if off+otherLen != len(msg) {
+		return nil, nil, fmt.Errorf("malformed TSIG record")
+	}
+	tsig.OtherData = msg[off:]
+
+	unsigned := append([]byte{}, msg[:start]...)
+	binary.BigEndian.PutUint16(unsigned[10:], uint16(counts[3]-1))
+	binary.BigEndian.PutUint16(unsigned[0:], tsig.OriginalID)
+	return unsigned, tsig, nil
+}
+
+// tsigMAC return the MAC of the unsigned message and TSIG variables. The MAC of the request is prepended for the responses.
+func tsigMAC(unsigned []byte, tsig *tsigRecord, secret, requestMAC []byte) ([]byte, error) {
+	newHash, ok := tsigAlgorithms[tsig.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %s", tsig.Algorithm)
+	}
+	h := hmac.New(newHash, secret)
+	if requestMAC != nil {
+		_ = binary.Write(h, binary.BigEndian, uint16(len(requestMAC)))
+		h.Write(requestMAC)
+	}
+	h.Write(unsigned)
+	variables := appendWireName(nil, tsig.KeyName)
+	variables = binary.BigEndian.AppendUint16(variables, CLASS_ANY)
+	variables = binary.BigEndian.AppendUint32(variables, 0)
+	variables = appendWireName(variables, tsig.Algorithm)
+	variables = binary.BigEndian.AppendUint16(variables, uint16(tsig.TimeSigned>>32))
+	variables = binary.BigEndian.AppendUint32(variables, uint32(tsig.TimeSigned))
+	variables = binary.BigEndian.AppendUint16(variables, tsig.Fudge)
+	variables = binary.BigEndian.AppendUint16(variables, tsig.Error)
+	variables = binary.BigEndian.AppendUint16(variables, uint16(len(tsig.OtherData)))
+	variables = append(variables, tsig.OtherData...)
+	h.Write(variables)
+	return h.Sum(nil), nil
+}
+
+// verifyTSIG return the TSIG error of a signed request
+func verifyTSIG(unsigned []byte, tsig *tsigRecord, secret []byte, now time.Time) uint16 {
+	mac, err := tsigMAC(unsigned, tsig, secret, nil)
+	if err != nil {
+		return TSIG_BADKEY
+	}
+	if !hmac.Equal(mac, tsig.MAC) {
+		return TSIG_BADSIG
+	}
+	signed := int64(tsig.TimeSigned)
+	if diff := now.Unix() - signed; diff > int64(tsig.Fudge) || -diff > int64(tsig.Fudge) {
+		return TSIG_BADTIME
+	}
+	return TSIG_NO_ERROR
+}
+
+// appendTSIG append a TSIG record to the message and increment its additional records count.
+// The record is signed when the secret is given, its MAC is otherwise empty (e.g. for BADKEY errors).
+func appendTSIG(msg []byte, tsig *tsigRecord, secret, requestMAC []byte) ([]byte, error) {
+	tsig.MAC = nil
+	if secret != nil {
+		mac, err := tsigMAC(msg, tsig, secret, requestMAC)
+		if err != nil {
+			return nil, err
+		}
+		tsig.MAC = mac
+	}
+	rdata := appendWireName(nil, tsig.Algorithm)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(tsig.TimeSigned>>32))
+	rdata = binary.BigEndian.AppendUint32(rdata, uint32(tsig.TimeSigned))
+	rdata = binary.BigEndian.AppendUint16(rdata, tsig.Fudge)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(tsig.MAC)))
+	rdata = append(rdata, tsig.MAC...)
+	rdata = binary.BigEndian.AppendUint16(rdata, tsig.OriginalID)
+	rdata = binary.BigEndian.AppendUint16(rdata, tsig.Error)
+	rdata = binary.BigEndian.AppendUint16(rdata, uint16(len(tsig.OtherData)))
+	rdata = append(rdata, tsig.OtherData...)
+
+	signed := appendWireName(append([]byte{}, msg...), tsig.KeyName)
+	signed = binary.BigEndian.AppendUint16(signed, TSIG_TYPE)
+	signed = binary.BigEndian.AppendUint16(signed, CLASS_ANY)
+	signed = binary.BigEndian.AppendUint32(signed, 0)
+	signed = binary.BigEndian.AppendUint16(signed, uint16(len(rdata)))
+	signed = append(signed, rdata...)
+	binary.BigEndian.PutUint16(signed[10:], binary.BigEndian.Uint16(signed[10:])+1)
+	return signed, nil
+}
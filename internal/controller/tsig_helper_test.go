@@ -0,0 +1,142 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// buildTestUpdate return a dynamic update message adding an A record to www.example.org.
+func buildTestUpdate(t *testing.T) []byte {
+	t.Helper()
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 4242, OpCode: RFC2136_UPDATE_OPCODE})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Question(dnsmessage.Question{Name: dnsmessage.MustNewName("example.org."), Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.StartAuthorities(); err != nil {
+		t.Fatal(err)
+	}
+	h := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName("www.example.org."), Class: dnsmessage.ClassINET, TTL: 300}
+	if err := b.AResource(h, dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestTSIGSignature(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	now := time.Unix(1700000000, 0)
+	unsigned := buildTestUpdate(t)
+
+	var testCases = []struct {
+		description string
+		algorithm   string
+		verifyAt    time.Time
+		secret      []byte
+		tamper      bool
+		want        uint16
+	}{
+		{"Valid HMAC-SHA256 signature", "hmac-sha256.", now, secret, false, TSIG_NO_ERROR},
+		{"Valid HMAC-SHA512 signature", "hmac-sha512.", now.Add(time.Minute), secret, false, TSIG_NO_ERROR},
+		{"Wrong secret", "hmac-sha256.", now, []byte("wrong"), false, TSIG_BADSIG},
+		{"Tampered message", "hmac-sha256.", now, secret, true, TSIG_BADSIG},
+		{"Outside of the fudge", "hmac-sha256.", now.Add(10 * time.Minute), secret, false, TSIG_BADTIME},
+		{"Unsupported algorithm", "hmac-md5.sig-alg.reg.int.", now, secret, false, TSIG_BADKEY},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tsig := &tsigRecord{KeyName: "update-key.", Algorithm: tc.algorithm, TimeSigned: uint64(now.Unix()), Fudge: TSIG_FUDGE, OriginalID: 4242}
+			signed, err := appendTSIG(unsigned, tsig, secret, nil)
+			if tc.algorithm != "hmac-md5.sig-alg.reg.int." && err != nil {
+				t.Fatal(err)
+			}
+			if err != nil {
+				// The request of an unsupported algorithm is sent unsigned
+				signed, _ = appendTSIG(unsigned, tsig, nil, nil)
+			}
+			if tc.tamper {
+				signed[len(unsigned)-1]++
+			}
+			gotUnsigned, gotTSIG, err := splitTSIG(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotTSIG == nil {
+				t.Fatal("TSIG record not found")
+			}
+			if gotTSIG.KeyName != "update-key." || gotTSIG.Algorithm != tc.algorithm {
+				t.Errorf("got key %s and algorithm %s", gotTSIG.KeyName, gotTSIG.Algorithm)
+			}
+			if !tc.tamper && !cmp.Equal(gotUnsigned, unsigned) {
+				t.Errorf("unsigned message differs from the original message")
+			}
+			if got := verifyTSIG(gotUnsigned, gotTSIG, tc.secret, tc.verifyAt); got != tc.want {
+				t.Errorf("got TSIG error %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitTSIGUnsigned(t *testing.T) {
+	unsigned := buildTestUpdate(t)
+	got, tsig, err := splitTSIG(unsigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tsig != nil || !cmp.Equal(got, unsigned) {
+		t.Errorf("unsigned message reported as signed")
+	}
+	if _, _, err := splitTSIG(unsigned[:5]); err == nil {
+		t.Errorf("truncated message accepted")
+	}
+}
+
+func TestReadWireName(t *testing.T) {
+	// www.Example.org. followed by a pointer to example.org.
+	msg := []byte{3, 'w', 'w', 'w', 7, 'E', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'o', 'r', 'g', 0, 3, 'f', 't', 'p', 0xC0, 4}
+	var testCases = []struct {
+		description string
+		offset      int
+		wantName    string
+		wantNext    int
+		wantErr     bool
+	}{
+		{"Uncompressed name", 0, "www.example.org.", 17, false},
+		{"Compressed name", 17, "ftp.example.org.", 23, false},
+		{"Out of the message", 30, "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			name, next, err := readWireName(msg, tc.offset)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v", err)
+			}
+			if name != tc.wantName || next != tc.wantNext {
+				t.Errorf("got %s at %d, want %s at %d", name, next, tc.wantName, tc.wantNext)
+			}
+		})
+	}
+}
@@ -13,14 +13,18 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
 )
@@ -29,6 +33,30 @@ const (
 	RESOURCES_FINALIZER_NAME   = "dns.cav.enablers.ob/external-resources"
 	METRICS_FINALIZER_NAME     = "dns.cav.enablers.ob/metrics"
 	DEFAULT_TTL_FOR_NS_RECORDS = uint32(1500)
+	DEFAULT_TTL_FOR_DS_RECORDS = uint32(3600)
+	DEFAULT_TTL                = uint32(3600)
+
+	PDNS_COMMENT_ACCOUNT  = "powerdns-operator"
+	DS_RECORDS_COMMENT    = "DS records published from child zone keys"
+	DELEGATION_COMMENT    = "Delegation published from child zone nameservers"
+	ZONE_FILE_COMMENT     = "Synchronized from the zone file of the zone"
+	OWNER_COMMENT         = "Managed by powerdns-operator"
+	DS_SHA256_DIGEST_TYPE = "2"
+
+	DEFAULT_DNSSEC_KEY_ROLLOVER_DAYS = uint32(365)
+	DNSSEC_METRICS_REFRESH_INTERVAL  = time.Hour
+
+	RETRANSFER_ANNOTATION       = "dns.cav.enablers.ob/retransfer"
+	RETRANSFER_ANNOTATION_VALUE = "now"
+
+	// PURGE_ANNOTATION deletes the records of the zone, except SOA and NS, its value must be the zone name
+	PURGE_ANNOTATION = "dns.cav.enablers.ob/purge"
+	// PURGE_RRSETS_ANNOTATION deletes also the RRsets owned by the zone on purge
+	PURGE_RRSETS_ANNOTATION       = "dns.cav.enablers.ob/purge-rrsets"
+	PURGE_RRSETS_ANNOTATION_VALUE = "true"
+
+	// ZONE_DESCRIPTION_METADATA is the custom zone metadata storing the description of the Zone
+	ZONE_DESCRIPTION_METADATA = "X-DESCRIPTION"
 
 	ZONE_NOT_FOUND_MSG  = "Not Found"
 	ZONE_NOT_FOUND_CODE = 404
@@ -41,25 +69,73 @@ const (
 	ZoneMessageSyncSucceeded          = "Zone synced with PowerDNS instance"
 	ZoneReasonSynchronizationFailed   = "SynchronizationFailed"
 	ZoneReasonNSSynchronizationFailed = "NSSynchronizationFailed"
+	ZoneReasonRetransferFailed        = "RetransferFailed"
+	ZoneReasonDescriptionFailed       = "DescriptionSynchronizationFailed"
+	ZoneReasonPurgeFailed             = "PurgeFailed"
+	ZoneReasonCloneFailed             = "CloneFailed"
+	ZoneReasonCloned                  = "ZoneCloned"
+	ZoneMessageCloned                 = "Zone initialized from"
+	ZoneReasonClonePending            = "ClonePending"
+	ZoneMessageClonePendingFailed     = "Zone not synchronized, the records of the source zone are copied once synchronized"
+	ZoneMessageClonePendingDryRun     = "Dry-run mode, the records of the source zone are not copied"
+	ZoneMessageClonePendingFrozen     = "Zone frozen, the records of the source zone are copied once unfrozen"
+	ZoneReasonZoneFileInvalid         = "ZoneFileInvalid"
+	ZoneReasonZoneFileFailed          = "ZoneFileSynchronizationFailed"
+	ZoneReasonDSSynchronizationFailed = "DSSynchronizationFailed"
+	ZoneReasonDelegationFailed        = "DelegationFailed"
+	ZoneReasonDryRun                  = "DryRun"
+	ZoneMessageDryRun                 = "Dry-run mode, pending changes of the zone are not applied to PowerDNS"
+	ZoneMessageDryRunNotCreated       = "Dry-run mode, the zone is not created in PowerDNS"
 	ZoneReasonDuplicated              = "ZoneDuplicated"
 	ZoneMessageDuplicated             = "Already existing Zone with the same FQDN"
 )
 
+// ZoneOptions are the settings of the synchronization of the Zones and ClusterZones
+type ZoneOptions struct {
+	// DryRun disables the writes to PowerDNS of the zone and of its records, globally or through annotation on the zone
+	DryRun bool
+	// DNSSECKeyRolloverDays is the maximum age of an active DNSSEC key before it should be rolled over
+	DNSSECKeyRolloverDays uint32
+	// ClusterID identifies the operator instance owning the records: only the delegation and DS records written
+	// by this instance are modified or removed, and the records of other instances are not purged
+	ClusterID string
+	// Reader reads the Services of the primaries and the ConfigMaps of the zone files from the API server, only
+	// their metadata is cached. The client of the reconciler is used if nil
+	Reader client.Reader
+}
+
+// getObjectReader return the reader of the Services and ConfigMaps referenced by the zones
+func (o ZoneOptions) getObjectReader(cl client.Client) client.Reader {
+	if o.Reader == nil {
+		return cl
+	}
+	return o.Reader
+}
+
 // ZoneReconciler reconciles a Zone object
 type ZoneReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	PDNSClient PdnsClienter
+	// ZoneOptions are the settings of the synchronization of the zones
+	ZoneOptions
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+	// Resync enqueues the resources failed during a PowerDNS outage when the API is available again, if not nil
+	Resync source.Source
 }
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(zonesStatusesMetric)
+	metrics.Registry.MustRegister(zonesStatusesMetric, zonesDNSSECStatusMetric, zonesDNSSECKeyRolloverMetric, zonesPeerDivergenceMetric, zonesLastSyncMetric)
 }
 
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zones/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=clusterzones,verbs=get;list;watch
 
 func (r *ZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -96,13 +172,15 @@ func (r *ZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	if !isDeleted && isModified {
 		isModified = true
 		meta.RemoveStatusCondition(&zone.Status.Conditions, "Available")
-		if err := r.Status().Patch(ctx, zone, client.MergeFrom(original)); err != nil {
+		if err := patchStatusIfChanged(ctx, zone, original, r.Client); err != nil {
 			log.Error(err, "unable to patch Zone status")
 			return ctrl.Result{}, err
 		}
 	}
 
-	return zoneReconcile(ctx, zone, isModified, isDeleted, r.Client, r.PDNSClient, log)
+	opts := r.ZoneOptions
+	opts.DryRun = isDryRun(zone, r.DryRun)
+	return zoneReconcile(ctx, zone, isModified, isDeleted, opts, r.Client, r.PDNSClient, log)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -118,9 +196,25 @@ func (r *ZoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.MasterServices", func(rawObj client.Object) []string {
+		return getMasterServicesIndexKeys(rawObj.(*dnsv1alpha2.Zone))
+	}); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &dnsv1alpha2.Zone{}, "Zone.ZoneFileFrom", func(rawObj client.Object) []string {
+		return getZoneFileSourceIndexKeys(rawObj.(*dnsv1alpha2.Zone))
+	}); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&dnsv1alpha2.Zone{}).
-		Owns(&dnsv1alpha2.ClusterRRset{}).
-		Owns(&dnsv1alpha2.RRset{}).
-		Complete(r)
+		Watches(&dnsv1alpha2.Zone{}, enqueueDeletionsFirst()).
+		Owns(&dnsv1alpha2.ClusterRRset{}, builder.WithPredicates(ownedRRsetChangedPredicate())).
+		Owns(&dnsv1alpha2.RRset{}, builder.WithPredicates(ownedRRsetChangedPredicate())).
+		Watches(&corev1.Service{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.ZoneList{}, "Zone.MasterServices"), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, enqueueForReferencingResources(r.Client, &dnsv1alpha2.ZoneList{}, "Zone.ZoneFileFrom"), builder.OnlyMetadata)
+	if r.Resync != nil {
+		b = b.WatchesRawSource(r.Resync)
+	}
+	return b.Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
 }
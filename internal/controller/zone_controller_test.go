@@ -291,6 +291,7 @@ var _ = Describe("Zone Controller", func() {
 				return err == nil && modifiedZone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
 			}, timeout, interval).Should(BeTrue())
 			Expect(getMockedSOAEditAPI(resourceName)).To(Equal(modifiedResourceSOAEditAPI), "SOA-Edit-API should have changed")
+			Expect(ptr.Deref(modifiedZone.Status.SOAEditAPI, "")).To(Equal(modifiedResourceSOAEditAPI), "Serial strategy should be reported")
 			Expect(*(modifiedZone.Status.Serial)).To(Equal(epochSerial), "Serial should have changed")
 		})
 	})
@@ -456,6 +457,37 @@ var _ = Describe("Zone Controller", func() {
 			}, timeout, interval).Should(BeTrue())
 		})
 	})
+	Context("When existing resource", func() {
+		It("should successfully trigger a retransfer of a secondary zone", Label("zone-modification", "retransfer"), func() {
+			ctx := context.Background()
+			initialRetransfers := retransfers.Load()
+
+			By("Annotating the resource as a secondary zone to retransfer")
+			resource := &dnsv1alpha2.Zone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+			}
+			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, resource, func() error {
+				resource.Spec.Kind = SLAVE_KIND_ZONE
+				resource.SetAnnotations(map[string]string{RETRANSFER_ANNOTATION: RETRANSFER_ANNOTATION_VALUE})
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Getting the modified resource")
+			modifiedZone := &dnsv1alpha2.Zone{}
+			// Waiting for the annotation to be cleared
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, modifiedZone)
+				_, annotated := modifiedZone.GetAnnotations()[RETRANSFER_ANNOTATION]
+				return err == nil && !annotated && modifiedZone.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(retransfers.Load()-initialRetransfers).To(Equal(int32(1)), "Retransfer should have been requested once")
+		})
+	})
+
 	Context("When creating a Zone with an existing Zone with same FQDN", func() {
 		It("should reconcile the resource with Failed status", Label("zone-creation", "existing-zone"), func() {
 			ctx := context.Background()
@@ -0,0 +1,109 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	// FORCE_DELETE_ANNOTATION deletes the zone even when it is not empty
+	FORCE_DELETE_ANNOTATION       = "dns.cav.enablers.ob/force-delete"
+	FORCE_DELETE_ANNOTATION_VALUE = "true"
+	// ZONE_DELETION_RETRY_INTERVAL is the interval between the checks of a zone whose deletion is blocked
+	ZONE_DELETION_RETRY_INTERVAL = time.Minute
+
+	// CASCADE_DELETION_FOREGROUND deletes the RRsets owned by the zone before the zone
+	CASCADE_DELETION_FOREGROUND = "Foreground"
+	// CASCADE_DELETION_RETRY_INTERVAL is the interval between the checks of the RRsets deleted before the zone
+	CASCADE_DELETION_RETRY_INTERVAL = 2 * time.Second
+
+	ZoneReasonDeletionBlocked  = "DeletionBlocked"
+	ZoneMessageDeletionBlocked = "Deletion blocked, the " + FORCE_DELETE_ANNOTATION + " annotation allows to force it, by"
+)
+
+// getDeletionBlockers return what prevents the deletion of the zone: the RRsets referencing it from other namespaces,
+// which would be garbage collected with the zone, and the records of the zone not written by the operator instance
+func getDeletionBlockers(ctx context.Context, zone dnsv1alpha2.GenericZone, clusterID string, cl client.Client, PDNSClient PdnsClienter) ([]string, error) {
+	blockers := []string{}
+	referencingRRsets, err := getReferencingRRsets(ctx, zone, cl)
+	if err != nil {
+		return nil, err
+	}
+	for _, rrset := range referencingRRsets {
+		if rrset.GetNamespace() != zone.GetNamespace() {
+			blockers = append(blockers, "RRset "+rrset.GetNamespace()+"/"+rrset.GetName())
+		}
+	}
+
+	// The records of the secondary zones come from their primaries
+	if !isSecondaryZone(zone) {
+		externalZone, err := PDNSClient.Zones.Get(ctx, zone.GetObjectMeta().Name)
+		if err != nil && err.Error() != ZONE_NOT_FOUND_MSG {
+			return nil, err
+		}
+		if err == nil {
+			for _, name := range getUnmanagedExternalRRsets(zone.GetName(), clusterID, externalZone) {
+				blockers = append(blockers, "unmanaged records "+name)
+			}
+		}
+	}
+	slices.Sort(blockers)
+	return blockers, nil
+}
+
+// deleteOwnedRRsets delete the RRsets owned by the zone, return the number of RRsets not deleted yet (their finalizers are pending)
+func deleteOwnedRRsets(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Client, log logr.Logger) (int, error) {
+	referencingRRsets, err := getReferencingRRsets(ctx, zone, cl)
+	if err != nil {
+		return 0, err
+	}
+	owned := getOwnedRRsets(zone, referencingRRsets)
+	for _, rrset := range owned {
+		if !rrset.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := cl.Delete(ctx, rrset); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to delete RRset", "RRset.Name", rrset.GetName(), "RRset.Namespace", rrset.GetNamespace())
+			return 0, err
+		}
+	}
+	return len(owned), nil
+}
+
+// getUnmanagedExternalRRsets return the name and type of the RRsets of the external zone not written by the operator
+// instance of the cluster id, except the ones managed through the zone itself (SOA and apex NS)
+func getUnmanagedExternalRRsets(zoneName string, clusterID string, externalZone *powerdns.Zone) []string {
+	unmanaged := []string{}
+	for _, rrset := range externalZone.RRsets {
+		if isZoneManagedRRset(zoneName, rrset) || isClusterManagedRRset(rrset, clusterID) {
+			continue
+		}
+		unmanaged = append(unmanaged, ptr.Deref(rrset.Name, "")+"/"+string(ptr.Deref(rrset.Type, "")))
+	}
+	return unmanaged
+}
+
+// getDeletionBlockedMessage return the message of the Available condition of a zone whose deletion is blocked
+func getDeletionBlockedMessage(blockers []string) string {
+	return ZoneMessageDeletionBlocked + " " + strings.Join(blockers, ", ")
+}
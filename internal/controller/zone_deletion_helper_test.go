@@ -0,0 +1,39 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetUnmanagedExternalRRsets(t *testing.T) {
+	managed := []powerdns.Comment{{Account: ptr.To(PDNS_COMMENT_ACCOUNT), Content: ptr.To(OWNER_COMMENT)}}
+	ownedByCluster := []powerdns.Comment{{Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/east"), Content: ptr.To(OWNER_COMMENT)}}
+	ownedByOtherCluster := []powerdns.Comment{{Account: ptr.To(PDNS_COMMENT_ACCOUNT + "/west"), Content: ptr.To(OWNER_COMMENT)}}
+	externalZone := &powerdns.Zone{RRsets: []powerdns.RRset{
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeSOA)},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS)},
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), Comments: managed},
+		{Name: ptr.To("api.example.org."), Type: ptr.To(powerdns.RRTypeA), Comments: ownedByCluster},
+		{Name: ptr.To("west.example.org."), Type: ptr.To(powerdns.RRTypeA), Comments: ownedByOtherCluster},
+		{Name: ptr.To("manual.example.org."), Type: ptr.To(powerdns.RRTypeA)},
+		{Name: ptr.To("sub.example.org."), Type: ptr.To(powerdns.RRTypeNS)},
+	}}
+	want := []string{"west.example.org./A", "manual.example.org./A", "sub.example.org./NS"}
+	if diff := cmp.Diff(want, getUnmanagedExternalRRsets("example.org", "east", externalZone)); diff != "" {
+		t.Errorf("unexpected unmanaged RRsets (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZONE_FILE_EXTENSION     = ".zone"
+	ZONE_BACKUP_TIME_FORMAT = "20060102T150405Z"
+
+	BackupReasonZoneNotAvailable = "ZoneNotAvailable"
+	BackupReasonBackupFailed     = "BackupFailed"
+	BackupReasonInvalidSchedule  = "InvalidSchedule"
+	BackupReasonBackupSucceeded  = "BackupSucceeded"
+	BackupMessageBackupSucceeded = "Zone file stored in target"
+)
+
+// ZoneBackupReconciler reconciles a ZoneBackup object
+type ZoneBackupReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	PDNSClient PdnsClienter
+	// NewObjectStorageClient create the clients of the S3 targets, defaults to NewMinioObjectStorageClient
+	NewObjectStorageClient ObjectStorageClientFactory
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonebackups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonebackups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonebackups/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+func (r *ZoneBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile ZoneBackup", "ZoneBackup.Name", req.Name)
+
+	// ZoneBackup
+	backup := &dnsv1alpha2.ZoneBackup{}
+	err := r.Get(ctx, req.NamespacedName, backup)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !backup.DeletionTimestamp.IsZero() {
+		// Stored zone files are garbage collected through owner references
+		return ctrl.Result{}, nil
+	}
+
+	// An invalid schedule can only be fixed by a modification of the ZoneBackup
+	if _, _, err := nextZoneBackupTime(backup, time.Now().UTC()); err != nil {
+		if err := patchZoneBackupStatus(ctx, backup, nil, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  BackupReasonInvalidSchedule,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneBackup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize variable to represent ZoneBackup situation
+	isModified := backup.Status.ObservedGeneration == nil || *backup.Status.ObservedGeneration != backup.GetGeneration()
+	if !isModified && !isZoneBackupDue(backup, time.Now().UTC()) {
+		return ctrl.Result{RequeueAfter: zoneBackupRequeueAfter(backup, time.Now().UTC())}, nil
+	}
+
+	// Zone
+	var zone dnsv1alpha2.GenericZone
+	switch backup.Spec.ZoneRef.Kind {
+	//nolint:goconst
+	case "Zone":
+		zone = &dnsv1alpha2.Zone{}
+	//nolint:goconst
+	case "ClusterZone":
+		zone = &dnsv1alpha2.ClusterZone{}
+	}
+	err = r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ZoneRef.Name}, zone)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err := patchZoneBackupStatus(ctx, backup, nil, nil, nil, PENDING_STATUS, r.Client, metav1.Condition{
+				Type:    "Available",
+				Status:  metav1.ConditionFalse,
+				Reason:  BackupReasonZoneNotAvailable,
+				Message: RrsetMessageNonExistentZone + err.Error(),
+			}); err != nil {
+				log.Error(err, "unable to patch ZoneBackup status")
+				return ctrl.Result{}, err
+			}
+			// The Zone may be created later on
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		log.Error(err, "Failed to get zone")
+		return ctrl.Result{}, err
+	}
+	// If a Zone/ClusterZone exists but is in Failed Status
+	if zone.GetStatus().SyncStatus != nil && *zone.GetStatus().SyncStatus == FAILED_STATUS {
+		if err := patchZoneBackupStatus(ctx, backup, nil, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  BackupReasonZoneNotAvailable,
+			Message: RrsetMessageUnavailableZone + zone.GetName(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneBackup status")
+			return ctrl.Result{}, err
+		}
+		// The Zone may recover later on, its Zone/ClusterZone is not watched
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+
+	now := metav1.NewTime(time.Now().UTC())
+	location, err := r.backupZone(ctx, backup, zone, now.Time, log)
+	if err != nil {
+		log.Error(err, "Failed to back up zone")
+		if err := patchZoneBackupStatus(ctx, backup, nil, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  BackupReasonBackupFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneBackup status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: zoneBackupRequeueAfter(backup, time.Now().UTC())}, nil
+	}
+
+	if err := patchZoneBackupStatus(ctx, backup, &now, zone.GetStatus().Serial, &location, SUCCEEDED_STATUS, r.Client, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  BackupReasonBackupSucceeded,
+		Message: BackupMessageBackupSucceeded,
+	}); err != nil {
+		if errors.IsConflict(err) {
+			log.Info("Object has been modified, forcing a new reconciliation")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to patch ZoneBackup status")
+		return ctrl.Result{}, err
+	}
+	log.Info("Zone backed up", "Zone.Name", zone.GetName())
+
+	return ctrl.Result{RequeueAfter: zoneBackupRequeueAfter(backup, now.Time)}, nil
+}
+
+// backupZone exports the zone and stores the zone file in the target of the backup, it returns the backup location
+func (r *ZoneBackupReconciler) backupZone(ctx context.Context, backup *dnsv1alpha2.ZoneBackup, zone dnsv1alpha2.GenericZone, now time.Time, log logr.Logger) (string, error) {
+	zoneFile, err := exportZoneExternalResources(ctx, zone, r.PDNSClient, log)
+	if err != nil {
+		return "", err
+	}
+	key := getZoneFileKey(zone.GetName())
+
+	var obj client.Object
+	var mutate func()
+	var location string
+	switch {
+	case backup.Spec.Target.ConfigMap != nil:
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: backup.Spec.Target.ConfigMap.Name, Namespace: backup.Namespace}}
+		obj, mutate, location = cm, func() {
+			cm.Data = map[string]string{key: zoneFile}
+		}, "configmap/"+cm.Name
+	case backup.Spec.Target.Secret != nil:
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: backup.Spec.Target.Secret.Name, Namespace: backup.Namespace}}
+		obj, mutate, location = secret, func() {
+			secret.Data = map[string][]byte{key: []byte(zoneFile)}
+		}, "secret/"+secret.Name
+	case backup.Spec.Target.S3 != nil:
+		return r.uploadZoneFile(ctx, backup, zone, zoneFile, now, log)
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		mutate()
+		return controllerutil.SetControllerReference(backup, obj, r.Scheme)
+	})
+	return location, err
+}
+
+// uploadZoneFile uploads the zone file to the S3 target of the backup and deletes the backups exceeding the retention
+func (r *ZoneBackupReconciler) uploadZoneFile(ctx context.Context, backup *dnsv1alpha2.ZoneBackup, zone dnsv1alpha2.GenericZone, zoneFile string, now time.Time, log logr.Logger) (string, error) {
+	target := *backup.Spec.Target.S3
+	osClient, err := newObjectStorageClientForTarget(ctx, r.Client, backup.Namespace, target, r.NewObjectStorageClient)
+	if err != nil {
+		log.Error(err, "Failed to create object storage client")
+		return "", err
+	}
+
+	prefix := getZoneBackupObjectPrefix(target, zone.GetName())
+	key := prefix + now.UTC().Format(ZONE_BACKUP_TIME_FORMAT) + ZONE_FILE_EXTENSION
+	if err := osClient.Put(ctx, target.Bucket, key, []byte(zoneFile)); err != nil {
+		log.Error(err, "Failed to upload zone file", "bucket", target.Bucket, "key", key)
+		return "", err
+	}
+
+	if target.Retention != nil {
+		keys, err := osClient.List(ctx, target.Bucket, prefix)
+		if err != nil {
+			log.Error(err, "Failed to list zone backups", "bucket", target.Bucket, "prefix", prefix)
+			return "", err
+		}
+		for _, expired := range expiredZoneBackupObjects(keys, target.Retention) {
+			if err := osClient.Remove(ctx, target.Bucket, expired); err != nil {
+				log.Error(err, "Failed to delete expired zone backup", "bucket", target.Bucket, "key", expired)
+				return "", err
+			}
+			log.Info("Expired zone backup deleted", "bucket", target.Bucket, "key", expired)
+		}
+	}
+	return "s3://" + target.Bucket + "/" + key, nil
+}
+
+func patchZoneBackupStatus(ctx context.Context, backup *dnsv1alpha2.ZoneBackup, lastBackupTime *metav1.Time, serial *uint32, location *string, status string, cl client.Client, condition metav1.Condition) error {
+	original := backup.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&backup.Status.Conditions, condition)
+	if lastBackupTime != nil {
+		backup.Status.LastBackupTime = lastBackupTime
+		backup.Status.Serial = serial
+		backup.Status.Location = location
+	}
+	backup.Status.SyncStatus = ptr.To(status)
+	backup.Status.ObservedGeneration = ptr.To(backup.GetGeneration())
+	return cl.Status().Patch(ctx, backup, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZoneBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.ZoneBackup{}).
+		Watches(&dnsv1alpha2.ZoneBackup{}, enqueueDeletionsFirst()).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
@@ -0,0 +1,231 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("ZoneBackup Controller", func() {
+
+	const (
+		zoneName          = "example8.org"
+		resourceName      = "example8-backup"
+		resourceNamespace = "example8"
+		targetName        = "example8-zonefile"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example8.org", "ns2.example8.org"}
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+	targetNamespacedName := types.NamespacedName{
+		Name:      targetName,
+		Namespace: resourceNamespace,
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		// Confirm that resource is created in the backend
+		Eventually(func() bool {
+			_, found := readFromZonesMap(makeCanonical(zoneName))
+			return found
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance ZoneBackup")
+		backup := &dnsv1alpha2.ZoneBackup{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, backup); err == nil {
+			Expect(k8sClient.Delete(ctx, backup)).To(Succeed())
+		}
+		// No garbage collector in the test environment, targets are removed explicitly
+		_ = k8sClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: targetName, Namespace: resourceNamespace}})
+		_ = k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: targetName, Namespace: resourceNamespace}})
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	Context("When creating a ZoneBackup with a ConfigMap target", func() {
+		It("should store the zone file in the ConfigMap", Label("zonebackup-creation", "configmap"), func() {
+			ctx := context.Background()
+			By("Creating the ZoneBackup")
+			backup := &dnsv1alpha2.ZoneBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneBackupSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Target:  dnsv1alpha2.BackupTarget{ConfigMap: &dnsv1alpha2.BackupObjectTarget{Name: targetName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, backup)).To(Succeed())
+
+			By("Getting the backed up resource")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, backup)
+				return err == nil && backup.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(backup.Status.LastBackupTime).NotTo(BeNil(), "Last backup time should be set")
+
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, targetNamespacedName, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKey(getZoneFileKey(zoneName)), "ConfigMap should contain the zone file")
+			Expect(cm.Data[getZoneFileKey(zoneName)]).To(ContainSubstring("ns1.example8.org."), "Zone file should contain the nameservers")
+			Expect(metav1.IsControlledBy(cm, backup)).To(BeTrue(), "ConfigMap should be owned by the ZoneBackup")
+		})
+	})
+
+	Context("When creating a ZoneBackup with a Secret target", func() {
+		It("should store the zone file in the Secret", Label("zonebackup-creation", "secret"), func() {
+			ctx := context.Background()
+			By("Creating the ZoneBackup")
+			backup := &dnsv1alpha2.ZoneBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneBackupSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Target:  dnsv1alpha2.BackupTarget{Secret: &dnsv1alpha2.BackupObjectTarget{Name: targetName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, backup)).To(Succeed())
+
+			By("Getting the backed up resource")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, backup)
+				return err == nil && backup.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, targetNamespacedName, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKey(getZoneFileKey(zoneName)), "Secret should contain the zone file")
+		})
+	})
+
+	Context("When creating a ZoneBackup with a S3 target", func() {
+		It("should upload the zone file and apply the retention", Label("zonebackup-creation", "s3"), func() {
+			ctx := context.Background()
+			// Specific test variables
+			bucket := "backups"
+			expiredKey := "example8.org/20000101T000000Z.zone"
+			Expect(mockObjectStorageClient{}.Put(ctx, bucket, expiredKey, []byte("expired"))).To(Succeed())
+
+			By("Creating the credentials Secret")
+			credentials := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      targetName,
+					Namespace: resourceNamespace,
+				},
+				StringData: map[string]string{S3_ACCESS_KEY_ID_KEY: "access", S3_SECRET_ACCESS_KEY_KEY: "secret"},
+			}
+			Expect(k8sClient.Create(ctx, credentials)).To(Succeed())
+
+			By("Creating the ZoneBackup")
+			backup := &dnsv1alpha2.ZoneBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneBackupSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Target: dnsv1alpha2.BackupTarget{S3: &dnsv1alpha2.S3BackupTarget{
+						Endpoint:             "s3.example8.org",
+						Bucket:               bucket,
+						CredentialsSecretRef: corev1.LocalObjectReference{Name: targetName},
+						Retention:            ptr.To(int32(1)),
+					}},
+					Schedule: ptr.To("0 3 * * *"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, backup)).To(Succeed())
+
+			By("Getting the backed up resource")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, backup)
+				return err == nil && backup.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(*backup.Status.Location).To(HavePrefix("s3://backups/example8.org/"), "Location should be the uploaded object")
+
+			keys, _ := mockObjectStorageClient{}.List(ctx, bucket, "example8.org/")
+			Expect(keys).To(HaveLen(1), "Only the last backup should be kept")
+			Expect(keys).NotTo(ContainElement(expiredKey), "Expired backup should be deleted")
+		})
+	})
+
+	Context("When creating a ZoneBackup on a non-existing zone", func() {
+		It("should reconcile the resource with Pending status", Label("zonebackup-creation", "non-existing-zone"), func() {
+			ctx := context.Background()
+			By("Creating the ZoneBackup")
+			backup := &dnsv1alpha2.ZoneBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneBackupSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: "nonexistent.org", Kind: "Zone"},
+					Target:  dnsv1alpha2.BackupTarget{ConfigMap: &dnsv1alpha2.BackupObjectTarget{Name: targetName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, backup)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, backup)
+				return err == nil && backup.IsInExpectedStatus(FIRST_GENERATION, PENDING_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(backup.Status.LastBackupTime).To(BeNil(), "No backup should have been done")
+		})
+	})
+})
@@ -0,0 +1,58 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+// nextZoneBackupTime return the time of the next scheduled backup, computed from the last backup
+// (or from now if no backup has been done yet), and False if backups are not scheduled
+func nextZoneBackupTime(backup *dnsv1alpha2.ZoneBackup, now time.Time) (time.Time, bool, error) {
+	base := now
+	if backup.Status.LastBackupTime != nil {
+		base = backup.Status.LastBackupTime.Time
+	}
+	switch {
+	case backup.Spec.Interval != nil:
+		return base.Add(backup.Spec.Interval.Duration), true, nil
+	case backup.Spec.Schedule != nil:
+		schedule, err := cron.ParseStandard(*backup.Spec.Schedule)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return schedule.Next(base), true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+// isZoneBackupDue return True if no backup has been done yet or if the next scheduled backup is due
+func isZoneBackupDue(backup *dnsv1alpha2.ZoneBackup, now time.Time) bool {
+	if backup.Status.LastBackupTime == nil {
+		return true
+	}
+	next, scheduled, err := nextZoneBackupTime(backup, now)
+	return err == nil && scheduled && !now.Before(next)
+}
+
+// zoneBackupRequeueAfter return the duration until the next scheduled backup, 0 if backups are not scheduled
+func zoneBackupRequeueAfter(backup *dnsv1alpha2.ZoneBackup, now time.Time) time.Duration {
+	next, scheduled, err := nextZoneBackupTime(backup, now)
+	if err != nil || !scheduled {
+		return 0
+	}
+	return max(next.Sub(now), time.Second)
+}
@@ -0,0 +1,58 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsZoneBackupDue(t *testing.T) {
+	var (
+		now        = time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC)
+		lastBackup = metav1.NewTime(time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC))
+	)
+
+	var testCases = []struct {
+		description  string
+		backup       *dnsv1alpha2.ZoneBackup
+		due          bool
+		requeueAfter time.Duration
+	}{
+		{"Never backed up", &dnsv1alpha2.ZoneBackup{}, true, 0},
+		{"On demand backup done", &dnsv1alpha2.ZoneBackup{Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, false, 0},
+		{"Interval not elapsed", &dnsv1alpha2.ZoneBackup{Spec: dnsv1alpha2.ZoneBackupSpec{Interval: &metav1.Duration{Duration: 24 * time.Hour}}, Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, false, 12 * time.Hour},
+		{"Interval elapsed", &dnsv1alpha2.ZoneBackup{Spec: dnsv1alpha2.ZoneBackupSpec{Interval: &metav1.Duration{Duration: 6 * time.Hour}}, Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, true, time.Second},
+		{"Schedule not reached", &dnsv1alpha2.ZoneBackup{Spec: dnsv1alpha2.ZoneBackupSpec{Schedule: ptr.To("0 18 * * *")}, Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, false, 6 * time.Hour},
+		{"Schedule reached", &dnsv1alpha2.ZoneBackup{Spec: dnsv1alpha2.ZoneBackupSpec{Schedule: ptr.To("0 3 * * *")}, Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, true, time.Second},
+		{"Invalid schedule", &dnsv1alpha2.ZoneBackup{Spec: dnsv1alpha2.ZoneBackupSpec{Schedule: ptr.To("every day")}, Status: dnsv1alpha2.ZoneBackupStatus{LastBackupTime: &lastBackup}}, false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			due := isZoneBackupDue(tc.backup, now)
+			if !cmp.Equal(due, tc.due) {
+				t.Errorf("got %v, want %v", due, tc.due)
+			}
+			requeueAfter := zoneBackupRequeueAfter(tc.backup, now)
+			if !cmp.Equal(requeueAfter, tc.requeueAfter) {
+				t.Errorf("got %v, want %v", requeueAfter, tc.requeueAfter)
+			}
+		})
+	}
+}
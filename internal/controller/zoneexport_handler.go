@@ -0,0 +1,209 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZONE_EXPORT_PATH             = "/export/"
+	ZONE_EXPORT_FORMAT_ZONEFILE  = "zonefile"
+	ZONE_EXPORT_FORMAT_OCTODNS   = "octodns"
+	ZONE_EXPORT_ZONE_QUERY_PARAM = "zone"
+)
+
+// ZoneExportHandler serves the managed zones, as stored in PowerDNS, rendered as zone files
+// (GET /export/zonefile) or octoDNS YAML (GET /export/octodns).
+// The "zone" query parameter restricts the export to a single zone.
+type ZoneExportHandler struct {
+	Client     client.Reader
+	PDNSClient PdnsClienter
+}
+
+// octodnsRecord is the octoDNS YAML representation of a RRset, the single-value types are set with value
+type octodnsRecord struct {
+	Type   string `json:"type"`
+	TTL    uint32 `json:"ttl"`
+	Value  any    `json:"value,omitempty"`
+	Values []any  `json:"values,omitempty"`
+}
+
+// octodnsSingleValueTypes are the types octoDNS loads from a value instead of values
+var octodnsSingleValueTypes = []powerdns.RRType{powerdns.RRTypeCNAME, powerdns.RRTypeALIAS, powerdns.RRTypeDNAME}
+
+func (h *ZoneExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("zone-export")
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	format := path.Base(r.URL.Path)
+	if format != ZONE_EXPORT_FORMAT_ZONEFILE && format != ZONE_EXPORT_FORMAT_OCTODNS {
+		http.Error(w, fmt.Sprintf("unknown export format %q", format), http.StatusNotFound)
+		return
+	}
+
+	zones, err := getManagedZones(r.Context(), h.Client, r.URL.Query().Get(ZONE_EXPORT_ZONE_QUERY_PARAM))
+	if err != nil {
+		log.Error(err, "Failed to list managed zones")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	switch format {
+	case ZONE_EXPORT_FORMAT_ZONEFILE:
+		body, err = renderZoneFiles(r.Context(), zones, h.PDNSClient, log)
+		w.Header().Set("Content-Type", "text/dns")
+	case ZONE_EXPORT_FORMAT_OCTODNS:
+		body, err = renderOctodnsZones(r.Context(), zones, h.PDNSClient, log)
+		w.Header().Set("Content-Type", "application/yaml")
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// getManagedZones return the Zones and ClusterZones successfully synchronized with PowerDNS, sorted by name.
+// If zoneName is not empty, only the matching zone is returned.
+func getManagedZones(ctx context.Context, cl client.Reader, zoneName string) ([]dnsv1alpha2.GenericZone, error) {
+	zones := []dnsv1alpha2.GenericZone{}
+	zoneList := &dnsv1alpha2.ZoneList{}
+	if err := cl.List(ctx, zoneList); err != nil {
+		return nil, err
+	}
+	for i := range zoneList.Items {
+		zones = append(zones, &zoneList.Items[i])
+	}
+	clusterZoneList := &dnsv1alpha2.ClusterZoneList{}
+	if err := cl.List(ctx, clusterZoneList); err != nil {
+		return nil, err
+	}
+	for i := range clusterZoneList.Items {
+		zones = append(zones, &clusterZoneList.Items[i])
+	}
+
+	zones = slices.DeleteFunc(zones, func(z dnsv1alpha2.GenericZone) bool {
+		return !z.GetDeletionTimestamp().IsZero() ||
+			ptr.Deref(z.GetStatus().SyncStatus, "") != SUCCEEDED_STATUS ||
+			(zoneName != "" && makeCanonical(z.GetObjectMeta().Name) != makeCanonical(zoneName))
+	})
+	slices.SortFunc(zones, func(a, b dnsv1alpha2.GenericZone) int {
+		return strings.Compare(a.GetObjectMeta().Name, b.GetObjectMeta().Name)
+	})
+	return zones, nil
+}
+
+// renderZoneFiles return the concatenation of the zone files exported by PowerDNS
+func renderZoneFiles(ctx context.Context, zones []dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) ([]byte, error) {
+	var sb strings.Builder
+	for _, zone := range zones {
+		zoneFile, err := exportZoneExternalResources(ctx, zone, PDNSClient, log.WithValues("zone", zone.GetObjectMeta().Name))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&sb, "; zone %s\n%s\n", makeCanonical(zone.GetObjectMeta().Name), zoneFile)
+	}
+	return []byte(sb.String()), nil
+}
+
+// renderOctodnsZones return the zones as a YAML document, keyed by zone name, each zone
+// being in the octoDNS YAML provider format
+func renderOctodnsZones(ctx context.Context, zones []dnsv1alpha2.GenericZone, PDNSClient PdnsClienter, log logr.Logger) ([]byte, error) {
+	result := map[string]map[string][]octodnsRecord{}
+	for _, zone := range zones {
+		zoneFile, err := exportZoneExternalResources(ctx, zone, PDNSClient, log.WithValues("zone", zone.GetObjectMeta().Name))
+		if err != nil {
+			return nil, err
+		}
+		rrsets, err := parseZoneFile(zoneFile)
+		if err != nil {
+			return nil, err
+		}
+		result[makeCanonical(zone.GetObjectMeta().Name)] = toOctodnsZone(zone.GetObjectMeta().Name, rrsets)
+	}
+	return yaml.Marshal(result)
+}
+
+// toOctodnsZone convert RRsets to octoDNS records, keyed by name relative to the zone ("" for the apex).
+// SOA records are managed by octoDNS providers and are skipped.
+func toOctodnsZone(zoneName string, rrsets []powerdns.RRset) map[string][]octodnsRecord {
+	result := map[string][]octodnsRecord{}
+	for _, rrset := range rrsets {
+		rrType := ptr.Deref(rrset.Type, "")
+		if rrType == powerdns.RRTypeSOA {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(ptr.Deref(rrset.Name, ""), makeCanonical(zoneName)), ".")
+		record := octodnsRecord{Type: string(rrType), TTL: ptr.Deref(rrset.TTL, 0), Values: []any{}}
+		for _, content := range recordsContent(rrset) {
+			record.Values = append(record.Values, toOctodnsValue(rrType, content))
+		}
+		if slices.Contains(octodnsSingleValueTypes, rrType) && len(record.Values) == 1 {
+			record.Value, record.Values = record.Values[0], nil
+		}
+		result[name] = append(result[name], record)
+	}
+	return result
+}
+
+// toOctodnsValue convert a record content to its octoDNS value.
+// Types without a structured octoDNS representation are kept as is.
+func toOctodnsValue(rrType powerdns.RRType, content string) any {
+	fields := strings.Fields(content)
+	switch rrType {
+	case powerdns.RRTypeMX:
+		if len(fields) == 2 {
+			if preference, err := strconv.Atoi(fields[0]); err == nil {
+				return map[string]any{"preference": preference, "exchange": fields[1]}
+			}
+		}
+	case powerdns.RRTypeSRV:
+		if len(fields) == 4 {
+			priority, errP := strconv.Atoi(fields[0])
+			weight, errW := strconv.Atoi(fields[1])
+			port, errPo := strconv.Atoi(fields[2])
+			if errP == nil && errW == nil && errPo == nil {
+				return map[string]any{"priority": priority, "weight": weight, "port": port, "target": fields[3]}
+			}
+		}
+	case powerdns.RRTypeCAA:
+		if len(fields) == 3 {
+			if flags, err := strconv.Atoi(fields[0]); err == nil {
+				return map[string]any{"flags": flags, "tag": fields[1], "value": strings.Trim(fields[2], `"`)}
+			}
+		}
+	case powerdns.RRTypeTXT, powerdns.RRTypeSPF:
+		// octoDNS expects unquoted values, with escaped semicolons
+		value := strings.ReplaceAll(strings.Trim(content, `"`), `" "`, "")
+		return strings.ReplaceAll(value, ";", `\;`)
+	}
+	return content
+}
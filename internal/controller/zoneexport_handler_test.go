@@ -0,0 +1,107 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestToOctodnsValue(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrType      powerdns.RRType
+		content     string
+		want        any
+	}{
+		{"A record", powerdns.RRTypeA, "1.1.1.1", "1.1.1.1"},
+		{"MX record", powerdns.RRTypeMX, "10 mx.example.org.", map[string]any{"preference": 10, "exchange": "mx.example.org."}},
+		{"SRV record", powerdns.RRTypeSRV, "10 20 5060 sip.example.org.", map[string]any{"priority": 10, "weight": 20, "port": 5060, "target": "sip.example.org."}},
+		{"CAA record", powerdns.RRTypeCAA, `0 issue "letsencrypt.org"`, map[string]any{"flags": 0, "tag": "issue", "value": "letsencrypt.org"}},
+		{"TXT record", powerdns.RRTypeTXT, `"v=spf1 mx -all"`, "v=spf1 mx -all"},
+		{"TXT record with chunks and semicolons", powerdns.RRTypeTXT, `"v=DKIM1; k=rsa; " "p=ABCD"`, `v=DKIM1\; k=rsa\; p=ABCD`},
+		{"Malformed MX record", powerdns.RRTypeMX, "mx.example.org.", "mx.example.org."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := toOctodnsValue(tc.rrType, tc.content)
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToOctodnsZone(t *testing.T) {
+	rrsets := []powerdns.RRset{
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeSOA), TTL: ptr.To(uint32(3600)), Records: toPdnsRecords([]string{"ns1.example.org. hostmaster.example.org. 1 10800 3600 604800 3600"})},
+		{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(1500)), Records: toPdnsRecords([]string{"ns2.example.org.", "ns1.example.org."})},
+		{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1"})},
+		{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeAAAA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"::1"})},
+		{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeCNAME), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"test.example.org."})},
+	}
+	want := map[string][]octodnsRecord{
+		"":     {{Type: "NS", TTL: 1500, Values: []any{"ns1.example.org.", "ns2.example.org."}}},
+		"test": {{Type: "A", TTL: 300, Values: []any{"1.1.1.1"}}, {Type: "AAAA", TTL: 300, Values: []any{"::1"}}},
+		"www":  {{Type: "CNAME", TTL: 300, Value: "test.example.org."}},
+	}
+
+	got := toOctodnsZone("example.org", rrsets)
+	if !cmp.Equal(got, want, cmp.AllowUnexported(octodnsRecord{})) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRenderOctodnsZones(t *testing.T) {
+	var (
+		name      = "example.org"
+		namespace = "example"
+	)
+	ctx := context.Background()
+	log := log.FromContext(ctx)
+
+	var testCases = []struct {
+		description string
+		zones       []dnsv1alpha2.GenericZone
+		want        string
+		e           bool
+	}{
+		{"No zone", []dnsv1alpha2.GenericZone{}, "{}\n", false},
+		{"Existing Zone", []dnsv1alpha2.GenericZone{&dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}}, "example.org.:\n  \"\":\n  - ttl: 1500\n    type: NS\n    values:\n    - ns1.example.org\n    - ns2.example.org\n  test:\n  - ttl: 1500\n    type: A\n    values:\n    - 1.1.1.2\n    - 2.2.2.3\n", false},
+		{"communication error", []dnsv1alpha2.GenericZone{&dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: FAKE_SITE, Namespace: namespace}}}, "", true},
+	}
+
+	// Mock initialization
+	teardownTestCase := setupTestCase()
+	defer teardownTestCase()
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := renderOctodnsZones(ctx, tc.zones, PDNSClient, log)
+			if string(got) != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+			if (err != nil) != tc.e {
+				t.Errorf("got %v, want error %v", err, tc.e)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+
+	"github.com/powerdns-operator/powerdns-operator/internal/dnsutil"
+)
+
+// A zone file line, as exported by PowerDNS: <name> <ttl> IN <type> <content>
+var zoneFileLineRegexp = regexp.MustCompile(`^(\S+)\s+(\d+)\s+IN\s+(\S+)\s+(.*?)\s*$`)
+
+// zoneFileNameFields are, for the types whose records hold domain names, the positions of these names in the records
+var zoneFileNameFields = map[string][]int{
+	string(powerdns.RRTypeCNAME): {0},
+	string(powerdns.RRTypeDNAME): {0},
+	string(powerdns.RRTypeNS):    {0},
+	string(powerdns.RRTypePTR):   {0},
+	string(powerdns.RRTypeALIAS): {0},
+	string(powerdns.RRTypeMX):    {1},
+	string(powerdns.RRTypeSRV):   {3},
+}
+
+// zoneFileEntry is an entry of a zone file in the BIND format, its lines grouped by parentheses joined
+type zoneFileEntry struct {
+	line int
+	// The owner name is omitted, the one of the previous entry applies
+	indented bool
+	fields   []string
+}
+
+// rrsetChanges describes the changes to apply on a zone to restore a zone file
+type rrsetChanges struct {
+	upserts   []powerdns.RRset
+	deletions []powerdns.RRset
+	diff      []string
+}
+
+// parseZoneFile return the RRsets of a zone file exported by PowerDNS, sorted by name and type.
+// Comments, blank lines and directives are ignored.
+func parseZoneFile(zoneFile string) ([]powerdns.RRset, error) {
+	rrsets := map[string]*powerdns.RRset{}
+	for i, line := range strings.Split(zoneFile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "$") {
+			continue
+		}
+		fields := zoneFileLineRegexp.FindStringSubmatch(line)
+		if fields == nil {
+			return nil, fmt.Errorf("invalid zone file line %d: %q", i+1, line)
+		}
+		ttl, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TTL on zone file line %d: %w", i+1, err)
+		}
+		name, rrType := makeCanonical(fields[1]), powerdns.RRType(strings.ToUpper(fields[3]))
+		key := name + "/" + string(rrType)
+		if _, ok := rrsets[key]; !ok {
+			rrsets[key] = &powerdns.RRset{Name: ptr.To(name), Type: ptr.To(rrType), TTL: ptr.To(uint32(ttl))}
+		}
+		rrsets[key].Records = append(rrsets[key].Records, powerdns.Record{Content: ptr.To(fields[4]), Disabled: ptr.To(false)})
+	}
+	return getSortedRRsets(rrsets), nil
+}
+
+// parseBINDZoneFile return the RRsets of a zone file in the BIND format (RFC 1035), sorted by name and type.
+// The relative names are qualified with the origin, the zone name unless set by $ORIGIN. An omitted TTL is
+// the one set by $TTL, or else the TTL of the previous record, DEFAULT_TTL for the first records.
+func parseBINDZoneFile(zoneName, zoneFile string) ([]powerdns.RRset, error) {
+	entries, err := splitZoneFileEntries(zoneFile)
+	if err != nil {
+		return nil, err
+	}
+	zone := strings.ToLower(makeCanonical(zoneName))
+	origin, owner := zone, ""
+	ttl, hasDefaultTTL := DEFAULT_TTL, false
+	rrsets := map[string]*powerdns.RRset{}
+	for _, entry := range entries {
+		fields := entry.fields
+		switch directive := strings.ToUpper(fields[0]); {
+		case directive == "$ORIGIN" && len(fields) == 2:
+			origin = qualifyZoneFileName(fields[1], origin)
+			continue
+		case directive == "$TTL" && len(fields) == 2:
+			if ttl, err = dnsutil.ParseTTL(strings.ToLower(fields[1])); err != nil {
+				return nil, fmt.Errorf("invalid $TTL on zone file line %d: %w", entry.line, err)
+			}
+			hasDefaultTTL = true
+			continue
+		case strings.HasPrefix(directive, "$"):
+			return nil, fmt.Errorf("unsupported directive on zone file line %d: %s", entry.line, fields[0])
+		}
+		if !entry.indented {
+			owner, fields = qualifyZoneFileName(fields[0], origin), fields[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("missing owner name on zone file line %d", entry.line)
+		}
+		if !isInZone(owner, zone) {
+			return nil, fmt.Errorf("name out of the zone on zone file line %d: %s", entry.line, owner)
+		}
+
+		// The TTL and the class are optional, in any order
+		recordTTL := ttl
+		for range 2 {
+			if len(fields) == 0 {
+				break
+			}
+			if strings.EqualFold(fields[0], "IN") {
+				fields = fields[1:]
+			} else if value, err := dnsutil.ParseTTL(strings.ToLower(fields[0])); err == nil {
+				recordTTL, fields = value, fields[1:]
+				if !hasDefaultTTL {
+					ttl = value
+				}
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid zone file line %d: missing type or data", entry.line)
+		}
+		rrType, data := strings.ToUpper(fields[0]), slices.Clone(fields[1:])
+		for _, i := range zoneFileNameFields[rrType] {
+			if i < len(data) {
+				data[i] = qualifyZoneFileName(data[i], origin)
+			}
+		}
+
+		key := owner + "/" + rrType
+		if _, ok := rrsets[key]; !ok {
+			rrsets[key] = &powerdns.RRset{Name: ptr.To(owner), Type: ptr.To(powerdns.RRType(rrType)), TTL: ptr.To(recordTTL)}
+		}
+		rrsets[key].Records = append(rrsets[key].Records, powerdns.Record{Content: ptr.To(strings.Join(data, " ")), Disabled: ptr.To(false)})
+	}
+	return getSortedRRsets(rrsets), nil
+}
+
+// splitZoneFileEntries return the entries of a zone file in the BIND format, without the comments.
+// The fields are separated by blanks, the quoted strings are kept as a single field, with their quotes.
+func splitZoneFileEntries(zoneFile string) ([]zoneFileEntry, error) {
+	entries := []zoneFileEntry{}
+	var entry zoneFileEntry
+	var field strings.Builder
+	endField := func() {
+		if field.Len() != 0 {
+			entry.fields = append(entry.fields, field.String())
+			field.Reset()
+		}
+	}
+	depth := 0
+	for i, line := range strings.Split(zoneFile, "\n") {
+		if depth == 0 {
+			entry = zoneFileEntry{line: i + 1, indented: strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")}
+		}
+		quoted, escaped := false, false
+	chars:
+		for _, c := range line {
+			switch {
+			case escaped:
+				field.WriteRune(c)
+				escaped = false
+			case c == '\\':
+				field.WriteRune(c)
+				escaped = true
+			case c == '"':
+				field.WriteRune(c)
+				quoted = !quoted
+			case quoted:
+				field.WriteRune(c)
+			case c == ';':
+				break chars
+			case c == '(' || c == ')' || c == ' ' || c == '\t' || c == '\r':
+				endField()
+				if c == '(' {
+					depth++
+				} else if c == ')' {
+					depth--
+				}
+			default:
+				field.WriteRune(c)
+			}
+		}
+		if quoted {
+			return nil, fmt.Errorf("unterminated quoted string on zone file line %d", i+1)
+		}
+		if depth < 0 {
+			return nil, fmt.Errorf("unbalanced parentheses on zone file line %d", i+1)
+		}
+		endField()
+		if depth == 0 && len(entry.fields) != 0 {
+			entries = append(entries, entry)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses on zone file line %d", entry.line)
+	}
+	return entries, nil
+}
+
+// qualifyZoneFileName return the canonical name of a name of a zone file, relative to the origin unless absolute
+func qualifyZoneFileName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name) + "." + origin
+}
+
+// getSortedRRsets return the RRsets, sorted by name and type
+func getSortedRRsets(rrsets map[string]*powerdns.RRset) []powerdns.RRset {
+	keys := make([]string, 0, len(rrsets))
+	for k := range rrsets {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	result := make([]powerdns.RRset, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, *rrsets[k])
+	}
+	return result
+}
+
+// isSynchronizedFromZoneFile return True if the external RRset has been written from the zone file of the zone
+func isSynchronizedFromZoneFile(externalRecord powerdns.RRset) bool {
+	for _, c := range externalRecord.Comments {
+		if ptr.Deref(c.Account, "") == PDNS_COMMENT_ACCOUNT && ptr.Deref(c.Content, "") == ZONE_FILE_COMMENT {
+			return true
+		}
+	}
+	return false
+}
+
+// getZoneFileChanges return the changes to apply on the external RRsets of a zone to synchronize the RRsets of its
+// zone file. The RRsets managed through the zone itself, by RRset resources (their name/type keys), or written by
+// any operator instance (e.g. the delegation of a child zone) are left untouched. The RRsets written from the zone
+// file, and removed from it since, are deleted.
+func getZoneFileChanges(zoneName string, external, desired []powerdns.RRset, managed []string) *powerdns.RRsets {
+	changes := &powerdns.RRsets{}
+	externalByKey := map[string]powerdns.RRset{}
+	for _, r := range external {
+		externalByKey[ptr.Deref(r.Name, "")+"/"+string(ptr.Deref(r.Type, ""))] = r
+	}
+	desiredKeys := map[string]bool{}
+	comment := powerdns.Comment{Content: ptr.To(ZONE_FILE_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}
+	for _, d := range desired {
+		key := ptr.Deref(d.Name, "") + "/" + string(ptr.Deref(d.Type, ""))
+		if isZoneManagedRRset(zoneName, d) || slices.Contains(managed, key) {
+			continue
+		}
+		e, exists := externalByKey[key]
+		if _, owned := getExternalRRsetOwner(e); exists && owned && !isSynchronizedFromZoneFile(e) {
+			continue
+		}
+		desiredKeys[key] = true
+		if exists && isSynchronizedFromZoneFile(e) && ptr.Deref(e.TTL, 0) == ptr.Deref(d.TTL, 0) && slices.Equal(recordsContent(e), recordsContent(d)) {
+			continue
+		}
+		changes.Sets = append(changes.Sets, powerdns.RRset{
+			Name:       d.Name,
+			Type:       d.Type,
+			TTL:        d.TTL,
+			ChangeType: ptr.To(powerdns.ChangeTypeReplace),
+			Records:    d.Records,
+			Comments:   []powerdns.Comment{comment},
+		})
+	}
+	for _, e := range external {
+		key := ptr.Deref(e.Name, "") + "/" + string(ptr.Deref(e.Type, ""))
+		if isSynchronizedFromZoneFile(e) && !desiredKeys[key] && !slices.Contains(managed, key) {
+			changes.Sets = append(changes.Sets, powerdns.RRset{Name: e.Name, Type: e.Type, ChangeType: ptr.To(powerdns.ChangeTypeDelete)})
+		}
+	}
+	return changes
+}
+
+// renderZoneFile return the zone file of RRsets, one line per record, in the PowerDNS export format
+func renderZoneFile(rrsets []powerdns.RRset) string {
+	var sb strings.Builder
+	for _, rrset := range rrsets {
+		for _, content := range recordsContent(rrset) {
+			fmt.Fprintf(&sb, "%s\t%d\tIN\t%s\t%s\n", ptr.Deref(rrset.Name, ""), ptr.Deref(rrset.TTL, 0), ptr.Deref(rrset.Type, ""), content)
+		}
+	}
+	return sb.String()
+}
+
+// isZoneManagedRRset return True for the RRsets managed through the Zone/ClusterZone itself (SOA and apex NS)
+func isZoneManagedRRset(zoneName string, rrset powerdns.RRset) bool {
+	rrType := ptr.Deref(rrset.Type, "")
+	return rrType == powerdns.RRTypeSOA || (rrType == powerdns.RRTypeNS && ptr.Deref(rrset.Name, "") == makeCanonical(zoneName))
+}
+
+// computeRestoreChanges return the changes to apply on the current RRsets of a zone to get the desired ones.
+// RRsets managed through the Zone/ClusterZone itself are left untouched.
+func computeRestoreChanges(zoneName string, current, desired []powerdns.RRset) rrsetChanges {
+	changes := rrsetChanges{diff: []string{}}
+	currentByKey := map[string]powerdns.RRset{}
+	for _, r := range current {
+		if !isZoneManagedRRset(zoneName, r) {
+			currentByKey[ptr.Deref(r.Name, "")+"/"+string(ptr.Deref(r.Type, ""))] = r
+		}
+	}
+
+	for _, d := range desired {
+		if isZoneManagedRRset(zoneName, d) {
+			continue
+		}
+		key := ptr.Deref(d.Name, "") + "/" + string(ptr.Deref(d.Type, ""))
+		c, exists := currentByKey[key]
+		delete(currentByKey, key)
+		if exists && ptr.Deref(c.TTL, 0) == ptr.Deref(d.TTL, 0) && slices.Equal(recordsContent(c), recordsContent(d)) {
+			continue
+		}
+		changes.upserts = append(changes.upserts, d)
+		if exists {
+			changes.diff = append(changes.diff, rrsetDiffLines("-", c)...)
+		}
+		changes.diff = append(changes.diff, rrsetDiffLines("+", d)...)
+	}
+
+	// Remaining RRsets are absent from the zone file
+	keys := make([]string, 0, len(currentByKey))
+	for k := range currentByKey {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		changes.deletions = append(changes.deletions, currentByKey[k])
+		changes.diff = append(changes.diff, rrsetDiffLines("-", currentByKey[k])...)
+	}
+	return changes
+}
+
+// rrsetDiffLines return one zone file line per record of the RRset, prefixed by the operation
+func rrsetDiffLines(operation string, rrset powerdns.RRset) []string {
+	lines := []string{}
+	for _, content := range recordsContent(rrset) {
+		lines = append(lines, fmt.Sprintf("%s %s %d IN %s %s", operation, ptr.Deref(rrset.Name, ""), ptr.Deref(rrset.TTL, 0), ptr.Deref(rrset.Type, ""), content))
+	}
+	return lines
+}
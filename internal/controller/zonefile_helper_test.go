@@ -0,0 +1,238 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/joeig/go-powerdns/v3"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	var testCases = []struct {
+		description string
+		zoneFile    string
+		want        []string
+		e           bool
+	}{
+		{"Empty zone file", "", []string{}, false},
+		{"Comments and directives", "; exported zone\n$ORIGIN example.org.\n\ntest.example.org.\t300\tIN\tA\t1.1.1.1\n", []string{"+ test.example.org. 300 IN A 1.1.1.1"}, false},
+		{"Grouped records", "test.example.org.\t300\tIN\tA\t2.2.2.2\ntest.example.org.\t300\tIN\tA\t1.1.1.1\n", []string{"+ test.example.org. 300 IN A 1.1.1.1", "+ test.example.org. 300 IN A 2.2.2.2"}, false},
+		{"Spaces in content", "example.org.\t300\tIN\tTXT\t\"hello  world\"\n", []string{"+ example.org. 300 IN TXT \"hello  world\""}, false},
+		{"Invalid line", "test.example.org. A 1.1.1.1\n", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrsets, err := parseZoneFile(tc.zoneFile)
+			if (err != nil) != tc.e {
+				t.Errorf("got %v, want error %v", err, tc.e)
+			}
+			if err != nil {
+				return
+			}
+			lines := []string{}
+			for _, r := range rrsets {
+				lines = append(lines, rrsetDiffLines("+", r)...)
+			}
+			if !cmp.Equal(lines, tc.want) {
+				t.Errorf("got %v, want %v", lines, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeRestoreChanges(t *testing.T) {
+	var (
+		zoneName = "example.org"
+		soa      = powerdns.RRset{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeSOA), TTL: ptr.To(uint32(3600)), Records: toPdnsRecords([]string{"ns1.example.org. hostmaster.example.org. 1 10800 3600 604800 3600"})}
+		apexNS   = powerdns.RRset{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(1500)), Records: toPdnsRecords([]string{"ns1.example.org."})}
+		testA    = powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1"})}
+		testA2   = powerdns.RRset{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"2.2.2.2"})}
+		wwwA     = powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"3.3.3.3"})}
+	)
+
+	var testCases = []struct {
+		description string
+		current     []powerdns.RRset
+		desired     []powerdns.RRset
+		upserts     int
+		deletions   int
+		diff        []string
+	}{
+		{"Identical", []powerdns.RRset{soa, apexNS, testA}, []powerdns.RRset{testA}, 0, 0, []string{}},
+		{"Zone managed RRsets ignored", []powerdns.RRset{testA}, []powerdns.RRset{soa, apexNS, testA}, 0, 0, []string{}},
+		{"Added RRset", []powerdns.RRset{testA}, []powerdns.RRset{testA, wwwA}, 1, 0, []string{"+ www.example.org. 300 IN A 3.3.3.3"}},
+		{"Modified RRset", []powerdns.RRset{testA}, []powerdns.RRset{testA2}, 1, 0, []string{"- test.example.org. 300 IN A 1.1.1.1", "+ test.example.org. 300 IN A 2.2.2.2"}},
+		{"Removed RRset", []powerdns.RRset{testA, wwwA}, []powerdns.RRset{testA}, 0, 1, []string{"- www.example.org. 300 IN A 3.3.3.3"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			changes := computeRestoreChanges(zoneName, tc.current, tc.desired)
+			if !cmp.Equal(len(changes.upserts), tc.upserts) {
+				t.Errorf("got %v, want %v", len(changes.upserts), tc.upserts)
+			}
+			if !cmp.Equal(len(changes.deletions), tc.deletions) {
+				t.Errorf("got %v, want %v", len(changes.deletions), tc.deletions)
+			}
+			if !cmp.Equal(changes.diff, tc.diff) {
+				t.Errorf("got %v, want %v", changes.diff, tc.diff)
+			}
+		})
+	}
+}
+
+func TestGetRegeneratedRRsetName(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrset       powerdns.RRset
+		want        string
+	}{
+		{"Simple name", powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA)}, "www.example.org-a"},
+		{"Wildcard name", powerdns.RRset{Name: ptr.To("*.test.example.org."), Type: ptr.To(powerdns.RRTypeCNAME)}, "wildcard.test.example.org-cname"},
+		{"Service name", powerdns.RRset{Name: ptr.To("_sip._tcp.example.org."), Type: ptr.To(powerdns.RRTypeSRV)}, "sip.tcp.example.org-srv"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			name := getRegeneratedRRsetName(tc.rrset)
+			if !cmp.Equal(name, tc.want) {
+				t.Errorf("got %v, want %v", name, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderZoneFile(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rrsets      []powerdns.RRset
+		want        string
+	}{
+		{"No RRset", []powerdns.RRset{}, ""},
+		{"Sorted records", []powerdns.RRset{
+			{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(1500)), Records: toPdnsRecords([]string{"ns2.example.org.", "ns1.example.org."})},
+			{Name: ptr.To("test.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"1.1.1.1"})},
+		}, "example.org.\t1500\tIN\tNS\tns1.example.org.\nexample.org.\t1500\tIN\tNS\tns2.example.org.\ntest.example.org.\t300\tIN\tA\t1.1.1.1\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			zoneFile := renderZoneFile(tc.rrsets)
+			if !cmp.Equal(zoneFile, tc.want) {
+				t.Errorf("got %q, want %q", zoneFile, tc.want)
+			}
+			// A rendered zone file is parsed back to the same RRsets
+			rrsets, err := parseZoneFile(zoneFile)
+			if err != nil || len(rrsets) != len(tc.rrsets) {
+				t.Errorf("got %v (%v), want %d RRsets", rrsets, err, len(tc.rrsets))
+			}
+		})
+	}
+}
+
+func TestParseBINDZoneFile(t *testing.T) {
+	var testCases = []struct {
+		description string
+		zoneFile    string
+		want        []string
+		e           bool
+	}{
+		{"Empty zone file", "; no records\n", []string{}, false},
+		{"Relative names", "$TTL 1h\n@ IN MX 10 mail\nwww IN CNAME @\nmail IN A 192.0.2.1\n", []string{
+			"+ example.org. 3600 IN MX 10 mail.example.org.",
+			"+ mail.example.org. 3600 IN A 192.0.2.1",
+			"+ www.example.org. 3600 IN CNAME example.org.",
+		}, false},
+		{"Omitted owner and TTL", "www 300 A 192.0.2.1\n    IN A 192.0.2.2 ; second address\nftp A 192.0.2.3\n", []string{
+			"+ ftp.example.org. 300 IN A 192.0.2.3",
+			"+ www.example.org. 300 IN A 192.0.2.1",
+			"+ www.example.org. 300 IN A 192.0.2.2",
+		}, false},
+		{"Origin and absolute names", "$ORIGIN lab.example.org.\nhost 60 IN AAAA 2001:db8::1\nWWW.example.org. 60 IN A 192.0.2.1\n", []string{
+			"+ host.lab.example.org. 60 IN AAAA 2001:db8::1",
+			"+ www.example.org. 60 IN A 192.0.2.1",
+		}, false},
+		{"Parentheses and quoted strings", "@ 3600 IN SOA ns1 hostmaster (\n  1 ; serial\n  10800 3600 604800 3600 )\n@ 300 IN TXT \"v=spf1 -all\" \"a;b\"\n", []string{
+			"+ example.org. 3600 IN SOA ns1 hostmaster 1 10800 3600 604800 3600",
+			"+ example.org. 300 IN TXT \"v=spf1 -all\" \"a;b\"",
+		}, false},
+		{"Name out of the zone", "www.example.com. 300 IN A 192.0.2.1\n", nil, true},
+		{"Missing owner", "  300 IN A 192.0.2.1\n", nil, true},
+		{"Unbalanced parentheses", "@ 3600 IN SOA ns1 hostmaster ( 1 10800\n", nil, true},
+		{"Unsupported directive", "$INCLUDE other.zone\n", nil, true},
+		{"Missing data", "www 300 IN A\n", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			rrsets, err := parseBINDZoneFile("example.org", tc.zoneFile)
+			if (err != nil) != tc.e {
+				t.Errorf("got %v, want error %v", err, tc.e)
+			}
+			if err != nil {
+				return
+			}
+			lines := []string{}
+			for _, r := range rrsets {
+				lines = append(lines, rrsetDiffLines("+", r)...)
+			}
+			if diff := cmp.Diff(tc.want, lines); diff != "" {
+				t.Errorf("unexpected RRsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetZoneFileChanges(t *testing.T) {
+	zoneFileComment := powerdns.Comment{Content: ptr.To(ZONE_FILE_COMMENT), Account: ptr.To(PDNS_COMMENT_ACCOUNT)}
+	var (
+		apexNS   = powerdns.RRset{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeNS), TTL: ptr.To(uint32(1500)), Records: toPdnsRecords([]string{"ns1.example.org."})}
+		wwwA     = powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"192.0.2.1"})}
+		wwwA2    = powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"192.0.2.2"})}
+		syncedA  = powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"192.0.2.1"}), Comments: []powerdns.Comment{zoneFileComment}}
+		ownedA   = powerdns.RRset{Name: ptr.To("www.example.org."), Type: ptr.To(powerdns.RRTypeA), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"192.0.2.2"}), Comments: []powerdns.Comment{{Content: ptr.To(OWNER_COMMENT), Account: ptr.To(getOwnerAccount("other"))}}}
+		syncedMX = powerdns.RRset{Name: ptr.To("example.org."), Type: ptr.To(powerdns.RRTypeMX), TTL: ptr.To(uint32(300)), Records: toPdnsRecords([]string{"10 mail.example.org."}), Comments: []powerdns.Comment{zoneFileComment}}
+	)
+
+	var testCases = []struct {
+		description string
+		external    []powerdns.RRset
+		desired     []powerdns.RRset
+		managed     []string
+		want        []string
+	}{
+		{"Synchronized", []powerdns.RRset{apexNS, syncedA}, []powerdns.RRset{wwwA}, nil, []string{}},
+		{"Added RRset", []powerdns.RRset{apexNS}, []powerdns.RRset{apexNS, wwwA}, nil, []string{"REPLACE www.example.org./A"}},
+		{"Modified RRset", []powerdns.RRset{syncedA}, []powerdns.RRset{wwwA2}, nil, []string{"REPLACE www.example.org./A"}},
+		{"Adopted RRset", []powerdns.RRset{wwwA}, []powerdns.RRset{wwwA}, nil, []string{"REPLACE www.example.org./A"}},
+		{"Removed RRset", []powerdns.RRset{syncedA, syncedMX}, []powerdns.RRset{wwwA}, nil, []string{"DELETE example.org./MX"}},
+		{"RRset managed by a resource", []powerdns.RRset{syncedMX}, []powerdns.RRset{wwwA}, []string{"www.example.org./A", "example.org./MX"}, []string{}},
+		{"RRset owned by an operator instance", []powerdns.RRset{ownedA}, []powerdns.RRset{wwwA}, nil, []string{}},
+		{"Unmanaged RRset kept", []powerdns.RRset{wwwA}, []powerdns.RRset{}, nil, []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := []string{}
+			for _, r := range getZoneFileChanges("example.org", tc.external, tc.desired, tc.managed).Sets {
+				got = append(got, string(ptr.Deref(r.ChangeType, ""))+" "+ptr.Deref(r.Name, "")+"/"+string(ptr.Deref(r.Type, "")))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
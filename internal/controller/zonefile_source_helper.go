@@ -0,0 +1,61 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	ZoneReasonZoneFileUnavailable = "ZoneFileUnavailable"
+)
+
+// getZoneFileSourceNamespace return the namespace of the ConfigMap holding the zone file of the zone
+func getZoneFileSourceNamespace(zone dnsv1alpha2.GenericZone, source *dnsv1alpha2.ZoneFileSource) string {
+	if zone.GetNamespace() != "" {
+		return zone.GetNamespace()
+	}
+	return ptr.Deref(source.ConfigMap.Namespace, "")
+}
+
+// getZoneFileSourceIndexKeys return the key the zone is indexed with, the ConfigMap holding its zone file, if any
+func getZoneFileSourceIndexKeys(zone dnsv1alpha2.GenericZone) []string {
+	source := zone.GetSpec().ZoneFileFrom
+	if source == nil {
+		return []string{}
+	}
+	return []string{getZoneFileSourceNamespace(zone, source) + "/" + source.ConfigMap.Name}
+}
+
+// getZoneFile return the zone file of the zone, inline or read from its ConfigMap, nil if the zone has none
+func getZoneFile(ctx context.Context, zone dnsv1alpha2.GenericZone, cl client.Reader) (*string, error) {
+	source := zone.GetSpec().ZoneFileFrom
+	if source == nil {
+		return zone.GetSpec().ZoneFile, nil
+	}
+	namespace := getZoneFileSourceNamespace(zone, source)
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace of the ConfigMap %s is required", source.ConfigMap.Name)
+	}
+	zoneFile, err := readZoneFileFromObject(ctx, cl, &corev1.ConfigMap{}, namespace, source.ConfigMap.Name, ptr.Deref(source.ConfigMap.Key, getZoneFileKey(zone.GetName())))
+	if err != nil {
+		return nil, err
+	}
+	return &zoneFile, nil
+}
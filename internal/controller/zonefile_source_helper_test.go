@@ -0,0 +1,43 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestGetZoneFileSourceIndexKeys(t *testing.T) {
+	source := &dnsv1alpha2.ZoneFileSource{ConfigMap: dnsv1alpha2.ZoneFileConfigMapSource{Name: "records", Namespace: ptr.To("dns")}}
+	var testCases = []struct {
+		description string
+		zone        dnsv1alpha2.GenericZone
+		want        []string
+	}{
+		{"Zone in its namespace", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "team-a"}, Spec: dnsv1alpha2.ZoneSpec{ZoneFileFrom: source}}, []string{"team-a/records"}},
+		{"ClusterZone", &dnsv1alpha2.ClusterZone{ObjectMeta: metav1.ObjectMeta{Name: "example.org"}, Spec: dnsv1alpha2.ZoneSpec{ZoneFileFrom: source}}, []string{"dns/records"}},
+		{"Inline zone file", &dnsv1alpha2.Zone{ObjectMeta: metav1.ObjectMeta{Name: "example.org", Namespace: "team-a"}, Spec: dnsv1alpha2.ZoneSpec{ZoneFile: ptr.To("www A 192.0.2.1")}}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, getZoneFileSourceIndexKeys(tc.zone)); diff != "" {
+				t.Errorf("unexpected index keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
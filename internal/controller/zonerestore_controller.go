@@ -0,0 +1,416 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/joeig/go-powerdns/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const (
+	RESTORE_DIFF_MAX_LINES = 100
+
+	RestoreReasonZoneNotAvailable     = "ZoneNotAvailable"
+	RestoreReasonSourceNotAvailable   = "SourceNotAvailable"
+	RestoreReasonNamespaceNotAllowed  = "NamespaceNotAllowed"
+	RestoreReasonRestoreFailed        = "RestoreFailed"
+	RestoreReasonDryRun               = "DryRun"
+	RestoreReasonRestoreSucceeded     = "RestoreSucceeded"
+	RestoreMessageDryRun              = "Changes computed, not applied"
+	RestoreMessageRestoreSucceeded    = "Zone file restored in PowerDNS instance"
+	RestoreMessageNoBackup            = "no successful backup for ZoneBackup:"
+	RestoreMessageNamespaceNotAllowed = "Namespace of the ZoneRestore not allowed by the zone:"
+)
+
+// ZoneRestoreReconciler reconciles a ZoneRestore object
+type ZoneRestoreReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	PDNSClient PdnsClienter
+	// NewObjectStorageClient create the clients of the S3 targets, defaults to NewMinioObjectStorageClient
+	NewObjectStorageClient ObjectStorageClientFactory
+	// ShutdownGracePeriod is the time left to in-flight reconciliations to complete on shutdown
+	ShutdownGracePeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonerestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonerestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonerestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dns.cav.enablers.ob,resources=zonebackups,verbs=get;list;watch
+
+func (r *ZoneRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconcile ZoneRestore", "ZoneRestore.Name", req.Name)
+
+	// ZoneRestore
+	restore := &dnsv1alpha2.ZoneRestore{}
+	err := r.Get(ctx, req.NamespacedName, restore)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A restore is done once per generation, a failed one is retried on modification only
+	isModified := restore.Status.ObservedGeneration == nil || *restore.Status.ObservedGeneration != restore.GetGeneration()
+	isPending := restore.Status.SyncStatus != nil && *restore.Status.SyncStatus == PENDING_STATUS && !ptr.Deref(restore.Spec.DryRun, false)
+	if !restore.DeletionTimestamp.IsZero() || (!isModified && !isPending) {
+		return ctrl.Result{}, nil
+	}
+
+	// Zone
+	var zone dnsv1alpha2.GenericZone
+	switch restore.Spec.ZoneRef.Kind {
+	//nolint:goconst
+	case "Zone":
+		zone = &dnsv1alpha2.Zone{}
+	//nolint:goconst
+	case "ClusterZone":
+		zone = &dnsv1alpha2.ClusterZone{}
+	}
+	err = r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Spec.ZoneRef.Name}, zone)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if err := patchZoneRestoreStatus(ctx, restore, nil, nil, PENDING_STATUS, r.Client, metav1.Condition{
+				Type:    "Available",
+				Status:  metav1.ConditionFalse,
+				Reason:  RestoreReasonZoneNotAvailable,
+				Message: RrsetMessageNonExistentZone + err.Error(),
+			}); err != nil {
+				log.Error(err, "unable to patch ZoneRestore status")
+				return ctrl.Result{}, err
+			}
+			// The Zone may be created later on
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		log.Error(err, "Failed to get zone")
+		return ctrl.Result{}, err
+	}
+	if zone.GetStatus().SyncStatus == nil || *zone.GetStatus().SyncStatus != SUCCEEDED_STATUS {
+		if err := patchZoneRestoreStatus(ctx, restore, nil, nil, PENDING_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  RestoreReasonZoneNotAvailable,
+			Message: RrsetMessageUnavailableZone + zone.GetName(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneRestore status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+	if allowed, err := restoreNamespaceReconcile(ctx, restore, zone, r.Client, log); !allowed {
+		return ctrl.Result{}, err
+	}
+
+	// Zone file to restore
+	zoneFile, err := r.getZoneFile(ctx, restore, zone, log)
+	if err != nil {
+		if err := patchZoneRestoreStatus(ctx, restore, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  RestoreReasonSourceNotAvailable,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneRestore status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	changes, err := getZoneRestoreChanges(ctx, zone, zoneFile, r.Client, r.PDNSClient, log)
+	if err != nil {
+		if err := patchZoneRestoreStatus(ctx, restore, nil, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  RestoreReasonRestoreFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneRestore status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if ptr.Deref(restore.Spec.DryRun, false) {
+		if err := patchZoneRestoreStatus(ctx, restore, &changes, nil, PENDING_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  RestoreReasonDryRun,
+			Message: RestoreMessageDryRun,
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneRestore status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	err = applyZoneRestoreChanges(ctx, zone, changes, r.PDNSClient, log)
+	if err == nil && ptr.Deref(restore.Spec.RegenerateRRsets, false) {
+		err = regenerateRRsets(ctx, restore.Namespace, dnsv1alpha2.ZoneRef{Name: zone.GetName(), Kind: restore.Spec.ZoneRef.Kind}, changes.upserts, r.Client, log)
+	}
+	if err != nil {
+		if err := patchZoneRestoreStatus(ctx, restore, &changes, nil, FAILED_STATUS, r.Client, metav1.Condition{
+			Type:    "Available",
+			Status:  metav1.ConditionFalse,
+			Reason:  RestoreReasonRestoreFailed,
+			Message: err.Error(),
+		}); err != nil {
+			log.Error(err, "unable to patch ZoneRestore status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	now := metav1.NewTime(time.Now().UTC())
+	if err := patchZoneRestoreStatus(ctx, restore, &changes, &now, SUCCEEDED_STATUS, r.Client, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionTrue,
+		Reason:  RestoreReasonRestoreSucceeded,
+		Message: RestoreMessageRestoreSucceeded,
+	}); err != nil {
+		log.Error(err, "unable to patch ZoneRestore status")
+		return ctrl.Result{}, err
+	}
+	log.Info("Zone restored", "Zone.Name", zone.GetName(), "changes", len(changes.diff))
+
+	return ctrl.Result{}, nil
+}
+
+// restoreNamespaceReconcile return True if the namespace of the ZoneRestore is allowed by the zone, the ZoneRestore
+// is failed otherwise
+func restoreNamespaceReconcile(ctx context.Context, restore *dnsv1alpha2.ZoneRestore, zone dnsv1alpha2.GenericZone, cl client.Client, log logr.Logger) (bool, error) {
+	allowed, err := isNamespaceAllowed(ctx, restore.Namespace, zone, cl)
+	if err != nil {
+		log.Error(err, "Failed to get namespace")
+		return false, err
+	}
+	if allowed {
+		return true, nil
+	}
+	if err := patchZoneRestoreStatus(ctx, restore, nil, nil, FAILED_STATUS, cl, metav1.Condition{
+		Type:    "Available",
+		Status:  metav1.ConditionFalse,
+		Reason:  RestoreReasonNamespaceNotAllowed,
+		Message: RestoreMessageNamespaceNotAllowed + " " + zone.GetName(),
+	}); err != nil {
+		log.Error(err, "unable to patch ZoneRestore status")
+		return false, err
+	}
+	return false, nil
+}
+
+// getZoneFile return the zone file to restore, read from the source of the restore
+func (r *ZoneRestoreReconciler) getZoneFile(ctx context.Context, restore *dnsv1alpha2.ZoneRestore, zone dnsv1alpha2.GenericZone, log logr.Logger) (string, error) {
+	source := restore.Spec.Source
+	switch {
+	case source.ConfigMap != nil:
+		return readZoneFileFromObject(ctx, r.Client, &corev1.ConfigMap{}, restore.Namespace, source.ConfigMap.Name, ptr.Deref(source.ConfigMap.Key, getZoneFileKey(zone.GetName())))
+	case source.Secret != nil:
+		return readZoneFileFromObject(ctx, r.Client, &corev1.Secret{}, restore.Namespace, source.Secret.Name, ptr.Deref(source.Secret.Key, getZoneFileKey(zone.GetName())))
+	}
+
+	backup := &dnsv1alpha2.ZoneBackup{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: restore.Namespace, Name: source.ZoneBackupRef.Name}, backup); err != nil {
+		log.Error(err, "Failed to get ZoneBackup")
+		return "", err
+	}
+	location := ptr.Deref(backup.Status.Location, "")
+	key := getZoneFileKey(backup.Spec.ZoneRef.Name)
+	if name, ok := strings.CutPrefix(location, "configmap/"); ok {
+		return readZoneFileFromObject(ctx, r.Client, &corev1.ConfigMap{}, restore.Namespace, name, key)
+	}
+	if name, ok := strings.CutPrefix(location, "secret/"); ok {
+		return readZoneFileFromObject(ctx, r.Client, &corev1.Secret{}, restore.Namespace, name, key)
+	}
+	if object, ok := strings.CutPrefix(location, "s3://"); ok && backup.Spec.Target.S3 != nil {
+		target := *backup.Spec.Target.S3
+		osClient, err := newObjectStorageClientForTarget(ctx, r.Client, restore.Namespace, target, r.NewObjectStorageClient)
+		if err != nil {
+			log.Error(err, "Failed to create object storage client")
+			return "", err
+		}
+		content, err := osClient.Get(ctx, target.Bucket, strings.TrimPrefix(object, target.Bucket+"/"))
+		return string(content), err
+	}
+	return "", fmt.Errorf("%s %s", RestoreMessageNoBackup, backup.Name)
+}
+
+// readZoneFileFromObject return the zone file stored under the key of a ConfigMap or a Secret
+func readZoneFileFromObject(ctx context.Context, cl client.Reader, obj client.Object, namespace, name, key string) (string, error) {
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		return "", err
+	}
+	var content string
+	var found bool
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		content, found = o.Data[key]
+	case *corev1.Secret:
+		var data []byte
+		data, found = o.Data[key]
+		content = string(data)
+	}
+	if !found {
+		return "", fmt.Errorf("key %s not found in %s", key, name)
+	}
+	return content, nil
+}
+
+// getZoneRestoreChanges return the changes to apply on the zone to restore the zone file.
+// The RRsets managed by a RRset/ClusterRRset are left to their resource, they are neither replaced nor deleted.
+func getZoneRestoreChanges(ctx context.Context, zone dnsv1alpha2.GenericZone, zoneFile string, cl client.Reader, PDNSClient PdnsClienter, log logr.Logger) (rrsetChanges, error) {
+	desired, err := parseZoneFile(zoneFile)
+	if err != nil {
+		return rrsetChanges{}, err
+	}
+	currentZoneFile, err := exportZoneExternalResources(ctx, zone, PDNSClient, log)
+	if err != nil {
+		return rrsetChanges{}, err
+	}
+	current, err := parseZoneFile(currentZoneFile)
+	if err != nil {
+		return rrsetChanges{}, err
+	}
+	if current, err = getUnmanagedRRsets(ctx, current, cl); err != nil {
+		return rrsetChanges{}, err
+	}
+	if desired, err = getUnmanagedRRsets(ctx, desired, cl); err != nil {
+		return rrsetChanges{}, err
+	}
+	return computeRestoreChanges(zone.GetName(), current, desired), nil
+}
+
+// getUnmanagedRRsets return the RRsets which are not managed by a RRset/ClusterRRset
+func getUnmanagedRRsets(ctx context.Context, rrsets []powerdns.RRset, cl client.Reader) ([]powerdns.RRset, error) {
+	unmanaged := make([]powerdns.RRset, 0, len(rrsets))
+	for _, r := range rrsets {
+		managed, err := isResourceManagedRRset(ctx, r, cl)
+		if err != nil {
+			return nil, err
+		}
+		if !managed {
+			unmanaged = append(unmanaged, r)
+		}
+	}
+	return unmanaged, nil
+}
+
+// isResourceManagedRRset return True if the RRset is managed by a RRset/ClusterRRset
+func isResourceManagedRRset(ctx context.Context, rrset powerdns.RRset, cl client.Reader) (bool, error) {
+	rrsets, clusterRRsets, err := listRRsetEntryOwners(ctx, getEntryIndexKey(ptr.Deref(rrset.Name, ""), string(ptr.Deref(rrset.Type, ""))), cl)
+	if err != nil {
+		return false, err
+	}
+	return len(rrsets) > 0 || len(clusterRRsets) > 0, nil
+}
+
+// applyZoneRestoreChanges replays the changes in PowerDNS
+func applyZoneRestoreChanges(ctx context.Context, zone dnsv1alpha2.GenericZone, changes rrsetChanges, PDNSClient PdnsClienter, log logr.Logger) error {
+	for _, r := range changes.upserts {
+		if err := PDNSClient.Records.Change(ctx, zone.GetName(), *r.Name, *r.Type, ptr.Deref(r.TTL, 0), recordsContent(r)); err != nil {
+			log.Error(err, "Failed to restore RRset", "name", *r.Name, "type", *r.Type)
+			return err
+		}
+	}
+	for _, r := range changes.deletions {
+		if err := PDNSClient.Records.Delete(ctx, zone.GetName(), *r.Name, *r.Type); err != nil {
+			log.Error(err, "Failed to delete RRset", "name", *r.Name, "type", *r.Type)
+			return err
+		}
+	}
+	return nil
+}
+
+// regenerateRRsets creates the RRset resources, in the namespace (ClusterRRset resources without namespace),
+// of the records which are not managed by a RRset/ClusterRRset yet
+func regenerateRRsets(ctx context.Context, namespace string, zoneRef dnsv1alpha2.ZoneRef, rrsets []powerdns.RRset, cl client.Client, log logr.Logger) error {
+	for _, r := range rrsets {
+		managed, err := isResourceManagedRRset(ctx, r, cl)
+		if err != nil {
+			return err
+		}
+		if managed {
+			continue
+		}
+
+		var rrset dnsv1alpha2.GenericRRset = &dnsv1alpha2.RRset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getRegeneratedRRsetName(r),
+				Namespace: namespace,
+			},
+		}
+		if namespace == "" {
+			rrset = &dnsv1alpha2.ClusterRRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: getRegeneratedRRsetName(r),
+				},
+			}
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, cl, rrset, func() error {
+			*rrset.GetSpec() = rrsetSpecFromExternal(r, zoneRef)
+			return nil
+		}); err != nil {
+			log.Error(err, "Failed to regenerate RRset", "name", *r.Name, "type", *r.Type)
+			return err
+		}
+	}
+	return nil
+}
+
+// getRegeneratedRRsetName return the name of the RRset resource regenerated for a restored RRset (e.g. "www.example.org-a")
+func getRegeneratedRRsetName(rrset powerdns.RRset) string {
+	name := strings.ReplaceAll(strings.TrimSuffix(ptr.Deref(rrset.Name, ""), "."), "*", "wildcard")
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "")
+	return strings.Trim(name, ".-") + "-" + strings.ToLower(string(ptr.Deref(rrset.Type, "")))
+}
+
+func patchZoneRestoreStatus(ctx context.Context, restore *dnsv1alpha2.ZoneRestore, changes *rrsetChanges, restoreTime *metav1.Time, status string, cl client.Client, condition metav1.Condition) error {
+	original := restore.DeepCopy()
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().UTC())
+	meta.SetStatusCondition(&restore.Status.Conditions, condition)
+	if changes != nil {
+		restore.Status.Changes = ptr.To(int32(len(changes.diff)))
+		restore.Status.Diff = changes.diff[:min(len(changes.diff), RESTORE_DIFF_MAX_LINES)]
+	}
+	if restoreTime != nil {
+		restore.Status.RestoreTime = restoreTime
+	}
+	restore.Status.SyncStatus = ptr.To(status)
+	restore.Status.ObservedGeneration = ptr.To(restore.GetGeneration())
+	return cl.Status().Patch(ctx, restore, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ZoneRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dnsv1alpha2.ZoneRestore{}).
+		Watches(&dnsv1alpha2.ZoneRestore{}, enqueueDeletionsFirst()).
+		Complete(drainOnShutdown(r, r.ShutdownGracePeriod))
+}
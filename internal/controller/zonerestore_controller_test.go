@@ -0,0 +1,224 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+//nolint:goconst
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+var _ = Describe("ZoneRestore Controller", func() {
+
+	const (
+		zoneName          = "example9.org"
+		resourceName      = "example9-restore"
+		resourceNamespace = "example9"
+		sourceName        = "example9-zonefile"
+
+		timeout  = time.Second * 5
+		interval = time.Millisecond * 250
+	)
+	zoneNameservers := []string{"ns1.example9.org", "ns2.example9.org"}
+	zoneFile := "example9.org.\t3600\tIN\tSOA\tns1.example9.org. hostmaster.example9.org. 1 10800 3600 604800 3600\n" +
+		"restored.example9.org.\t300\tIN\tA\t9.9.9.9\n"
+
+	typeNamespacedName := types.NamespacedName{
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		By("creating the Zone resource")
+		zone := &dnsv1alpha2.Zone{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      zoneName,
+				Namespace: resourceNamespace,
+			},
+		}
+		zone.SetResourceVersion("")
+		_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, zone, func() error {
+			zone.Spec = dnsv1alpha2.ZoneSpec{
+				Kind:        NATIVE_KIND_ZONE,
+				Nameservers: zoneNameservers,
+			}
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return err == nil && zone.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+		}, timeout, interval).Should(BeTrue())
+
+		By("creating the source ConfigMap")
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sourceName,
+				Namespace: resourceNamespace,
+			},
+			Data: map[string]string{getZoneFileKey(zoneName): zoneFile},
+		}
+		Expect(k8sClient.Create(ctx, cm)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctx := context.Background()
+		By("Cleanup the specific resource instance ZoneRestore")
+		restore := &dnsv1alpha2.ZoneRestore{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, restore); err == nil {
+			Expect(k8sClient.Delete(ctx, restore)).To(Succeed())
+		}
+		Expect(k8sClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: sourceName, Namespace: resourceNamespace}})).To(Succeed())
+
+		By("Cleanup the specific resource instance Zone")
+		zone := &dnsv1alpha2.Zone{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, zone)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: zoneName, Namespace: resourceNamespace}, zone)
+			return errors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+		deleteFromRecordsMap(makeCanonical("restored." + zoneName))
+	})
+
+	Context("When creating a ZoneRestore in dry-run mode", func() {
+		It("should report the diff, then apply it when dry-run is disabled", Label("zonerestore-creation", "dry-run"), func() {
+			ctx := context.Background()
+			By("Creating the ZoneRestore")
+			restore := &dnsv1alpha2.ZoneRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneRestoreSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.RestoreSource{ConfigMap: &dnsv1alpha2.RestoreObjectSource{Name: sourceName}},
+					DryRun:  ptr.To(true),
+				},
+			}
+			Expect(k8sClient.Create(ctx, restore)).To(Succeed())
+
+			By("Getting the computed diff")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, restore)
+				return err == nil && restore.IsInExpectedStatus(FIRST_GENERATION, PENDING_STATUS) && restore.Status.Changes != nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(restore.Status.Diff).To(ContainElement("+ restored.example9.org. 300 IN A 9.9.9.9"), "Diff should contain the restored record")
+			_, found := readFromRecordsMap(makeCanonical("restored." + zoneName))
+			Expect(found).To(BeFalse(), "Record should not be restored in dry-run mode")
+
+			By("Disabling the dry-run mode")
+			_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, restore, func() error {
+				restore.Spec.DryRun = ptr.To(false)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, restore)
+				return err == nil && restore.IsInExpectedStatus(MODIFIED_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(restore.Status.RestoreTime).NotTo(BeNil(), "Restore time should be set")
+			_, found = readFromRecordsMap(makeCanonical("restored." + zoneName))
+			Expect(found).To(BeTrue(), "Record should be restored")
+		})
+	})
+
+	Context("When creating a ZoneRestore of a zone with a RRset resource", func() {
+		It("should leave the records of the RRset untouched", Label("zonerestore-creation", "managed-rrset"), func() {
+			ctx := context.Background()
+			By("Creating the RRset")
+			rrset := &dnsv1alpha2.RRset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "managed.example9.org",
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.RRsetSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Type:    "A",
+					Name:    "managed",
+					TTL:     ptr.To(uint32(300)),
+					Records: []string{"8.8.8.8"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rrset)).To(Succeed())
+			Eventually(func() bool {
+				_, found := readFromRecordsMap(makeCanonical("managed." + zoneName))
+				return found
+			}, timeout, interval).Should(BeTrue())
+
+			By("Creating the ZoneRestore")
+			restore := &dnsv1alpha2.ZoneRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneRestoreSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.RestoreSource{ConfigMap: &dnsv1alpha2.RestoreObjectSource{Name: sourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, restore)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, restore)
+				return err == nil && restore.IsInExpectedStatus(FIRST_GENERATION, SUCCEEDED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+			Expect(restore.Status.Diff).NotTo(ContainElement("- managed.example9.org. 300 IN A 8.8.8.8"), "Diff should not contain the managed record")
+			_, found := readFromRecordsMap(makeCanonical("managed." + zoneName))
+			Expect(found).To(BeTrue(), "Managed record should not be deleted")
+
+			By("Cleanup the RRset")
+			Expect(k8sClient.Delete(ctx, rrset)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(rrset), rrset)
+				return errors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+
+	Context("When creating a ZoneRestore with a missing source", func() {
+		It("should reconcile the resource with Failed status", Label("zonerestore-creation", "missing-source"), func() {
+			ctx := context.Background()
+			By("Creating the ZoneRestore")
+			restore := &dnsv1alpha2.ZoneRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: resourceNamespace,
+				},
+				Spec: dnsv1alpha2.ZoneRestoreSpec{
+					ZoneRef: dnsv1alpha2.ZoneRef{Name: zoneName, Kind: "Zone"},
+					Source:  dnsv1alpha2.RestoreSource{ConfigMap: &dnsv1alpha2.RestoreObjectSource{Name: "nonexistent"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, restore)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, typeNamespacedName, restore)
+				return err == nil && restore.IsInExpectedStatus(FIRST_GENERATION, FAILED_STATUS)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+})
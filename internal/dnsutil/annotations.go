@@ -0,0 +1,20 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package dnsutil
+
+const (
+	// APPROVED_ANNOTATION approves the changes of a generation of a RRset referencing a zone requiring approval
+	APPROVED_ANNOTATION = "dns.cav.enablers.ob/approved"
+	// APEX_OVERRIDE_ANNOTATION allows a RRset to overwrite the SOA or NS records of the zone apex
+	APEX_OVERRIDE_ANNOTATION       = "dns.cav.enablers.ob/allow-apex-override"
+	APEX_OVERRIDE_ANNOTATION_VALUE = "true"
+)
@@ -0,0 +1,49 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package dnsutil
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+func TestIsNamespaceAllowed(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"dns": "public"}}}
+	var testCases = []struct {
+		description string
+		allowed     *dnsv1alpha2.AllowedNamespaces
+		want        bool
+	}{
+		{"No restriction", nil, true},
+		{"Listed", &dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b", "team-a"}}, true},
+		{"Not listed", &dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b"}}, false},
+		{"Matching selector", &dnsv1alpha2.AllowedNamespaces{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"dns": "public"}}}, true},
+		{"Not matching selector", &dnsv1alpha2.AllowedNamespaces{Names: []string{"team-b"}, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"dns": "private"}}}, false},
+		{"No namespace", &dnsv1alpha2.AllowedNamespaces{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := IsNamespaceAllowed(tc.allowed, namespace)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+// Package dnsutil holds the DNS helpers shared by the controllers and the webhooks
+package dnsutil
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// ttlDurationRegexp matches a TTL in the BIND format, e.g. "1h30m"
+var ttlDurationRegexp = regexp.MustCompile(`^([0-9]+)([smhdw])`)
+
+// ttlUnits are the number of seconds of each BIND TTL unit
+var ttlUnits = map[string]uint64{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+}
+
+// ParseTTL return the number of seconds of a TTL expressed in seconds ("300") or
+// as a duration in the BIND format, a sequence of numbers followed by a unit (s, m, h, d or w), e.g. "1h30m"
+func ParseTTL(value string) (uint32, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 31); err == nil {
+		return uint32(seconds), nil
+	}
+	if value == "" {
+		return 0, fmt.Errorf("empty TTL")
+	}
+	var total uint64
+	for remaining := value; remaining != ""; {
+		match := ttlDurationRegexp.FindStringSubmatch(remaining)
+		if match == nil {
+			return 0, fmt.Errorf("invalid TTL %q, expected seconds or a duration like \"1h30m\"", value)
+		}
+		count, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q: %w", value, err)
+		}
+		total += count * ttlUnits[match[2]]
+		if total > math.MaxInt32 {
+			return 0, fmt.Errorf("invalid TTL %q, exceeding %d seconds", value, math.MaxInt32)
+		}
+		remaining = remaining[len(match[0]):]
+	}
+	return uint32(total), nil
+}
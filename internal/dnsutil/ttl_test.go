@@ -0,0 +1,49 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package dnsutil
+
+import (
+	"testing"
+)
+
+func TestParseTTL(t *testing.T) {
+	var testCases = []struct {
+		description string
+		value       string
+		want        uint32
+		wantErr     bool
+	}{
+		{"Seconds", "300", 300, false},
+		{"Minutes", "5m", 300, false},
+		{"Hours", "1h", 3600, false},
+		{"Days", "1d", 86400, false},
+		{"Weeks", "1w", 604800, false},
+		{"Combined units", "1h30m", 5400, false},
+		{"Empty", "", 0, true},
+		{"Unknown unit", "5y", 0, true},
+		{"Missing unit", "1h30", 0, true},
+		{"Go duration", "1.5h", 0, true},
+		{"Overflow", "100000w", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := ParseTTL(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+	"github.com/powerdns-operator/powerdns-operator/internal/dnsutil"
+)
+
+const (
+	RRSET_DEFAULTING_WEBHOOK_PATH = "/mutate-dns-cav-enablers-ob-v1alpha2-rrset"
+	RRSET_VALIDATING_WEBHOOK_PATH = "/validate-dns-cav-enablers-ob-v1alpha2-rrset"
+
+	// APPROVED_ANNOTATION approves the changes of a generation of a RRset referencing a zone requiring approval
+	APPROVED_ANNOTATION = dnsutil.APPROVED_ANNOTATION
+	// APPROVE_VERB is the verb on the rrsets, or clusterrrsets, resource granted to the approvers
+	APPROVE_VERB = "approve"
+	// APEX_OVERRIDE_ANNOTATION allows a RRset to overwrite the SOA or NS records of the zone apex
+	APEX_OVERRIDE_ANNOTATION       = dnsutil.APEX_OVERRIDE_ANNOTATION
+	APEX_OVERRIDE_ANNOTATION_VALUE = dnsutil.APEX_OVERRIDE_ANNOTATION_VALUE
+)
+
+// +kubebuilder:webhook:path=/mutate-dns-cav-enablers-ob-v1alpha2-rrset,mutating=true,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=rrsets;clusterrrsets,verbs=create;update,versions=v1alpha2,name=mrrset-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-rrset,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=rrsets;clusterrrsets,verbs=create;update,versions=v1alpha2,name=vrrset-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// SetupRRsetWebhookWithManager registers the defaulting and the validating webhooks of RRsets and ClusterRRsets
+// in the manager
+func SetupRRsetWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(RRSET_DEFAULTING_WEBHOOK_PATH, &webhook.Admission{Handler: &RRsetDefaulter{}})
+	mgr.GetWebhookServer().Register(RRSET_VALIDATING_WEBHOOK_PATH, &webhook.Admission{Handler: &RRsetValidator{Client: mgr.GetClient()}})
+	return nil
+}
+
+// RRsetDefaulter converts the TTL of RRsets and ClusterRRsets expressed as a duration into seconds.
+// The resources are handled as raw JSON, as a duration cannot be decoded into the TTL field.
+type RRsetDefaulter struct{}
+
+func (d *RRsetDefaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	var obj map[string]any
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	spec, ok := obj["spec"].(map[string]any)
+	if !ok {
+		return admission.Allowed("")
+	}
+	duration, ok := spec["ttl"].(string)
+	if !ok {
+		return admission.Allowed("")
+	}
+	ttl, err := dnsutil.ParseTTL(duration)
+	if err != nil {
+		return admission.Denied(fmt.Sprintf("spec.ttl: %v", err))
+	}
+	spec["ttl"] = ttl
+	patched, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, patched)
+}
+
+// RRsetValidator rejects the approvals of the users not granted the approve verb on the RRsets, or ClusterRRsets,
+// the RRsets overwriting the SOA or NS records of the zone apex without the override annotation,
+// and the RRsets of the namespaces not allowed by the ClusterZone they reference.
+// A missing ClusterZone is not rejected: the RRset is pending until the zone is created.
+type RRsetValidator struct {
+	Client client.Client
+}
+
+// validatedRRset is the part of the RRsets and ClusterRRsets decoded by the validating webhook,
+// the TTL may still be a duration with the defaulting webhook disabled
+type validatedRRset struct {
+	Metadata struct {
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Name    string              `json:"name"`
+		Type    string              `json:"type"`
+		ZoneRef dnsv1alpha2.ZoneRef `json:"zoneRef"`
+	} `json:"spec"`
+}
+
+func (v *RRsetValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var obj validatedRRset
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var old validatedRRset
+	if len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+	if isApprovalChanged(old.Metadata.Annotations, obj.Metadata.Annotations) {
+		approver, err := v.isApprover(ctx, req)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !approver {
+			return admission.Denied(fmt.Sprintf("metadata.annotations: %s is not allowed to %s %s", req.UserInfo.Username, APPROVE_VERB, req.Resource.Resource))
+		}
+	}
+	// The RRsets already overwriting the apex, admitted before, are kept updatable to be fixed or deleted
+	if isProtectedApex(obj) && !isProtectedApex(old) {
+		return admission.Denied(fmt.Sprintf("spec.name: the SOA and NS records of the zone apex are managed by the zone, "+
+			"the annotation %s allows to override them", APEX_OVERRIDE_ANNOTATION))
+	}
+	if req.Kind.Kind != "RRset" || obj.Spec.ZoneRef.Kind != "ClusterZone" {
+		return admission.Allowed("")
+	}
+	zone := &dnsv1alpha2.ClusterZone{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: obj.Spec.ZoneRef.Name}, zone); err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if zone.Spec.AllowedNamespaces == nil {
+		return admission.Allowed("")
+	}
+	namespace := &corev1.Namespace{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: req.Namespace}, namespace); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	allowed, err := dnsutil.IsNamespaceAllowed(zone.Spec.AllowedNamespaces, namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !allowed {
+		return admission.Denied(fmt.Sprintf("spec.zoneRef: namespace %s is not allowed by the ClusterZone %s", req.Namespace, zone.Name))
+	}
+	return admission.Allowed("")
+}
+
+// isProtectedApex return True if the RRset overwrites the SOA record or the NS records of the zone apex,
+// managed by the zone, without the override annotation
+func isProtectedApex(rrset validatedRRset) bool {
+	if !strings.EqualFold(rrset.Spec.Type, "SOA") && !strings.EqualFold(rrset.Spec.Type, "NS") {
+		return false
+	}
+	// A name not ending with a dot is relative to the zone, it cannot be the apex
+	return strings.HasSuffix(rrset.Spec.Name, ".") &&
+		strings.EqualFold(strings.TrimSuffix(rrset.Spec.Name, "."), strings.TrimSuffix(rrset.Spec.ZoneRef.Name, ".")) &&
+		rrset.Metadata.Annotations[APEX_OVERRIDE_ANNOTATION] != APEX_OVERRIDE_ANNOTATION_VALUE
+}
+
+// isApprovalChanged return True if the approval annotation is set, or set to another generation
+func isApprovalChanged(old map[string]string, annotations map[string]string) bool {
+	approval, ok := annotations[APPROVED_ANNOTATION]
+	return ok && approval != "" && approval != old[APPROVED_ANNOTATION]
+}
+
+// isApprover return True if the user of the request is granted the approve verb on the RRset, or ClusterRRset
+func (v *RRsetValidator) isApprover(ctx context.Context, req admission.Request) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: req.Namespace,
+				Verb:      APPROVE_VERB,
+				Group:     req.Resource.Group,
+				Resource:  req.Resource.Resource,
+				Name:      req.Name,
+			},
+		},
+	}
+	if err := v.Client.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestRRsetDefaulter(t *testing.T) {
+	var testCases = []struct {
+		description string
+		object      string
+		allowed     bool
+		patches     []jsonpatch.JsonPatchOperation
+	}{
+		{"TTL in seconds", `{"spec":{"ttl":300}}`, true, nil},
+		{"TTL as a duration", `{"spec":{"ttl":"5m"}}`, true, []jsonpatch.JsonPatchOperation{{Operation: "replace", Path: "/spec/ttl", Value: float64(300)}}},
+		{"Invalid TTL", `{"spec":{"ttl":"5y"}}`, false, nil},
+		{"No spec", `{}`, true, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Object: runtime.RawExtension{Raw: []byte(tc.object)}}}
+			resp := (&RRsetDefaulter{}).Handle(context.Background(), req)
+			if resp.Allowed != tc.allowed {
+				t.Errorf("got allowed %t, want %t", resp.Allowed, tc.allowed)
+			}
+			if !cmp.Equal(resp.Patches, tc.patches) {
+				t.Errorf("got patches %v, want %v", resp.Patches, tc.patches)
+			}
+		})
+	}
+}
+
+func TestIsApprovalChanged(t *testing.T) {
+	var testCases = []struct {
+		description string
+		old         map[string]string
+		annotations map[string]string
+		want        bool
+	}{
+		{"No approval", nil, map[string]string{"team": "web"}, false},
+		{"Approval set", nil, map[string]string{APPROVED_ANNOTATION: "2"}, true},
+		{"Approval unchanged", map[string]string{APPROVED_ANNOTATION: "2"}, map[string]string{APPROVED_ANNOTATION: "2"}, false},
+		{"Another generation approved", map[string]string{APPROVED_ANNOTATION: "2"}, map[string]string{APPROVED_ANNOTATION: "3"}, true},
+		{"Approval removed", map[string]string{APPROVED_ANNOTATION: "2"}, nil, false},
+		{"Empty approval", nil, map[string]string{APPROVED_ANNOTATION: ""}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := isApprovalChanged(tc.old, tc.annotations); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRRsetValidatorApex(t *testing.T) {
+	apex := `{"spec":{"name":"example.org.","type":"NS","zoneRef":{"name":"example.org","kind":"Zone"}}}`
+	var testCases = []struct {
+		description string
+		object      string
+		old         string
+		allowed     bool
+	}{
+		{"Apex NS", apex, "", false},
+		{"Apex SOA", `{"spec":{"name":"Example.org.","type":"soa","zoneRef":{"name":"example.org","kind":"Zone"}}}`, "", false},
+		{"Apex A", `{"spec":{"name":"example.org.","type":"A","zoneRef":{"name":"example.org","kind":"Zone"}}}`, "", true},
+		{"Delegation NS", `{"spec":{"name":"sub","type":"NS","zoneRef":{"name":"example.org","kind":"Zone"}}}`, "", true},
+		{"Relative name of the zone", `{"spec":{"name":"example.org","type":"NS","zoneRef":{"name":"example.org","kind":"Zone"}}}`, "", true},
+		{"Override annotation", `{"metadata":{"annotations":{"` + APEX_OVERRIDE_ANNOTATION + `":"true"}},"spec":{"name":"example.org.","type":"NS","zoneRef":{"name":"example.org","kind":"Zone"}}}`, "", true},
+		{"Already overwriting the apex", apex, apex, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Kind: "RRset"},
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+				OldObject: runtime.RawExtension{Raw: []byte(tc.old)},
+			}}
+			resp := (&RRsetValidator{}).Handle(context.Background(), req)
+			if resp.Allowed != tc.allowed {
+				t.Errorf("got allowed %t, want %t", resp.Allowed, tc.allowed)
+			}
+		})
+	}
+}
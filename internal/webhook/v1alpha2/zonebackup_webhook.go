@@ -0,0 +1,53 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/robfig/cron/v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dnsv1alpha2 "github.com/powerdns-operator/powerdns-operator/api/v1alpha2"
+)
+
+const ZONEBACKUP_VALIDATING_WEBHOOK_PATH = "/validate-dns-cav-enablers-ob-v1alpha2-zonebackup"
+
+// +kubebuilder:webhook:path=/validate-dns-cav-enablers-ob-v1alpha2-zonebackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=dns.cav.enablers.ob,resources=zonebackups,verbs=create;update,versions=v1alpha2,name=vzonebackup-v1alpha2.kb.io,admissionReviewVersions=v1
+
+// SetupZoneBackupWebhookWithManager registers the validating webhook of ZoneBackups in the manager
+func SetupZoneBackupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(ZONEBACKUP_VALIDATING_WEBHOOK_PATH, &webhook.Admission{Handler: &ZoneBackupValidator{}})
+	return nil
+}
+
+// ZoneBackupValidator rejects the ZoneBackups whose schedule is not in cron format
+type ZoneBackupValidator struct{}
+
+func (v *ZoneBackupValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	var obj dnsv1alpha2.ZoneBackup
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if obj.Spec.Schedule == nil {
+		return admission.Allowed("")
+	}
+	if _, err := cron.ParseStandard(*obj.Spec.Schedule); err != nil {
+		return admission.Denied(fmt.Sprintf("spec.schedule: %v", err))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,45 @@
+/*
+ * Software Name : PowerDNS-Operator
+ *
+ * SPDX-FileCopyrightText: Copyright (c) PowerDNS-Operator contributors
+ * SPDX-FileCopyrightText: Copyright (c) 2025 Orange Business Services SA
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * This software is distributed under the Apache 2.0 License,
+ * see the "LICENSE" file for more details
+ */
+
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestZoneBackupValidator(t *testing.T) {
+	var testCases = []struct {
+		description string
+		object      string
+		allowed     bool
+	}{
+		{"Without schedule", `{"spec":{"interval":"24h"}}`, true},
+		{"Valid schedule", `{"spec":{"schedule":"0 3 * * *"}}`, true},
+		{"Invalid schedule", `{"spec":{"schedule":"every day"}}`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Object: runtime.RawExtension{Raw: []byte(tc.object)},
+			}}
+			resp := (&ZoneBackupValidator{}).Handle(context.Background(), req)
+			if resp.Allowed != tc.allowed {
+				t.Errorf("got allowed %t, want %t", resp.Allowed, tc.allowed)
+			}
+		})
+	}
+}